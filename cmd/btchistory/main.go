@@ -2,12 +2,17 @@ package main
 
 import (
     "context"
+    "errors"
     "flag"
     "log"
     "time"
 
     "github.com/yourusername/investutil-gocrawler/internal/common/config"
+    cr "github.com/yourusername/investutil-gocrawler/internal/crawler"
     "github.com/yourusername/investutil-gocrawler/internal/crawler/crypto"
+    "github.com/yourusername/investutil-gocrawler/internal/httpcache"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
     "github.com/yourusername/investutil-gocrawler/internal/storage"
 )
 
@@ -15,7 +20,11 @@ type Config struct {
     Storage struct {
         MongoDB storage.MongoDBConfig `yaml:"mongodb"`
     } `yaml:"storage"`
-    Crawler crypto.Config `yaml:"crawler"`
+    Crawler   crypto.Config     `yaml:"crawler"`
+    HTTP      httpclient.Config `yaml:"http"`
+    HTTPCache httpcache.Config  `yaml:"http_cache"`
+    // Notifications reports failed and recovered runs
+    Notifications notify.RunConfig `yaml:"notifications"`
 }
 
 func main() {
@@ -40,16 +49,40 @@ func main() {
         }
     }()
 
+    // Initialize HTTP client with the response cache
+    client := httpclient.New(cfg.HTTP)
+    cache, err := httpcache.New(cfg.HTTPCache, mongoStorage)
+    if err != nil {
+        log.Fatalf("Failed to initialize HTTP cache: %v", err)
+    }
+    if cache != nil {
+        client.Use(httpcache.Middleware(cache))
+    }
+
+    runs, err := notify.NewRunNotifier(mongoStorage, cfg.Notifications)
+    if err != nil {
+        log.Fatalf("Invalid notifications config: %v", err)
+    }
+
     // Initialize crawler
-    crawler := crypto.NewBitcoinCrawler(mongoStorage, &cfg.Crawler)
+    crawler := crypto.NewBitcoinCrawler(mongoStorage, client, &cfg.Crawler)
 
     // Run crawler
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
     defer cancel()
 
-    if err := crawler.Crawl(ctx); err != nil {
+    start := time.Now()
+    err = crawler.Crawl(ctx)
+    if errors.Is(err, cr.ErrNoChange) {
+        log.Printf("Crawler %s completed: no change", crawler.Name())
+        err = nil
+    } else if err == nil {
+        log.Printf("Crawler %s completed successfully", crawler.Name())
+    }
+    if err := runs.Record(context.Background(), crawler.Name(), time.Since(start), err); err != nil {
+        log.Printf("Failed to notify run: %v", err)
+    }
+    if err != nil {
         log.Fatalf("Crawler failed: %v", err)
     }
-
-    log.Printf("Crawler %s completed successfully", crawler.Name())
 } 
\ No newline at end of file
@@ -0,0 +1,98 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "log"
+    "os"
+    "os/signal"
+    "syscall"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/backfill"
+    "github.com/yourusername/investutil-gocrawler/internal/common/config"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// runBackfill implements the backfill subcommand. It fills the days missing
+// from a crawler's stored data between -from and -to, or with -detect
+// between the first stored day and today.
+func runBackfill(args []string) {
+    fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+    commonConfig := fs.String("common-config", "configs/common.yaml", "path to common config file")
+    specificConfig := fs.String("config", "configs/crawler.yaml", "path to specific config file")
+    asset := fs.String("asset", "", "name of the crawler to backfill, e.g. bitcoin-history")
+    from := fs.String("from", "", "first day to check (YYYY-MM-DD)")
+    to := fs.String("to", "", "day after the last day to check (YYYY-MM-DD), defaults to today")
+    detect := fs.Bool("detect", false, "check every day from the first stored one to today")
+    chunkDays := fs.Int("chunk-days", 90, "maximum days requested at once")
+    delay := fs.Duration("delay", 2*time.Second, "pause between chunks")
+    fs.Parse(args)
+
+    if *asset == "" {
+        log.Fatalf("-asset is required")
+    }
+    if *detect == (*from != "") {
+        log.Fatalf("Exactly one of -from and -detect is required")
+    }
+
+    backfillCfg := backfill.Config{ChunkDays: *chunkDays, Delay: *delay}
+    var err error
+    if *from != "" {
+        if backfillCfg.From, err = time.Parse("2006-01-02", *from); err != nil {
+            log.Fatalf("Invalid -from: %v", err)
+        }
+    }
+    if *to != "" {
+        if backfillCfg.To, err = time.Parse("2006-01-02", *to); err != nil {
+            log.Fatalf("Invalid -to: %v", err)
+        }
+    }
+
+    // Load configs
+    var cfg Config
+    if err := config.LoadCommonConfig(*commonConfig, *specificConfig, &cfg); err != nil {
+        log.Fatalf("Failed to load configs: %v", err)
+    }
+
+    // Initialize MongoDB storage
+    mongoStorage, err := storage.NewMongoDBStorage(cfg.Storage.MongoDB)
+    if err != nil {
+        log.Fatalf("Failed to initialize MongoDB storage: %v", err)
+    }
+    defer func() {
+        if err := mongoStorage.Close(context.Background()); err != nil {
+            log.Printf("Failed to close MongoDB connection: %v", err)
+        }
+    }()
+
+    // Stop after the current chunk on interrupt; the next run resumes
+    ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer cancel()
+
+    registry := crawler.NewRegistry()
+    limiter, err := register(ctx, registry, mongoStorage, nil, &cfg)
+    if err != nil {
+        log.Fatalf("Failed to register crawlers: %v", err)
+    }
+    c, ok := registry.Get(*asset)
+    if !ok {
+        log.Fatalf("Unknown crawler: %s", *asset)
+    }
+    source, ok := c.(backfill.Source)
+    if !ok {
+        log.Fatalf("Crawler %s does not support backfill", *asset)
+    }
+
+    added, err := backfill.NewBackfiller(mongoStorage, source, backfillCfg).Run(ctx)
+    flushCtx, flushCancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer flushCancel()
+    if err := limiter.Flush(flushCtx); err != nil {
+        log.Printf("Failed to save request budgets: %v", err)
+    }
+    if err != nil {
+        log.Fatalf("Backfill failed after adding %d days: %v", added, err)
+    }
+    log.Printf("Backfill %s completed, added %d days", *asset, added)
+} 
\ No newline at end of file
@@ -0,0 +1,318 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "log"
+    "net/http"
+    "os"
+    "os/signal"
+    "syscall"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/cassette"
+    "github.com/yourusername/investutil-gocrawler/internal/common/config"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/analytics"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/crypto"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/jsonapi"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/onchain"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/reconcile"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/sentiment"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/web"
+    "github.com/yourusername/investutil-gocrawler/internal/httpcache"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/metrics"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
+    "github.com/yourusername/investutil-gocrawler/internal/proxy"
+    "github.com/yourusername/investutil-gocrawler/internal/ratelimit"
+    "github.com/yourusername/investutil-gocrawler/internal/scheduler"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
+)
+
+type Config struct {
+    Storage struct {
+        MongoDB storage.MongoDBConfig `yaml:"mongodb"`
+    } `yaml:"storage"`
+    Crawlers struct {
+        Bitcoin   *crypto.Config    `yaml:"bitcoin"`
+        FearGreed *sentiment.Config `yaml:"fear_greed"`
+        Onchain   *onchain.Config   `yaml:"onchain"`
+        Web       []web.Config      `yaml:"web"`
+    } `yaml:"crawlers"`
+    Sources   []jsonapi.SourceConfig `yaml:"sources"`
+    Reconcile []reconcile.Config     `yaml:"reconcile"`
+    Analytics []analytics.Config     `yaml:"analytics"`
+    HTTP      httpclient.Config      `yaml:"http"`
+    HTTPCache httpcache.Config       `yaml:"http_cache"`
+    Proxy     proxy.Config           `yaml:"proxy"`
+    RateLimit ratelimit.Config       `yaml:"rate_limit"`
+    Timeout   time.Duration          `yaml:"timeout"`
+    // Notifications reports failed and recovered runs
+    Notifications notify.RunConfig `yaml:"notifications"`
+    // Metrics serves the Prometheus metrics of the daemon
+    Metrics metrics.Config `yaml:"metrics"`
+    // Tracing exports a span per crawler run with its HTTP requests
+    Tracing tracing.Config `yaml:"tracing"`
+}
+
+func main() {
+    if len(os.Args) > 1 {
+        switch os.Args[1] {
+        case "backfill":
+            runBackfill(os.Args[2:])
+            return
+        case "resample":
+            runResample(os.Args[2:])
+            return
+        }
+    }
+
+    commonConfig := flag.String("common-config", "configs/common.yaml", "path to common config file")
+    specificConfig := flag.String("config", "configs/crawler.yaml", "path to specific config file")
+    runName := flag.String("run", "", "run the named crawler once and exit")
+    once := flag.Bool("once", false, "run every crawler once and exit")
+    cassettePath := flag.String("cassette", "", "replay HTTP responses from this cassette, using in-memory storage")
+    record := flag.Bool("record", false, "record the cassette from the live APIs instead of replaying it")
+    flag.Parse()
+
+    // Load configs
+    var cfg Config
+    if err := config.LoadCommonConfig(*commonConfig, *specificConfig, &cfg); err != nil {
+        log.Fatalf("Failed to load configs: %v", err)
+    }
+    if cfg.Timeout == 0 {
+        cfg.Timeout = 5 * time.Minute
+    }
+
+    // Cassette runs are offline and start from empty storage, so they are
+    // deterministic
+    var cas *cassette.Cassette
+    var store storage.Storage
+    if *cassettePath != "" {
+        mode := cassette.ModeReplay
+        if *record {
+            mode = cassette.ModeRecord
+        }
+        var err error
+        if cas, err = cassette.Load(*cassettePath, mode); err != nil {
+            log.Fatalf("Failed to load cassette: %v", err)
+        }
+        store = storage.NewMemoryStorage()
+        defer func() {
+            if err := cas.Save(); err != nil {
+                log.Printf("Failed to save cassette: %v", err)
+            }
+        }()
+    } else {
+        // Initialize MongoDB storage
+        mongoStorage, err := storage.NewMongoDBStorage(cfg.Storage.MongoDB)
+        if err != nil {
+            log.Fatalf("Failed to initialize MongoDB storage: %v", err)
+        }
+        defer func() {
+            if err := mongoStorage.Close(context.Background()); err != nil {
+                log.Printf("Failed to close MongoDB connection: %v", err)
+            }
+        }()
+        store = mongoStorage
+    }
+
+    // Cassette runs are offline, so they export no spans
+    if cas == nil {
+        shutdownTracing, err := tracing.Setup(cfg.Tracing, "investutil-crawler")
+        if err != nil {
+            log.Fatalf("Failed to initialize tracing: %v", err)
+        }
+        defer func() {
+            ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+            defer cancel()
+            if err := shutdownTracing(ctx); err != nil {
+                log.Printf("Failed to flush traces: %v", err)
+            }
+        }()
+    }
+
+    // Setup signal handling
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    sigChan := make(chan os.Signal, 1)
+    signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+    go func() {
+        <-sigChan
+        cancel()
+    }()
+
+    // Register crawlers
+    registry := crawler.NewRegistry()
+    limiter, err := register(ctx, registry, store, cas, &cfg)
+    if err != nil {
+        log.Fatalf("Failed to register crawlers: %v", err)
+    }
+    flushBudgets := func() {
+        ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+        defer cancel()
+        if err := limiter.Flush(ctx); err != nil {
+            log.Printf("Failed to save request budgets: %v", err)
+        }
+    }
+    defer flushBudgets()
+    sched := scheduler.NewScheduler(registry, cfg.Timeout)
+
+    // Cassette runs are offline, so they notify nobody
+    if cas == nil {
+        runs, err := notify.NewRunNotifier(store, cfg.Notifications)
+        if err != nil {
+            log.Fatalf("Invalid notifications config: %v", err)
+        }
+        if runs != nil {
+            sched.OnRun(func(ctx context.Context, name string, duration time.Duration, err error) {
+                if err := runs.Record(ctx, name, duration, err); err != nil {
+                    log.Printf("Failed to notify run of %s: %v", name, err)
+                }
+            })
+        }
+    }
+
+    switch {
+    case *runName != "":
+        c, ok := registry.Get(*runName)
+        if !ok {
+            log.Fatalf("Unknown crawler: %s", *runName)
+        }
+        if err := sched.Run(ctx, c); err != nil {
+            flushBudgets()
+            log.Fatalf("Crawler failed: %v", err)
+        }
+
+    case *once:
+        failed := false
+        for _, c := range registry.All() {
+            if err := sched.Run(ctx, c); err != nil {
+                log.Printf("Crawler %s failed: %v", c.Name(), err)
+                failed = true
+            }
+        }
+        if failed {
+            flushBudgets()
+            os.Exit(1)
+        }
+
+    default:
+        if cfg.Metrics.Addr != "" {
+            go func() {
+                if err := metrics.ListenAndServe(ctx, cfg.Metrics); err != nil {
+                    log.Printf("Metrics endpoint failed: %v", err)
+                }
+            }()
+            log.Printf("Serving metrics on %s", cfg.Metrics.Addr)
+        }
+        if err := sched.Start(ctx); err != nil {
+            log.Fatalf("Failed to start scheduler: %v", err)
+        }
+        <-ctx.Done()
+        log.Printf("Shutting down, waiting for running crawlers")
+        sched.Stop()
+    }
+}
+
+// register creates every configured crawler and adds it to the registry.
+// All crawlers share one HTTP client and with it the proxy pool, the
+// response cache, the per-host rate limits and request budgets. A non-nil
+// cassette replaces or records the network. It returns the limiter, whose
+// budgets are flushed on shutdown.
+func register(ctx context.Context, registry *crawler.Registry, s storage.Storage, cas *cassette.Cassette, cfg *Config) (*ratelimit.Limiter, error) {
+    limiter := ratelimit.NewLimiter(cfg.RateLimit, s)
+    for i := range cfg.Sources {
+        if cfg.Sources[i].RateLimit == nil {
+            continue
+        }
+        host, err := cfg.Sources[i].Host()
+        if err != nil {
+            return nil, err
+        }
+        limiter.SetHost(host, *cfg.Sources[i].RateLimit)
+    }
+
+    var transport http.RoundTripper = http.DefaultTransport
+    if len(cfg.Proxy.URLs) > 0 {
+        pool, err := proxy.NewPool(cfg.Proxy)
+        if err != nil {
+            return nil, err
+        }
+        pool.Start(ctx)
+        transport = pool
+    }
+    if cas != nil {
+        transport = cas.Transport(transport)
+    }
+    client := httpclient.NewWithTransport(cfg.HTTP, transport)
+    client.Use(limiter.Middleware())
+
+    // Cached validators would turn recorded responses into 304s
+    if cas == nil {
+        cache, err := httpcache.New(cfg.HTTPCache, s)
+        if err != nil {
+            return nil, err
+        }
+        if cache != nil {
+            client.Use(httpcache.Middleware(cache))
+        }
+    }
+
+    if cfg.Crawlers.Bitcoin != nil {
+        if err := registry.Register(crypto.NewBitcoinCrawler(s, client, cfg.Crawlers.Bitcoin)); err != nil {
+            return nil, err
+        }
+    }
+    if cfg.Crawlers.FearGreed != nil {
+        c := sentiment.NewSentimentCrawler(s, sentiment.NewFearGreedIndex(client, cfg.Crawlers.FearGreed.BaseURL), cfg.Crawlers.FearGreed)
+        if err := registry.Register(c); err != nil {
+            return nil, err
+        }
+    }
+    if cfg.Crawlers.Onchain != nil {
+        if err := registry.Register(onchain.NewBlockchainCrawler(s, client, cfg.Crawlers.Onchain)); err != nil {
+            return nil, err
+        }
+    }
+    for i := range cfg.Crawlers.Web {
+        c, err := web.NewWebCrawler(s, client, &cfg.Crawlers.Web[i])
+        if err != nil {
+            return nil, err
+        }
+        if err := registry.Register(c); err != nil {
+            return nil, err
+        }
+    }
+    for i := range cfg.Sources {
+        c, err := jsonapi.NewSourceCrawler(s, client, &cfg.Sources[i])
+        if err != nil {
+            return nil, err
+        }
+        if err := registry.Register(c); err != nil {
+            return nil, err
+        }
+    }
+    for i := range cfg.Reconcile {
+        j, err := reconcile.NewJob(s, &cfg.Reconcile[i])
+        if err != nil {
+            return nil, err
+        }
+        if err := registry.Register(j); err != nil {
+            return nil, err
+        }
+    }
+    for i := range cfg.Analytics {
+        j, err := analytics.NewJob(s, &cfg.Analytics[i])
+        if err != nil {
+            return nil, err
+        }
+        if err := registry.Register(j); err != nil {
+            return nil, err
+        }
+    }
+    return limiter, nil
+} 
\ No newline at end of file
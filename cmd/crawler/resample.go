@@ -0,0 +1,135 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "flag"
+    "fmt"
+    "log"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/common/config"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// runResample implements the resample subcommand. It resamples the series
+// stored under -prefix to calendar periods and prints the bars as JSON, or
+// with -store materializes them next to the series.
+func runResample(args []string) {
+    fs := flag.NewFlagSet("resample", flag.ExitOnError)
+    commonConfig := fs.String("common-config", "configs/common.yaml", "path to common config file")
+    specificConfig := fs.String("config", "configs/crawler.yaml", "path to specific config file")
+    prefix := fs.String("prefix", "", "storage prefix of the series, e.g. crypto/bitcoin")
+    format := fs.String("format", "series", "stored format: series or bitcoin")
+    periods := fs.String("period", "month", "comma separated periods: day, week, month, quarter or year")
+    timezone := fs.String("tz", "UTC", "timezone of the period boundaries")
+    from := fs.String("from", "", "first period to print (YYYY-MM-DD)")
+    to := fs.String("to", "", "day after the last period to print (YYYY-MM-DD)")
+    store := fs.Bool("store", false, "store the bars under <prefix>/bars/<period>/ instead of printing them")
+    fs.Parse(args)
+
+    if *prefix == "" {
+        log.Fatalf("-prefix is required")
+    }
+    resampleCfg := resample.Config{Periods: strings.Split(*periods, ","), Timezone: *timezone}
+    if err := resampleCfg.Validate(); err != nil {
+        log.Fatalf("Invalid options: %v", err)
+    }
+    loc, _ := resample.LoadLocation(*timezone)
+    var fromTime, toTime time.Time
+    var err error
+    if *from != "" {
+        if fromTime, err = time.ParseInLocation("2006-01-02", *from, loc); err != nil {
+            log.Fatalf("Invalid -from: %v", err)
+        }
+    }
+    if *to != "" {
+        if toTime, err = time.ParseInLocation("2006-01-02", *to, loc); err != nil {
+            log.Fatalf("Invalid -to: %v", err)
+        }
+    }
+
+    // Load configs
+    var cfg Config
+    if err := config.LoadCommonConfig(*commonConfig, *specificConfig, &cfg); err != nil {
+        log.Fatalf("Failed to load configs: %v", err)
+    }
+
+    // Initialize MongoDB storage
+    mongoStorage, err := storage.NewMongoDBStorage(cfg.Storage.MongoDB)
+    if err != nil {
+        log.Fatalf("Failed to initialize MongoDB storage: %v", err)
+    }
+    defer func() {
+        if err := mongoStorage.Close(context.Background()); err != nil {
+            log.Printf("Failed to close MongoDB connection: %v", err)
+        }
+    }()
+
+    ctx := context.Background()
+    metric, points, err := loadPoints(ctx, mongoStorage, *prefix, *format)
+    if err != nil {
+        log.Fatalf("Failed to load %s: %v", *prefix, err)
+    }
+    if len(points) == 0 {
+        log.Fatalf("No data stored under %s", *prefix)
+    }
+
+    if *store {
+        if err := resample.Materialize(ctx, mongoStorage, *prefix, metric, points, resampleCfg); err != nil {
+            log.Fatalf("Failed to store bars: %v", err)
+        }
+        log.Printf("Stored %s bars of %s", *periods, *prefix)
+        return
+    }
+
+    enc := json.NewEncoder(os.Stdout)
+    enc.SetIndent("", "  ")
+    for _, period := range resampleCfg.Periods {
+        bars, err := resample.Resample(points, period, loc)
+        if err != nil {
+            log.Fatalf("Failed to resample: %v", err)
+        }
+        series := models.AggregateSeries{
+            LastUpdated: time.Now().UTC(),
+            Metric:      metric,
+            Period:      period,
+            Timezone:    loc.String(),
+        }
+        for _, bar := range bars {
+            if (fromTime.IsZero() || !bar.Start.Before(fromTime)) && (toTime.IsZero() || bar.Start.Before(toTime)) {
+                series.Bars = append(series.Bars, bar)
+            }
+        }
+        if err := enc.Encode(series); err != nil {
+            log.Fatalf("Failed to write bars: %v", err)
+        }
+    }
+}
+
+// loadPoints reads the series stored under prefix in the given format
+func loadPoints(ctx context.Context, s storage.Storage, prefix, format string) (string, []resample.Point, error) {
+    switch format {
+    case "bitcoin":
+        var data models.BitcoinDailyData
+        key := fmt.Sprintf("%s/latest.json", prefix)
+        if err := s.Load(ctx, key, &data); err != nil && !errors.Is(err, storage.ErrNotFound) {
+            return "", nil, err
+        }
+        return "btc", resample.FromBitcoin(data.Data), nil
+    case "series":
+        series, err := crawler.LoadSeries(ctx, s, prefix)
+        if err != nil {
+            return "", nil, err
+        }
+        return series.Metric, resample.FromSeries(series.Data), nil
+    default:
+        return "", nil, fmt.Errorf("unknown format %q", format)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,60 @@
+package main
+
+import (
+    "context"
+    "log"
+    "os"
+    "os/signal"
+    "syscall"
+
+    "github.com/yourusername/investutil-gocrawler/internal/api"
+    "github.com/yourusername/investutil-gocrawler/internal/config"
+    "github.com/yourusername/investutil-gocrawler/internal/metrics"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// runAPI serves the read-only REST API over the series in storage until
+// interrupted
+func runAPI(cfg *config.Config) {
+    // Initialize MongoDB storage
+    mongoStorage, err := storage.NewMongoDBStorage(cfg.Storage.MongoDB)
+    if err != nil {
+        log.Fatalf("Failed to initialize MongoDB storage: %v", err)
+    }
+    defer func() {
+        if err := mongoStorage.Close(context.Background()); err != nil {
+            log.Printf("Failed to close MongoDB connection: %v", err)
+        }
+    }()
+
+    server, err := api.NewServer(mongoStorage, cfg.API)
+    if err != nil {
+        log.Fatalf("Failed to create API server: %v", err)
+    }
+
+    // Setup signal handling
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    sigChan := make(chan os.Signal, 1)
+    signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+    go func() {
+        <-sigChan
+        cancel()
+    }()
+
+    if cfg.Metrics.Addr != "" {
+        go func() {
+            if err := metrics.ListenAndServe(ctx, cfg.Metrics); err != nil {
+                log.Printf("Metrics endpoint failed: %v", err)
+            }
+        }()
+        log.Printf("Serving metrics on %s", cfg.Metrics.Addr)
+    }
+
+    log.Printf("Serving API on %s", server.Addr())
+    if err := server.ListenAndServe(ctx); err != nil {
+        log.Fatalf("API server failed: %v", err)
+    }
+    log.Printf("API server stopped")
+} 
\ No newline at end of file
@@ -9,15 +9,24 @@ import (
     "syscall"
     "time"
 
+    "github.com/yourusername/investutil-gocrawler/internal/alerts"
     "github.com/yourusername/investutil-gocrawler/internal/collector"
     "github.com/yourusername/investutil-gocrawler/internal/config"
     "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/events"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/indicators"
+    "github.com/yourusername/investutil-gocrawler/internal/metrics"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
     "github.com/yourusername/investutil-gocrawler/internal/queue"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
 )
 
 func main() {
     configPath := flag.String("config", "config.yaml", "path to config file")
-    mode := flag.String("mode", "collect", "operation mode: collect or process")
+    mode := flag.String("mode", "collect", "operation mode: collect, process or api")
+    collectorName := flag.String("collector", "bitcoin-price", "collector to run: bitcoin-price, orderbook or stream")
     flag.Parse()
 
     // Load configuration
@@ -26,6 +35,22 @@ func main() {
         log.Fatalf("Failed to load config: %v", err)
     }
 
+    // The API reads from the storage layer and needs neither the collector
+    // database nor the queue
+    if *mode == "api" {
+        runAPI(cfg)
+        return
+    }
+
+    // Spans are exported when a tracing endpoint is configured. The
+    // collector and the processor are separate services joined by the trace
+    // context in the message headers.
+    shutdownTracing, err := tracing.Setup(cfg.Tracing, "investutil-"+*mode)
+    if err != nil {
+        log.Fatalf("Failed to initialize tracing: %v", err)
+    }
+    defer flushTracing(shutdownTracing)
+
     // Initialize MongoDB
     db, err := database.NewMongoDB(cfg.Database.MongoDB)
     if err != nil {
@@ -39,15 +64,88 @@ func main() {
         }
     }()
 
+    // The processor updates the indicators of the prices it stores, evaluates
+    // the alert rules on them and announces the new points to push
+    // subscribers. Indicators and alert state live in the storage layer.
+    client := httpclient.New(cfg.HTTP)
+    var store database.Database = db
+    if *mode == "process" && (cfg.Indicators.Prefix != "" || cfg.Alerts.Prefix != "") {
+        mongoStorage, err := storage.NewMongoDBStorage(cfg.Storage.MongoDB)
+        if err != nil {
+            log.Fatalf("Failed to initialize MongoDB storage: %v", err)
+        }
+        defer func() {
+            if err := mongoStorage.Close(context.Background()); err != nil {
+                log.Printf("Failed to close MongoDB connection: %v", err)
+            }
+        }()
+        if cfg.Indicators.Prefix != "" {
+            if err := cfg.Indicators.Validate(); err != nil {
+                log.Fatalf("Invalid indicators config: %v", err)
+            }
+            pipeline := indicators.NewPipeline(mongoStorage, cfg.Indicators.Prefix, cfg.Indicators.Config)
+            store = indicators.NewDatabase(store, pipeline)
+        }
+        if cfg.Alerts.Prefix != "" {
+            if err := cfg.Alerts.Validate(); err != nil {
+                log.Fatalf("Invalid alerts config: %v", err)
+            }
+            engine := alerts.NewEngine(mongoStorage, cfg.Alerts.Prefix, cfg.Alerts.Config)
+            store = alerts.NewDatabase(store, engine)
+        }
+    }
+    // Failed collections are reported to the notification sinks, which count
+    // the failures in a row in the storage layer
+    var runs *notify.RunNotifier
+    if *mode == "collect" && len(cfg.Notifications.Sinks) > 0 {
+        mongoStorage, err := storage.NewMongoDBStorage(cfg.Storage.MongoDB)
+        if err != nil {
+            log.Fatalf("Failed to initialize MongoDB storage: %v", err)
+        }
+        defer func() {
+            if err := mongoStorage.Close(context.Background()); err != nil {
+                log.Printf("Failed to close MongoDB connection: %v", err)
+            }
+        }()
+        if runs, err = notify.NewRunNotifier(mongoStorage, cfg.Notifications); err != nil {
+            log.Fatalf("Invalid notifications config: %v", err)
+        }
+    }
+    var push *events.PushServer
+    if *mode == "process" && cfg.Push.Addr != "" {
+        bus := events.NewBus(cfg.Push.Buffer, cfg.Push.Replay)
+        store = events.NewDatabase(store, bus)
+        push = events.NewPushServer(bus, cfg.Push)
+    }
+
+    // Each collector publishes to its own queue
+    queueConfig := cfg.Queue.RabbitMQ
+    switch *collectorName {
+    case "orderbook":
+        queueConfig = cfg.Queue.OrderBook
+    case "stream":
+        queueConfig = cfg.Queue.Stream
+    }
+
     // Initialize RabbitMQ
-    rmq, err := queue.NewRabbitMQ(cfg.Queue.RabbitMQ)
+    rmq, err := queue.NewRabbitMQ(queueConfig)
     if err != nil {
         log.Fatalf("Failed to initialize RabbitMQ: %v", err)
     }
     defer rmq.Close()
 
-    // Initialize Bitcoin collector
-    btcCollector := collector.NewBitcoinCollector(db, rmq, cfg.Collector.Schedule)
+    // Initialize collector
+    var dataCollector collector.Collector
+    switch *collectorName {
+    case "bitcoin-price":
+        dataCollector = collector.NewBitcoinCollector(store, rmq, client, cfg.Collector.Schedule, cfg.Collector.BaseURL)
+    case "orderbook":
+        dataCollector = collector.NewOrderBookCollector(store, rmq, client, &cfg.Collector.OrderBook)
+    case "stream":
+        dataCollector = collector.NewStreamCollector(store, rmq, &cfg.Collector.Stream)
+    default:
+        log.Fatalf("Unknown collector: %s", *collectorName)
+    }
 
     // Setup signal handling
     ctx, cancel := context.WithCancel(context.Background())
@@ -60,23 +158,55 @@ func main() {
         cancel()
     }()
 
+    if cfg.Metrics.Addr != "" {
+        go func() {
+            if err := metrics.ListenAndServe(ctx, cfg.Metrics); err != nil {
+                log.Printf("Metrics endpoint failed: %v", err)
+            }
+        }()
+        log.Printf("Serving metrics on %s", cfg.Metrics.Addr)
+    }
+
     // Run in specified mode
     switch *mode {
     case "collect":
-        log.Printf("Starting collector: %s", btcCollector.Name())
-        if err := btcCollector.Collect(ctx); err != nil {
+        log.Printf("Starting collector: %s", dataCollector.Name())
+        start := time.Now()
+        err := dataCollector.Collect(ctx)
+        if err := runs.Record(context.Background(), dataCollector.Name(), time.Since(start), err); err != nil {
+            log.Printf("Failed to notify run: %v", err)
+        }
+        if err != nil {
+            flushTracing(shutdownTracing)
             log.Fatalf("Collector failed: %v", err)
         }
-        log.Printf("Collector %s completed successfully", btcCollector.Name())
+        log.Printf("Collector %s completed successfully", dataCollector.Name())
 
     case "process":
-        log.Printf("Starting processor: %s", btcCollector.Name())
-        if err := btcCollector.Process(ctx); err != nil {
+        if push != nil {
+            go func() {
+                if err := push.ListenAndServe(ctx); err != nil {
+                    log.Fatalf("Push server failed: %v", err)
+                }
+            }()
+            log.Printf("Pushing stored points on %s", cfg.Push.Addr)
+        }
+        log.Printf("Starting processor: %s", dataCollector.Name())
+        if err := dataCollector.Process(ctx); err != nil {
             log.Fatalf("Processor failed: %v", err)
         }
-        log.Printf("Processor %s completed successfully", btcCollector.Name())
+        log.Printf("Processor %s completed successfully", dataCollector.Name())
 
     default:
         log.Fatalf("Unknown mode: %s", *mode)
     }
+}
+
+// flushTracing exports the spans still buffered and stops tracing
+func flushTracing(shutdown func(context.Context) error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := shutdown(ctx); err != nil {
+        log.Printf("Failed to flush traces: %v", err)
+    }
 } 
\ No newline at end of file
@@ -0,0 +1,71 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "log"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/common/config"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler/onchain"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+type Config struct {
+    Storage struct {
+        MongoDB storage.MongoDBConfig `yaml:"mongodb"`
+    } `yaml:"storage"`
+    Crawler onchain.Config    `yaml:"crawler"`
+    HTTP    httpclient.Config `yaml:"http"`
+    // Notifications reports failed and recovered runs
+    Notifications notify.RunConfig `yaml:"notifications"`
+}
+
+func main() {
+    commonConfig := flag.String("common-config", "configs/common.yaml", "path to common config file")
+    specificConfig := flag.String("config", "configs/onchain.yaml", "path to specific config file")
+    flag.Parse()
+
+    // Load configs
+    var cfg Config
+    if err := config.LoadCommonConfig(*commonConfig, *specificConfig, &cfg); err != nil {
+        log.Fatalf("Failed to load configs: %v", err)
+    }
+
+    // Initialize MongoDB storage
+    mongoStorage, err := storage.NewMongoDBStorage(cfg.Storage.MongoDB)
+    if err != nil {
+        log.Fatalf("Failed to initialize MongoDB storage: %v", err)
+    }
+    defer func() {
+        if err := mongoStorage.Close(context.Background()); err != nil {
+            log.Printf("Failed to close MongoDB connection: %v", err)
+        }
+    }()
+
+    client := httpclient.New(cfg.HTTP)
+    runs, err := notify.NewRunNotifier(mongoStorage, cfg.Notifications)
+    if err != nil {
+        log.Fatalf("Invalid notifications config: %v", err)
+    }
+
+    // Initialize crawler
+    crawler := onchain.NewBlockchainCrawler(mongoStorage, client, &cfg.Crawler)
+
+    // Run crawler
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+    defer cancel()
+
+    start := time.Now()
+    err = crawler.Crawl(ctx)
+    if err := runs.Record(context.Background(), crawler.Name(), time.Since(start), err); err != nil {
+        log.Printf("Failed to notify run: %v", err)
+    }
+    if err != nil {
+        log.Fatalf("Crawler failed: %v", err)
+    }
+
+    log.Printf("Crawler %s completed successfully", crawler.Name())
+} 
\ No newline at end of file
@@ -3,7 +3,16 @@ module github.com/yourusername/investutil-gocrawler
 go 1.21
 
 require (
+	github.com/gocolly/colly/v2 v2.1.0
+	github.com/gorilla/websocket v1.5.0
+	github.com/graph-gophers/graphql-go v1.5.0
+	github.com/prometheus/client_golang v1.19.0
 	go.mongodb.org/mongo-driver v1.13.1
 	gopkg.in/yaml.v3 v3.0.1
 	github.com/robfig/cron/v3 v3.0.1
+	go.opentelemetry.io/otel v1.26.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.26.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.26.0
+	go.opentelemetry.io/otel/sdk v1.26.0
+	go.opentelemetry.io/otel/trace v1.26.0
 ) 
\ No newline at end of file
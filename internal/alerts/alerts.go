@@ -0,0 +1,268 @@
+package alerts
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "sort"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/indicators"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+const defaultCooldown = time.Hour
+
+// Config holds the alert rules of a price series and where alerts go
+type Config struct {
+    // Asset names the series in the alerts, defaults to bitcoin
+    Asset string `yaml:"asset"`
+    // Cooldown is the default minimum time between two alerts of a rule,
+    // defaults to an hour
+    Cooldown time.Duration `yaml:"cooldown"`
+    Rules    []Rule        `yaml:"rules"`
+    Notify   notify.Config `yaml:"notify"`
+}
+
+// Validate checks the rules and the sinks
+func (c Config) Validate() error {
+    if err := c.Notify.Validate(); err != nil {
+        return fmt.Errorf("alerts: %w", err)
+    }
+    if len(c.Notify.Sinks) == 0 {
+        return fmt.Errorf("alerts: at least one notify sink is required")
+    }
+    seen := make(map[string]bool, len(c.Rules))
+    for _, r := range c.Rules {
+        if err := r.validate(c.Notify); err != nil {
+            return fmt.Errorf("alerts: %w", err)
+        }
+        if seen[r.Name] {
+            return fmt.Errorf("alerts: rule %s is declared twice", r.Name)
+        }
+        seen[r.Name] = true
+    }
+    return nil
+}
+
+// Alert is a fired rule
+type Alert struct {
+    Rule      string    `json:"rule"`
+    Type      string    `json:"type"`
+    Asset     string    `json:"asset"`
+    Timestamp time.Time `json:"timestamp"`
+    Value     float64   `json:"value"`
+    Message   string    `json:"message"`
+}
+
+// ruleState is what is remembered of a rule between evaluations
+type ruleState struct {
+    // Active reports whether the condition held at the last evaluation
+    Active bool `json:"active" bson:"active"`
+    // Side is the side of the level a cross rule's price was last on
+    Side string `json:"side,omitempty" bson:"side,omitempty"`
+    // High is the highest value an ATH rule has seen
+    High      float64   `json:"high,omitempty" bson:"high,omitempty"`
+    LastFired time.Time `json:"last_fired" bson:"last_fired"`
+    // Evaluated is the timestamp of the latest point at the last evaluation
+    Evaluated time.Time `json:"evaluated" bson:"evaluated"`
+    // Pending is the last alert until every sink of the rule received it,
+    // and Delivered lists the sinks that did
+    Pending   *Alert   `json:"pending,omitempty" bson:"pending,omitempty"`
+    Delivered []string `json:"delivered,omitempty" bson:"delivered,omitempty"`
+}
+
+// Engine evaluates the rules after each ingest of a price series and
+// notifies the sinks. A rule fires once per crossing or new high, and a
+// condition such as a change or an indicator threshold fires when it starts
+// to hold; either way no more often than its cooldown.
+type Engine struct {
+    storage  storage.Storage
+    prefix   string
+    config   Config
+    notifier *notify.Notifier
+    err      error
+
+    mu sync.Mutex
+}
+
+// NewEngine creates a new Engine for the series under prefix, whose
+// indicators are read from <prefix>/indicators/ and whose state is kept in
+// <prefix>/alerts/state.json. A configuration error is returned by Evaluate.
+func NewEngine(s storage.Storage, prefix string, config Config) *Engine {
+    if config.Asset == "" {
+        config.Asset = "bitcoin"
+    }
+    if config.Cooldown <= 0 {
+        config.Cooldown = defaultCooldown
+    }
+    e := &Engine{
+        storage: s,
+        prefix:  prefix,
+        config:  config,
+    }
+    if e.err = config.Validate(); e.err == nil {
+        e.notifier, e.err = notify.New(config.Notify)
+    }
+    return e
+}
+
+// FromBitcoin converts the Bitcoin price history to points
+func FromBitcoin(prices []models.BitcoinPrice) []models.MetricPoint {
+    points := make([]models.MetricPoint, 0, len(prices))
+    for _, p := range prices {
+        points = append(points, models.MetricPoint{Timestamp: p.Timestamp, Value: p.Price})
+    }
+    return points
+}
+
+func (e *Engine) stateKey() string {
+    return fmt.Sprintf("%s/alerts/state.json", e.prefix)
+}
+
+// Evaluate checks every rule against points, the history of the series, and
+// returns the alerts that were delivered to all of their sinks. The sinks an
+// alert could not be delivered to are tried again at the next evaluation.
+func (e *Engine) Evaluate(ctx context.Context, points []models.MetricPoint) ([]Alert, error) {
+    if e.err != nil {
+        return nil, e.err
+    }
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    points = append([]models.MetricPoint(nil), points...)
+    sort.SliceStable(points, func(i, j int) bool {
+        return points[i].Timestamp.Before(points[j].Timestamp)
+    })
+
+    states := make(map[string]ruleState)
+    if err := e.storage.Load(ctx, e.stateKey(), &states); err != nil && !errors.Is(err, storage.ErrNotFound) {
+        return nil, fmt.Errorf("failed to load alert state: %w", err)
+    }
+
+    var fired []Alert
+    var errs []error
+    now := time.Now().UTC()
+    for _, r := range e.config.Rules {
+        st := states[r.Name]
+
+        // Finish the delivery of an earlier alert first
+        if st.Pending != nil {
+            if err := e.deliver(ctx, r, &st); err != nil {
+                errs = append(errs, fmt.Errorf("rule %s: %w", r.Name, err))
+            } else {
+                fired = append(fired, *st.Pending)
+                st.Pending, st.Delivered = nil, nil
+            }
+            states[r.Name] = st
+        }
+
+        var indicator []models.MetricPoint
+        if r.Type == RuleIndicator {
+            series, err := crawler.LoadSeries(ctx, e.storage, indicators.Key(e.prefix, r.Indicator))
+            if err != nil {
+                errs = append(errs, fmt.Errorf("rule %s: %w", r.Name, err))
+                continue
+            }
+            indicator = series.Data
+        }
+
+        t := r.evaluate(e.config.Asset, points, indicator, st)
+        if t.at.IsZero() {
+            continue
+        }
+        fire := t.active
+        if !t.edge {
+            fire = t.active && !st.Active
+        }
+        st.Active, st.Side, st.High = t.active, t.side, t.high
+        st.Evaluated = points[len(points)-1].Timestamp
+        states[r.Name] = st
+        if !fire {
+            continue
+        }
+
+        cooldown := r.Cooldown
+        if cooldown <= 0 {
+            cooldown = e.config.Cooldown
+        }
+        if !st.LastFired.IsZero() && now.Sub(st.LastFired) < cooldown {
+            log.Printf("Alert %s suppressed, it fired %s ago", r.Name, now.Sub(st.LastFired).Round(time.Second))
+            continue
+        }
+
+        alert := Alert{
+            Rule:      r.Name,
+            Type:      r.Type,
+            Asset:     e.config.Asset,
+            Timestamp: t.at,
+            Value:     t.value,
+            Message:   t.text,
+        }
+        log.Printf("Alert %s: %s", r.Name, t.text)
+        st.LastFired = now
+        st.Pending, st.Delivered = &alert, nil
+        if err := e.deliver(ctx, r, &st); err != nil {
+            errs = append(errs, fmt.Errorf("rule %s: %w", r.Name, err))
+        } else {
+            fired = append(fired, alert)
+            st.Pending, st.Delivered = nil, nil
+        }
+        states[r.Name] = st
+    }
+
+    if err := e.storage.Save(ctx, e.stateKey(), states); err != nil {
+        errs = append(errs, fmt.Errorf("failed to save alert state: %w", err))
+    }
+    return fired, errors.Join(errs...)
+}
+
+// deliver sends the pending alert of st to the sinks of r it has not been
+// delivered to yet and records the sinks that received it
+func (e *Engine) deliver(ctx context.Context, r Rule, st *ruleState) error {
+    sinks := r.Notify
+    if len(sinks) == 0 {
+        sinks = e.notifier.Sinks()
+    }
+    var remaining []string
+    for _, name := range sinks {
+        if !contains(st.Delivered, name) {
+            remaining = append(remaining, name)
+        }
+    }
+    if len(remaining) == 0 {
+        return nil
+    }
+    delivered, err := e.notifier.NotifyEach(ctx, message(*st.Pending), remaining...)
+    st.Delivered = append(st.Delivered, delivered...)
+    return err
+}
+
+func contains(names []string, name string) bool {
+    for _, n := range names {
+        if n == name {
+            return true
+        }
+    }
+    return false
+}
+
+// message formats an alert for the sinks
+func message(a Alert) notify.Message {
+    return notify.Message{
+        Subject: fmt.Sprintf("[%s] %s", a.Rule, a.Message),
+        Text:    a.Message,
+        Fields: map[string]interface{}{
+            "rule":      a.Rule,
+            "type":      a.Type,
+            "asset":     a.Asset,
+            "value":     a.Value,
+            "timestamp": a.Timestamp.UTC().Format(time.RFC3339),
+        },
+    }
+} 
\ No newline at end of file
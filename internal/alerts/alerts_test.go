@@ -0,0 +1,173 @@
+package alerts
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// webhook is a stand-in webhook sink counting the alerts it received
+type webhook struct {
+    *httptest.Server
+
+    mu    sync.Mutex
+    calls int
+    fail  bool
+}
+
+func newWebhook(t *testing.T) *webhook {
+    w := &webhook{}
+    w.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+        w.mu.Lock()
+        defer w.mu.Unlock()
+        w.calls++
+        if w.fail {
+            rw.WriteHeader(http.StatusBadGateway)
+        }
+    }))
+    t.Cleanup(w.Close)
+    return w
+}
+
+func (w *webhook) count() int {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    return w.calls
+}
+
+func (w *webhook) setFail(fail bool) {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    w.fail = fail
+}
+
+// daily returns one point per day ending with a "now" point at now
+func daily(now time.Time, values ...float64) []models.MetricPoint {
+    start := now.Truncate(24*time.Hour).AddDate(0, 0, -(len(values) - 1))
+    points := make([]models.MetricPoint, len(values))
+    for i, v := range values {
+        points[i] = models.MetricPoint{Timestamp: start.AddDate(0, 0, i), Value: v}
+    }
+    points[len(points)-1].Timestamp = now
+    return points
+}
+
+func newTestEngine(t *testing.T, rules []Rule, sinks ...notify.SinkConfig) *Engine {
+    e := NewEngine(storage.NewMemoryStorage(), "crypto/bitcoin", Config{
+        Cooldown: time.Nanosecond,
+        Rules:    rules,
+        Notify:   notify.Config{Sinks: sinks},
+    })
+    if e.err != nil {
+        t.Fatal(e.err)
+    }
+    return e
+}
+
+func TestCrossFiresOnceWhenNowPointIsReplaced(t *testing.T) {
+    hook := newWebhook(t)
+    e := newTestEngine(t, []Rule{{Name: "100k", Type: RuleCross, Level: 100000}},
+        notify.SinkConfig{Name: "hook", Type: notify.SinkWebhook, URL: hook.URL})
+    ctx := context.Background()
+    now := time.Date(2024, 12, 5, 9, 0, 0, 0, time.UTC)
+
+    fired, err := e.Evaluate(ctx, daily(now, 97000, 98000, 101000))
+    if err != nil || len(fired) != 1 {
+        t.Fatalf("first cross: fired %v, err %v", fired, err)
+    }
+
+    // Later crawls replace the "now" point while the price stays above
+    for i, v := range []float64{100500, 102000, 100100} {
+        now = now.Add(time.Hour)
+        fired, err := e.Evaluate(ctx, daily(now, 97000, 98000, v))
+        if err != nil || len(fired) != 0 {
+            t.Fatalf("update %d: fired %v, err %v", i, fired, err)
+        }
+    }
+
+    // Falling back below and rising again is a new cross
+    now = now.Add(time.Hour)
+    if fired, _ := e.Evaluate(ctx, daily(now, 97000, 98000, 99000)); len(fired) != 1 {
+        t.Errorf("cross below fired %v", fired)
+    }
+    now = now.Add(time.Hour)
+    if fired, _ := e.Evaluate(ctx, daily(now, 97000, 98000, 100001)); len(fired) != 1 {
+        t.Errorf("second cross above fired %v", fired)
+    }
+    if n := hook.count(); n != 3 {
+        t.Errorf("webhook received %d alerts, want 3", n)
+    }
+}
+
+func TestATHFiresOncePerNewHigh(t *testing.T) {
+    hook := newWebhook(t)
+    e := newTestEngine(t, []Rule{{Name: "ath", Type: RuleATH}},
+        notify.SinkConfig{Name: "hook", Type: notify.SinkWebhook, URL: hook.URL})
+    ctx := context.Background()
+    now := time.Date(2024, 12, 5, 9, 0, 0, 0, time.UTC)
+
+    steps := []struct {
+        value float64
+        fire  bool
+    }{
+        {105000, true},
+        // The "now" point is replaced with a lower price
+        {104000, false},
+        // and with one that is above the history but not above the alert
+        {104900, false},
+        {106000, true},
+    }
+    for i, step := range steps {
+        fired, err := e.Evaluate(ctx, daily(now, 100000, 103000, step.value))
+        if err != nil {
+            t.Fatal(err)
+        }
+        if (len(fired) == 1) != step.fire {
+            t.Errorf("step %d (%v): fired %v", i, step.value, fired)
+        }
+        now = now.Add(time.Hour)
+    }
+}
+
+func TestAlertIsRetriedOnlyToFailedSinks(t *testing.T) {
+    good := newWebhook(t)
+    flaky := newWebhook(t)
+    flaky.setFail(true)
+    e := newTestEngine(t, []Rule{{Name: "100k", Type: RuleCross, Level: 100000}},
+        notify.SinkConfig{Name: "good", Type: notify.SinkWebhook, URL: good.URL},
+        notify.SinkConfig{Name: "flaky", Type: notify.SinkWebhook, URL: flaky.URL})
+    ctx := context.Background()
+    now := time.Date(2024, 12, 5, 9, 0, 0, 0, time.UTC)
+
+    fired, err := e.Evaluate(ctx, daily(now, 98000, 101000))
+    if err == nil || len(fired) != 0 {
+        t.Fatalf("fired %v, err %v; want the flaky sink to fail", fired, err)
+    }
+
+    flaky.setFail(false)
+    fired, err = e.Evaluate(ctx, daily(now.Add(time.Hour), 98000, 101500))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(fired) != 1 || fired[0].Value != 101000 {
+        t.Errorf("retry delivered %v, want the first alert", fired)
+    }
+    if good.count() != 1 || flaky.count() != 2 {
+        t.Errorf("good received %d posts and flaky %d, want 1 and 2", good.count(), flaky.count())
+    }
+
+    // Nothing is left to deliver
+    if fired, err := e.Evaluate(ctx, daily(now.Add(2*time.Hour), 98000, 101200)); err != nil || len(fired) != 0 {
+        t.Errorf("fired %v, err %v", fired, err)
+    }
+    if good.count() != 1 || flaky.count() != 2 {
+        t.Errorf("good received %d posts and flaky %d after delivery", good.count(), flaky.count())
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,36 @@
+package alerts
+
+import (
+    "context"
+    "log"
+
+    "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Database wraps a database.Database and evaluates the alert rules on the
+// Bitcoin prices it saves
+type Database struct {
+    database.Database
+    engine *Engine
+}
+
+// NewDatabase creates a new Database evaluating engine
+func NewDatabase(db database.Database, engine *Engine) *Database {
+    return &Database{
+        Database: db,
+        engine:   engine,
+    }
+}
+
+// SaveBitcoinPrices implements database.Database.SaveBitcoinPrices. Failed
+// alerts are only logged so that the batch is not redelivered.
+func (d *Database) SaveBitcoinPrices(ctx context.Context, data models.BitcoinDailyData) error {
+    if err := d.Database.SaveBitcoinPrices(ctx, data); err != nil {
+        return err
+    }
+    if _, err := d.engine.Evaluate(ctx, FromBitcoin(data.Data)); err != nil {
+        log.Printf("Failed to evaluate alerts: %v", err)
+    }
+    return nil
+} 
\ No newline at end of file
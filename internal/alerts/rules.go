@@ -0,0 +1,217 @@
+package alerts
+
+import (
+    "fmt"
+    "math"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
+)
+
+// Rule types supported by Rule.Type
+const (
+    // RuleCross fires when the price crosses Level between two points
+    RuleCross = "cross"
+    // RuleChange fires when the price moved by Percent over Window
+    RuleChange = "change"
+    // RuleATH fires on a new all-time high
+    RuleATH = "ath"
+    // RuleIndicator fires when an indicator goes above Above or below Below
+    RuleIndicator = "indicator"
+)
+
+// Directions supported by Rule.Direction
+const (
+    DirectionUp   = "up"
+    DirectionDown = "down"
+)
+
+// Rule is a user-defined alert condition
+type Rule struct {
+    Name string `yaml:"name"`
+    Type string `yaml:"type"`
+    // Level is the price a cross rule watches
+    Level float64 `yaml:"level"`
+    // Direction limits a cross rule to up or down crossings, defaults to both
+    Direction string `yaml:"direction"`
+    // Percent is the change of a change rule, e.g. 10 for a rise of 10% or
+    // more and -10 for a fall of 10% or more
+    Percent float64 `yaml:"percent"`
+    // Window is the span a change rule compares the price over, e.g. 24h
+    Window time.Duration `yaml:"window"`
+    // Indicator names the series an indicator rule watches, e.g. rsi-14
+    Indicator string `yaml:"indicator"`
+    // Above and Below are the thresholds of an indicator rule
+    Above *float64 `yaml:"above"`
+    Below *float64 `yaml:"below"`
+    // Cooldown is the minimum time between two alerts of the rule, defaults
+    // to Config.Cooldown
+    Cooldown time.Duration `yaml:"cooldown"`
+    // Notify lists the sinks the alerts go to, defaults to every sink
+    Notify []string `yaml:"notify"`
+}
+
+// validate checks the rule's settings for its type
+func (r Rule) validate(sinks notify.Config) error {
+    if r.Name == "" {
+        return fmt.Errorf("rule name is required")
+    }
+    switch r.Type {
+    case RuleCross:
+        if r.Level <= 0 {
+            return fmt.Errorf("rule %s: level is required", r.Name)
+        }
+        switch r.Direction {
+        case "", DirectionUp, DirectionDown:
+        default:
+            return fmt.Errorf("rule %s: unknown direction %q", r.Name, r.Direction)
+        }
+    case RuleChange:
+        if r.Percent == 0 || r.Window <= 0 {
+            return fmt.Errorf("rule %s: percent and window are required", r.Name)
+        }
+    case RuleATH:
+    case RuleIndicator:
+        if r.Indicator == "" || (r.Above == nil && r.Below == nil) {
+            return fmt.Errorf("rule %s: indicator and above or below are required", r.Name)
+        }
+    default:
+        return fmt.Errorf("rule %s has unknown type %q", r.Name, r.Type)
+    }
+    for _, name := range r.Notify {
+        if !sinks.Has(name) {
+            return fmt.Errorf("rule %s: unknown sink %s", r.Name, name)
+        }
+    }
+    return nil
+}
+
+// trigger is the outcome of evaluating a rule on the latest point
+type trigger struct {
+    // active reports whether the condition holds
+    active bool
+    // edge is set for conditions that only hold at one point, such as a
+    // cross or a new high; the others fire when they start to hold
+    edge bool
+    // at is the point the condition was evaluated at
+    at    time.Time
+    value float64
+    text  string
+    // side is the side of the level a cross rule's latest point is on
+    side string
+    // high is the highest value an ATH rule has seen
+    high float64
+}
+
+// Sides of a cross rule's level
+const (
+    sideAbove = "above"
+    sideBelow = "below"
+)
+
+// evaluate checks the rule against the price points, sorted by time, and
+// the latest values of the indicator series. st is the state left by the
+// previous evaluation: a cross fires when the price moved to the other side
+// of the level and an ATH when it passed the highest value seen, so that a
+// point replaced by a later crawl, such as CoinGecko's "now" point, does not
+// fire again.
+func (r Rule) evaluate(asset string, points []models.MetricPoint, indicator []models.MetricPoint, st ruleState) trigger {
+    if len(points) == 0 {
+        return trigger{}
+    }
+    last := points[len(points)-1]
+    t := trigger{at: last.Timestamp, value: last.Value}
+
+    switch r.Type {
+    case RuleCross:
+        t.edge = true
+        t.side = side(last.Value, r.Level)
+        prev := st.Side
+        if prev == "" {
+            // No state yet: compare with the last point of the previous
+            // evaluation, or the point before the latest one
+            if len(points) < 2 {
+                return t
+            }
+            p := points[len(points)-2].Value
+            for i := len(points) - 2; i >= 0 && !st.Evaluated.IsZero(); i-- {
+                if !points[i].Timestamp.After(st.Evaluated) {
+                    p = points[i].Value
+                    break
+                }
+            }
+            prev = side(p, r.Level)
+        }
+        switch {
+        case prev == sideBelow && t.side == sideAbove && r.Direction != DirectionDown:
+            t.active = true
+            t.text = fmt.Sprintf("%s crossed above %s at %s", asset, formatValue(r.Level), formatValue(last.Value))
+        case prev == sideAbove && t.side == sideBelow && r.Direction != DirectionUp:
+            t.active = true
+            t.text = fmt.Sprintf("%s crossed below %s at %s", asset, formatValue(r.Level), formatValue(last.Value))
+        }
+
+    case RuleChange:
+        // Compare with the last point at least Window old
+        since := last.Timestamp.Add(-r.Window)
+        var base *models.MetricPoint
+        for i := len(points) - 2; i >= 0; i-- {
+            if !points[i].Timestamp.After(since) {
+                base = &points[i]
+                break
+            }
+        }
+        if base == nil || base.Value == 0 {
+            return t
+        }
+        change := (last.Value/base.Value - 1) * 100
+        if (r.Percent > 0 && change >= r.Percent) || (r.Percent < 0 && change <= r.Percent) {
+            t.active = true
+            t.text = fmt.Sprintf("%s moved %+.2f%% over %s to %s", asset, change, r.Window, formatValue(last.Value))
+        }
+
+    case RuleATH:
+        t.edge = true
+        high := st.High
+        for _, p := range points[:len(points)-1] {
+            high = math.Max(high, p.Value)
+        }
+        t.high = math.Max(high, last.Value)
+        if (len(points) > 1 || st.High > 0) && last.Value > high {
+            t.active = true
+            t.text = fmt.Sprintf("%s reached a new all-time high of %s", asset, formatValue(last.Value))
+        }
+
+    case RuleIndicator:
+        if len(indicator) == 0 {
+            return trigger{}
+        }
+        latest := indicator[len(indicator)-1]
+        t.at, t.value = latest.Timestamp, latest.Value
+        switch {
+        case r.Above != nil && latest.Value > *r.Above:
+            t.active = true
+            t.text = fmt.Sprintf("%s %s is above %s at %s", asset, r.Indicator, formatValue(*r.Above), formatValue(latest.Value))
+        case r.Below != nil && latest.Value < *r.Below:
+            t.active = true
+            t.text = fmt.Sprintf("%s %s is below %s at %s", asset, r.Indicator, formatValue(*r.Below), formatValue(latest.Value))
+        }
+    }
+    return t
+}
+
+// side returns the side of level v is on; the level itself counts as above
+func side(v, level float64) string {
+    if v >= level {
+        return sideAbove
+    }
+    return sideBelow
+}
+
+func formatValue(v float64) string {
+    if math.Abs(v) >= 1000 {
+        return fmt.Sprintf("%.0f", v)
+    }
+    return fmt.Sprintf("%.4g", v)
+} 
\ No newline at end of file
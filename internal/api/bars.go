@@ -0,0 +1,110 @@
+package api
+
+import (
+    "net/http"
+    "strings"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+)
+
+// BarsResponse is the body of /v1/bars/{asset}
+type BarsResponse struct {
+    Asset    string `json:"asset"`
+    Currency string `json:"currency"`
+    Period   string `json:"period"`
+    Timezone string `json:"timezone"`
+    // Materialized is set when the bars were read from storage rather than
+    // computed for the request
+    Materialized bool                  `json:"materialized"`
+    Data         []models.AggregateBar `json:"data"`
+    Pagination   Pagination            `json:"pagination"`
+}
+
+// handleBars serves /v1/bars/{asset}?period=&tz=&from=&to=&currency=, the
+// asset resampled to calendar periods. Bars materialized by the crawlers are
+// used when they match the period and timezone.
+func (s *Server) handleBars(w http.ResponseWriter, r *http.Request) {
+    asset, ok := s.asset(w, r, "/v1/bars/")
+    if !ok {
+        return
+    }
+    query := r.URL.Query()
+
+    period := query.Get("period")
+    if period == "" {
+        period = resample.PeriodMonth
+    }
+    if err := resample.ValidatePeriod(period); err != nil {
+        writeError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+    loc, err := resample.LoadLocation(query.Get("tz"))
+    if err != nil {
+        writeError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+    from, err := parseTime(query.Get("from"), false)
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "from: "+err.Error())
+        return
+    }
+    to, err := parseTime(query.Get("to"), true)
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "to: "+err.Error())
+        return
+    }
+    limit, offset, err := s.page(query.Get("limit"), query.Get("offset"))
+    if err != nil {
+        writeError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+
+    resp := BarsResponse{
+        Asset:    asset.Name,
+        Currency: asset.Currency,
+        Period:   period,
+        Timezone: loc.String(),
+    }
+    var bars []models.AggregateBar
+    currency := strings.ToLower(query.Get("currency"))
+    if currency == "" || currency == asset.Currency {
+        stored, found, err := resample.Load(r.Context(), s.storage, asset.Prefix, period)
+        if err != nil {
+            s.internalError(w, err)
+            return
+        }
+        if found && stored.Timezone == loc.String() {
+            bars = stored.Bars
+            resp.Materialized = true
+        }
+    }
+    if !resp.Materialized {
+        currency, points, ok := s.points(w, r, asset)
+        if !ok {
+            return
+        }
+        resp.Currency = currency
+        if bars, err = resample.Resample(resamplePoints(points), period, loc); err != nil {
+            s.internalError(w, err)
+            return
+        }
+    }
+
+    // Bars are selected by their start
+    var selected []models.AggregateBar
+    for _, bar := range bars {
+        if (from.IsZero() || !bar.Start.Before(from)) && (to.IsZero() || bar.Start.Before(to)) {
+            selected = append(selected, bar)
+        }
+    }
+    start, end, pagination := paginate(w, r, len(selected), limit, offset)
+    resp.Data = append([]models.AggregateBar{}, selected[start:end]...)
+    resp.Pagination = pagination
+
+    if wantsCSV(r) {
+        s.writeBody(w, r, contentTypeCSV, barsCSV(resp.Data))
+        return
+    }
+    s.writeJSON(w, r, resp)
+} 
\ No newline at end of file
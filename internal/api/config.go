@@ -0,0 +1,121 @@
+package api
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+// Storage formats supported by AssetConfig.Format and RateConfig.Format
+const (
+    FormatSeries  = "series"
+    FormatBitcoin = "bitcoin"
+)
+
+// AssetConfig declares a stored series served by the API
+type AssetConfig struct {
+    // Name is the asset name used in the URLs, e.g. bitcoin
+    Name string `yaml:"name"`
+    // Prefix is the storage prefix holding latest.json
+    Prefix string `yaml:"prefix"`
+    // Format is series (a metric series) or bitcoin (the Bitcoin price history)
+    Format string `yaml:"format"`
+    // Currency the series is quoted in, defaults to usd
+    Currency string `yaml:"currency"`
+}
+
+// RateConfig declares a stored exchange rate series. Each value is the
+// number of To units one From unit buys.
+type RateConfig struct {
+    From   string `yaml:"from"`
+    To     string `yaml:"to"`
+    Prefix string `yaml:"prefix"`
+    Format string `yaml:"format"`
+}
+
+// Config holds configuration for the API server
+type Config struct {
+    Addr   string        `yaml:"addr"`
+    Assets []AssetConfig `yaml:"assets"`
+    Rates  []RateConfig  `yaml:"rates"`
+    // PageSize is the default number of points per page, defaults to 1000
+    PageSize int `yaml:"page_size"`
+    // MaxPageSize caps the limit parameter, defaults to 10000
+    MaxPageSize int `yaml:"max_page_size"`
+    // MaxAge is sent in Cache-Control, defaults to a minute
+    MaxAge time.Duration `yaml:"max_age"`
+}
+
+// withDefaults returns the configuration with unset values filled in
+func (c Config) withDefaults() Config {
+    if c.Addr == "" {
+        c.Addr = ":8080"
+    }
+    if c.PageSize <= 0 {
+        c.PageSize = 1000
+    }
+    if c.MaxPageSize <= 0 {
+        c.MaxPageSize = 10000
+    }
+    if c.PageSize > c.MaxPageSize {
+        c.PageSize = c.MaxPageSize
+    }
+    if c.MaxAge <= 0 {
+        c.MaxAge = time.Minute
+    }
+    assets := make([]AssetConfig, len(c.Assets))
+    for i, a := range c.Assets {
+        a.Currency = strings.ToLower(a.Currency)
+        if a.Currency == "" {
+            a.Currency = "usd"
+        }
+        assets[i] = a
+    }
+    c.Assets = assets
+    rates := make([]RateConfig, len(c.Rates))
+    for i, r := range c.Rates {
+        r.From = strings.ToLower(r.From)
+        r.To = strings.ToLower(r.To)
+        rates[i] = r
+    }
+    c.Rates = rates
+    return c
+}
+
+// Validate checks the configuration for missing or unknown settings
+func (c *Config) Validate() error {
+    if len(c.Assets) == 0 {
+        return fmt.Errorf("api: at least one asset is required")
+    }
+    seen := make(map[string]bool, len(c.Assets))
+    for _, a := range c.Assets {
+        if a.Name == "" || a.Prefix == "" {
+            return fmt.Errorf("api: asset name and prefix are required")
+        }
+        if seen[a.Name] {
+            return fmt.Errorf("api: asset %s is declared twice", a.Name)
+        }
+        seen[a.Name] = true
+        if err := validFormat(a.Format); err != nil {
+            return fmt.Errorf("api: asset %s: %w", a.Name, err)
+        }
+    }
+    for _, r := range c.Rates {
+        if r.From == "" || r.To == "" || r.Prefix == "" {
+            return fmt.Errorf("api: rate from, to and prefix are required")
+        }
+        if err := validFormat(r.Format); err != nil {
+            return fmt.Errorf("api: rate %s/%s: %w", r.From, r.To, err)
+        }
+    }
+    return nil
+}
+
+func validFormat(format string) error {
+    switch format {
+    case "", FormatSeries, FormatBitcoin:
+        return nil
+    default:
+        return fmt.Errorf("unknown format %q", format)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,168 @@
+package api
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/csv"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+const (
+    contentTypeJSON = "application/json"
+    contentTypeCSV  = "text/csv; charset=utf-8"
+)
+
+// wantsCSV reports whether the request asks for CSV with format=csv or an
+// Accept header preferring text/csv
+func wantsCSV(r *http.Request) bool {
+    if format := r.URL.Query().Get("format"); format != "" {
+        return format == "csv"
+    }
+    return strings.Contains(r.Header.Get("Accept"), "text/csv")
+}
+
+// writeJSON encodes v and writes it with writeBody. HTML escaping is off so
+// the next page links stay readable.
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+    var buf bytes.Buffer
+    enc := json.NewEncoder(&buf)
+    enc.SetEscapeHTML(false)
+    if err := enc.Encode(v); err != nil {
+        s.internalError(w, fmt.Errorf("failed to marshal response: %w", err))
+        return
+    }
+    s.writeBody(w, r, contentTypeJSON, buf.Bytes())
+}
+
+// writeBody writes a successful response tagged with an ETag of the body.
+// A request whose If-None-Match carries the tag gets a 304 instead.
+func (s *Server) writeBody(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+    sum := sha256.Sum256(body)
+    etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+    h := w.Header()
+    h.Set("ETag", etag)
+    h.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.config.MaxAge/time.Second)))
+    h.Add("Vary", "Accept")
+    if matchesETag(r.Header.Get("If-None-Match"), etag) {
+        w.WriteHeader(http.StatusNotModified)
+        return
+    }
+
+    h.Set("Content-Type", contentType)
+    h.Set("Content-Length", strconv.Itoa(len(body)))
+    w.WriteHeader(http.StatusOK)
+    if r.Method != http.MethodHead {
+        w.Write(body)
+    }
+}
+
+// matchesETag reports whether an If-None-Match header lists etag, comparing
+// weakly as RFC 7232 asks for
+func matchesETag(header, etag string) bool {
+    if header == "" {
+        return false
+    }
+    for _, candidate := range strings.Split(header, ",") {
+        candidate = strings.TrimSpace(candidate)
+        if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+            return true
+        }
+    }
+    return false
+}
+
+// writeError writes a JSON error body, these are never cached
+func writeError(w http.ResponseWriter, status int, message string) {
+    w.Header().Set("Cache-Control", "no-store")
+    writeJSONStatus(w, status, map[string]string{"error": message})
+}
+
+// writeJSONStatus writes v as JSON without an ETag
+func writeJSONStatus(w http.ResponseWriter, status int, v interface{}) {
+    w.Header().Set("Content-Type", contentTypeJSON)
+    w.WriteHeader(status)
+    enc := json.NewEncoder(w)
+    enc.SetEscapeHTML(false)
+    enc.Encode(v)
+}
+
+// pointsCSV renders points with a header row
+func pointsCSV(points []Point) []byte {
+    rows := [][]string{{"timestamp", "price", "market_cap", "volume"}}
+    for _, p := range points {
+        rows = append(rows, []string{
+            p.Timestamp.Format(time.RFC3339),
+            strconv.FormatFloat(p.Price, 'f', -1, 64),
+            formatFloat(p.MarketCap),
+            formatFloat(p.Volume),
+        })
+    }
+    return encodeCSV(rows)
+}
+
+// barsCSV renders bars with a header row
+func barsCSV(bars []models.AggregateBar) []byte {
+    rows := [][]string{{"start", "end", "open", "high", "low", "close", "volume", "market_cap", "count"}}
+    for _, b := range bars {
+        rows = append(rows, []string{
+            b.Start.Format(time.RFC3339),
+            b.End.Format(time.RFC3339),
+            strconv.FormatFloat(b.Open, 'f', -1, 64),
+            strconv.FormatFloat(b.High, 'f', -1, 64),
+            strconv.FormatFloat(b.Low, 'f', -1, 64),
+            strconv.FormatFloat(b.Close, 'f', -1, 64),
+            formatFloat(b.Volume),
+            formatFloat(b.MarketCap),
+            strconv.Itoa(b.Count),
+        })
+    }
+    return encodeCSV(rows)
+}
+
+// assetsCSV renders the asset list with a header row
+func assetsCSV(assets []AssetInfo) []byte {
+    rows := [][]string{{"name", "currency", "currencies", "unit", "first", "last", "points", "last_updated"}}
+    for _, a := range assets {
+        var first, last string
+        if a.First != nil {
+            first = a.First.Format(time.RFC3339)
+            last = a.Last.Format(time.RFC3339)
+        }
+        rows = append(rows, []string{
+            a.Name,
+            a.Currency,
+            strings.Join(a.Currencies, " "),
+            a.Unit,
+            first,
+            last,
+            strconv.Itoa(a.Points),
+            a.LastUpdated.Format(time.RFC3339),
+        })
+    }
+    return encodeCSV(rows)
+}
+
+func encodeCSV(rows [][]string) []byte {
+    var buf bytes.Buffer
+    cw := csv.NewWriter(&buf)
+    cw.WriteAll(rows)
+    return buf.Bytes()
+}
+
+// formatFloat leaves zero values empty so missing market caps and volumes
+// stay blank
+func formatFloat(f float64) string {
+    if f == 0 {
+        return ""
+    }
+    return strconv.FormatFloat(f, 'f', -1, 64)
+} 
\ No newline at end of file
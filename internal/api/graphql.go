@@ -0,0 +1,161 @@
+package api
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+
+    graphql "github.com/graph-gophers/graphql-go"
+    gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// GraphQLSchema is the schema served at /v1/graphql. Times are RFC 3339
+// strings and from/to accept the same values as the REST API.
+const GraphQLSchema = `type Query {
+  assets(names: [String!]): [Asset!]!
+  asset(name: String!): Asset
+}
+
+type Asset {
+  name: String!
+  currency: String!
+  currencies: [String!]!
+  unit: String
+  first: String
+  last: String
+  count: Int!
+  lastUpdated: String
+  latest(currency: String): Point
+  series(from: String, to: String, interval: String, currency: String, limit: Int, offset: Int): [Point!]!
+  aggregate(from: String, to: String, currency: String): Aggregate!
+}
+
+type Point {
+  timestamp: String!
+  price: Float!
+  marketCap: Float
+  volume: Float
+}
+
+type Aggregate {
+  count: Int!
+  open: Float
+  high: Float
+  low: Float
+  close: Float
+  mean: Float
+  change: Float
+  volume: Float
+  marketCap: Float
+}`
+
+// maxGraphQLBody caps the size of a POSTed query
+const maxGraphQLBody = 1 << 20
+
+// GraphQLRequest is the body of a GraphQL request
+type GraphQLRequest struct {
+    Query         string                 `json:"query"`
+    OperationName string                 `json:"operationName"`
+    Variables     map[string]interface{} `json:"variables"`
+}
+
+// parseGraphQLSchema binds GraphQLSchema to the resolvers of s
+func parseGraphQLSchema(s *Server) (*graphql.Schema, error) {
+    schema, err := graphql.ParseSchema(GraphQLSchema, &gqlQuery{server: s})
+    if err != nil {
+        return nil, fmt.Errorf("api: invalid graphql schema: %w", err)
+    }
+    return schema, nil
+}
+
+// loaderKey is the context key of the series loader of a GraphQL request
+type loaderKey struct{}
+
+// requestLoader returns the series loader of the GraphQL request in ctx
+func (s *Server) requestLoader(ctx context.Context) *loader {
+    if l, ok := ctx.Value(loaderKey{}).(*loader); ok {
+        return l
+    }
+    return newLoader(s.storage)
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+    switch r.Method {
+    case http.MethodGet, http.MethodHead, http.MethodPost:
+    default:
+        w.Header().Set("Allow", "GET, HEAD, POST")
+        writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+        return
+    }
+    if r.Method != http.MethodPost && r.URL.Query().Get("query") == "" {
+        // A bare GET returns the schema
+        s.writeBody(w, r, "text/plain; charset=utf-8", []byte(GraphQLSchema+"\n"))
+        return
+    }
+
+    body, err := io.ReadAll(io.LimitReader(r.Body, maxGraphQLBody+1))
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "failed to read request body")
+        return
+    }
+    if len(body) > maxGraphQLBody {
+        writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+        return
+    }
+
+    req, err := graphQLRequest(r.Method, r.Header.Get("Content-Type"), r.URL.Query(), body)
+    if err != nil {
+        writeJSONStatus(w, http.StatusBadRequest, graphql.Response{
+            Errors: []*gqlerrors.QueryError{{Message: err.Error()}},
+        })
+        return
+    }
+
+    // Every series is read from storage at most once per request
+    ctx := context.WithValue(r.Context(), loaderKey{}, newLoader(s.storage))
+    resp := s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+    if resp.Data == nil {
+        // The query did not parse or validate
+        writeJSONStatus(w, http.StatusBadRequest, resp)
+        return
+    }
+    // Only GETs are cacheable, POSTs are answered with no-store
+    if r.Method == http.MethodPost {
+        w.Header().Set("Cache-Control", "no-store")
+        writeJSONStatus(w, http.StatusOK, resp)
+        return
+    }
+    s.writeJSON(w, r, resp)
+}
+
+// graphQLRequest reads a request from the query string of a GET or the JSON
+// body of a POST
+func graphQLRequest(method, contentType string, query map[string][]string, body []byte) (GraphQLRequest, error) {
+    var req GraphQLRequest
+    if method != http.MethodPost {
+        get := func(k string) string {
+            if v := query[k]; len(v) > 0 {
+                return v[0]
+            }
+            return ""
+        }
+        req.Query = get("query")
+        req.OperationName = get("operationName")
+        if v := get("variables"); v != "" {
+            if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+                return req, fmt.Errorf("invalid variables: %w", err)
+            }
+        }
+    } else if strings.HasPrefix(contentType, "application/graphql") {
+        req.Query = string(body)
+    } else if err := json.Unmarshal(body, &req); err != nil {
+        return req, fmt.Errorf("invalid request body: %w", err)
+    }
+    if req.Query == "" {
+        return req, fmt.Errorf("query is required")
+    }
+    return req, nil
+} 
\ No newline at end of file
@@ -0,0 +1,331 @@
+package api
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+)
+
+// gqlQuery resolves the fields of Query
+type gqlQuery struct {
+    server *Server
+}
+
+// Assets resolves Query.assets. The requested series are read from storage
+// concurrently in one batch before the assets are resolved.
+func (q *gqlQuery) Assets(ctx context.Context, args struct{ Names *[]string }) ([]*gqlAsset, error) {
+    var names []string
+    if args.Names != nil {
+        names = *args.Names
+    } else {
+        for _, a := range q.server.config.Assets {
+            names = append(names, a.Name)
+        }
+    }
+
+    l := q.server.requestLoader(ctx)
+    assets := make([]*gqlAsset, 0, len(names))
+    keys := make([]seriesKey, 0, len(names))
+    for _, name := range names {
+        asset, ok := q.server.assets[name]
+        if !ok {
+            return nil, fmt.Errorf("unknown asset %q", name)
+        }
+        assets = append(assets, &gqlAsset{server: q.server, loader: l, asset: asset})
+        keys = append(keys, seriesKey{asset.Prefix, asset.Format})
+    }
+    if err := l.loadMany(ctx, keys); err != nil {
+        return nil, err
+    }
+    return assets, nil
+}
+
+// Asset resolves Query.asset, null for an unknown asset
+func (q *gqlQuery) Asset(ctx context.Context, args struct{ Name string }) *gqlAsset {
+    asset, ok := q.server.assets[args.Name]
+    if !ok {
+        return nil
+    }
+    return &gqlAsset{server: q.server, loader: q.server.requestLoader(ctx), asset: asset}
+}
+
+// gqlAsset resolves an Asset
+type gqlAsset struct {
+    server *Server
+    loader *loader
+    asset  AssetConfig
+}
+
+func (a *gqlAsset) info(ctx context.Context) (AssetInfo, error) {
+    return a.server.assetInfo(ctx, a.loader, a.asset)
+}
+
+func (a *gqlAsset) Name() string {
+    return a.asset.Name
+}
+
+func (a *gqlAsset) Currency() string {
+    return a.asset.Currency
+}
+
+func (a *gqlAsset) Currencies() []string {
+    return a.server.currencies(a.asset)
+}
+
+func (a *gqlAsset) Unit(ctx context.Context) (*string, error) {
+    info, err := a.info(ctx)
+    if err != nil || info.Unit == "" {
+        return nil, err
+    }
+    return &info.Unit, nil
+}
+
+func (a *gqlAsset) First(ctx context.Context) (*string, error) {
+    info, err := a.info(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return formatTime(info.First), nil
+}
+
+func (a *gqlAsset) Last(ctx context.Context) (*string, error) {
+    info, err := a.info(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return formatTime(info.Last), nil
+}
+
+func (a *gqlAsset) Count(ctx context.Context) (int32, error) {
+    info, err := a.info(ctx)
+    return int32(info.Points), err
+}
+
+func (a *gqlAsset) LastUpdated(ctx context.Context) (*string, error) {
+    info, err := a.info(ctx)
+    if err != nil || info.LastUpdated.IsZero() {
+        return nil, err
+    }
+    return formatTime(&info.LastUpdated), nil
+}
+
+// Latest resolves Asset.latest, null for an empty series
+func (a *gqlAsset) Latest(ctx context.Context, args struct{ Currency *string }) (*gqlPoint, error) {
+    _, points, err := a.server.assetPoints(ctx, a.loader, a.asset, deref(args.Currency))
+    if err != nil || len(points) == 0 {
+        return nil, err
+    }
+    return &gqlPoint{points[len(points)-1]}, nil
+}
+
+// rangeArgs are the arguments selecting the points of a range
+type rangeArgs struct {
+    From     *string
+    To       *string
+    Currency *string
+}
+
+// points returns the asset's points within from and to, in currency
+func (a *gqlAsset) points(ctx context.Context, args rangeArgs) ([]Point, error) {
+    from, err := parseTime(deref(args.From), false)
+    if err != nil {
+        return nil, err
+    }
+    to, err := parseTime(deref(args.To), true)
+    if err != nil {
+        return nil, err
+    }
+    _, points, err := a.server.assetPoints(ctx, a.loader, a.asset, deref(args.Currency))
+    if err != nil {
+        return nil, err
+    }
+    return between(points, from, to), nil
+}
+
+// Series resolves Asset.series, downsampled to interval and paginated
+func (a *gqlAsset) Series(ctx context.Context, args struct {
+    From     *string
+    To       *string
+    Interval *string
+    Currency *string
+    Limit    *int32
+    Offset   *int32
+}) ([]*gqlPoint, error) {
+    points, err := a.points(ctx, rangeArgs{From: args.From, To: args.To, Currency: args.Currency})
+    if err != nil {
+        return nil, err
+    }
+    if interval := deref(args.Interval); interval != "" {
+        iv, err := ParseInterval(interval)
+        if err != nil {
+            return nil, err
+        }
+        points = downsample(points, iv)
+    }
+
+    cfg := a.server.config
+    limit, offset := cfg.PageSize, 0
+    if args.Limit != nil {
+        limit = int(*args.Limit)
+    }
+    if args.Offset != nil {
+        offset = int(*args.Offset)
+    }
+    if limit <= 0 || offset < 0 {
+        return nil, fmt.Errorf("limit must be positive and offset not negative")
+    }
+    if limit > cfg.MaxPageSize {
+        limit = cfg.MaxPageSize
+    }
+    if offset >= len(points) {
+        return []*gqlPoint{}, nil
+    }
+    points = points[offset:]
+    if limit < len(points) {
+        points = points[:limit]
+    }
+
+    list := make([]*gqlPoint, 0, len(points))
+    for _, p := range points {
+        list = append(list, &gqlPoint{p})
+    }
+    return list, nil
+}
+
+// Aggregate resolves Asset.aggregate
+func (a *gqlAsset) Aggregate(ctx context.Context, args rangeArgs) (*gqlAggregate, error) {
+    points, err := a.points(ctx, args)
+    if err != nil {
+        return nil, err
+    }
+    return &gqlAggregate{points}, nil
+}
+
+// gqlPoint resolves a Point
+type gqlPoint struct {
+    p Point
+}
+
+func (p *gqlPoint) Timestamp() string {
+    return p.p.Timestamp.Format(time.RFC3339)
+}
+
+func (p *gqlPoint) Price() float64 {
+    return p.p.Price
+}
+
+func (p *gqlPoint) MarketCap() *float64 {
+    return nullableFloat(p.p.MarketCap)
+}
+
+func (p *gqlPoint) Volume() *float64 {
+    return nullableFloat(p.p.Volume)
+}
+
+// gqlAggregate resolves an Aggregate over points; its fields are null
+// when there are none
+type gqlAggregate struct {
+    points []Point
+}
+
+func (a *gqlAggregate) Count() int32 {
+    return int32(len(a.points))
+}
+
+func (a *gqlAggregate) Open() *float64 {
+    if len(a.points) == 0 {
+        return nil
+    }
+    return &a.points[0].Price
+}
+
+func (a *gqlAggregate) Close() *float64 {
+    if len(a.points) == 0 {
+        return nil
+    }
+    return &a.points[len(a.points)-1].Price
+}
+
+func (a *gqlAggregate) High() *float64 {
+    return a.extreme(func(v, extreme float64) bool { return v > extreme })
+}
+
+func (a *gqlAggregate) Low() *float64 {
+    return a.extreme(func(v, extreme float64) bool { return v < extreme })
+}
+
+// extreme returns the price that beats every other one
+func (a *gqlAggregate) extreme(beats func(v, extreme float64) bool) *float64 {
+    if len(a.points) == 0 {
+        return nil
+    }
+    extreme := a.points[0].Price
+    for _, p := range a.points {
+        if beats(p.Price, extreme) {
+            extreme = p.Price
+        }
+    }
+    return &extreme
+}
+
+func (a *gqlAggregate) Mean() *float64 {
+    if len(a.points) == 0 {
+        return nil
+    }
+    var sum float64
+    for _, p := range a.points {
+        sum += p.Price
+    }
+    mean := sum / float64(len(a.points))
+    return &mean
+}
+
+func (a *gqlAggregate) Change() *float64 {
+    if len(a.points) == 0 || a.points[0].Price == 0 {
+        return nil
+    }
+    change := a.points[len(a.points)-1].Price/a.points[0].Price - 1
+    return &change
+}
+
+func (a *gqlAggregate) Volume() *float64 {
+    if len(a.points) == 0 {
+        return nil
+    }
+    return nullableFloat(resample.Volume(resamplePoints(a.points), time.UTC))
+}
+
+func (a *gqlAggregate) MarketCap() *float64 {
+    if len(a.points) == 0 {
+        return nil
+    }
+    return nullableFloat(a.points[len(a.points)-1].MarketCap)
+}
+
+// nullableFloat turns the zero value used for missing market caps and
+// volumes into null, and non-finite values too as JSON cannot hold them
+func nullableFloat(f float64) *float64 {
+    if f == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+        return nil
+    }
+    return &f
+}
+
+// formatTime formats a time as RFC 3339, nil staying null
+func formatTime(t *time.Time) *string {
+    if t == nil {
+        return nil
+    }
+    s := t.Format(time.RFC3339)
+    return &s
+}
+
+func deref(s *string) string {
+    if s == nil {
+        return ""
+    }
+    return *s
+} 
\ No newline at end of file
@@ -0,0 +1,209 @@
+package api
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// newTestServer serves a Bitcoin history and a hash rate series of three days
+func newTestServer(t *testing.T) *Server {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+    btc := models.BitcoinDailyData{LastUpdated: day.AddDate(0, 0, 3)}
+    hashRate := models.MetricSeries{Metric: "hash-rate", Unit: "TH/s", LastUpdated: day.AddDate(0, 0, 3)}
+    for i, price := range []float64{40000, 44000, 42000} {
+        ts := day.AddDate(0, 0, i)
+        btc.Data = append(btc.Data, models.BitcoinPrice{Timestamp: ts, Price: price, MarketCap: price * 19e6, Volume24h: 1e9})
+        hashRate.Data = append(hashRate.Data, models.MetricPoint{Timestamp: ts, Value: 500 + float64(i)})
+    }
+    if err := s.Save(ctx, "crypto/bitcoin/latest.json", btc); err != nil {
+        t.Fatal(err)
+    }
+    if err := s.Save(ctx, "onchain/bitcoin/hash-rate/latest.json", hashRate); err != nil {
+        t.Fatal(err)
+    }
+
+    server, err := NewServer(s, Config{Assets: []AssetConfig{
+        {Name: "bitcoin", Prefix: "crypto/bitcoin", Format: FormatBitcoin},
+        {Name: "hash-rate", Prefix: "onchain/bitcoin/hash-rate"},
+    }})
+    if err != nil {
+        t.Fatal(err)
+    }
+    return server
+}
+
+type gqlResult struct {
+    Data   map[string]json.RawMessage `json:"data"`
+    Errors []struct {
+        Message string        `json:"message"`
+        Path    []interface{} `json:"path"`
+    } `json:"errors"`
+}
+
+// post sends a GraphQL request and decodes the response
+func post(t *testing.T, s *Server, query string, variables map[string]interface{}) (int, gqlResult) {
+    body, _ := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+    req := httptest.NewRequest(http.MethodPost, "/v1/graphql", strings.NewReader(string(body)))
+    req.Header.Set("Content-Type", "application/json")
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, req)
+
+    var result gqlResult
+    if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+        t.Fatalf("decode %s: %v", rec.Body.String(), err)
+    }
+    return rec.Code, result
+}
+
+func TestGraphQLQuery(t *testing.T) {
+    s := newTestServer(t)
+    query := `query Dashboard($names: [String!], $from: String = "2024-01-02") {
+  assets(names: $names) {
+    name
+    unit
+    ...range
+  }
+}
+
+fragment range on Asset {
+  latest { timestamp price }
+  week: aggregate(from: $from) { count open high low close change }
+  series(limit: 2, offset: 1) { timestamp price marketCap volume }
+}`
+
+    code, result := post(t, s, query, map[string]interface{}{"names": []string{"bitcoin", "hash-rate"}})
+    if code != http.StatusOK || len(result.Errors) > 0 {
+        t.Fatalf("status %d, errors %+v", code, result.Errors)
+    }
+
+    var assets []struct {
+        Name   string
+        Unit   *string
+        Latest struct {
+            Timestamp string
+            Price     float64
+        }
+        Week struct {
+            Count                  int
+            Open, High, Low, Close float64
+            Change                 float64
+        }
+        Series []struct {
+            Timestamp string
+            Price     float64
+            MarketCap *float64
+            Volume    *float64
+        }
+    }
+    if err := json.Unmarshal(result.Data["assets"], &assets); err != nil {
+        t.Fatal(err)
+    }
+    if len(assets) != 2 {
+        t.Fatalf("got %d assets", len(assets))
+    }
+
+    btc, hr := assets[0], assets[1]
+    if btc.Name != "bitcoin" || btc.Unit != nil || btc.Latest.Timestamp != "2024-01-03T00:00:00Z" || btc.Latest.Price != 42000 {
+        t.Errorf("bitcoin = %+v", btc)
+    }
+    if btc.Week.Count != 2 || btc.Week.Open != 44000 || btc.Week.High != 44000 || btc.Week.Low != 42000 || btc.Week.Close != 42000 {
+        t.Errorf("bitcoin aggregate = %+v", btc.Week)
+    }
+    if len(btc.Series) != 2 || btc.Series[0].Price != 44000 || btc.Series[0].MarketCap == nil || btc.Series[0].Volume == nil {
+        t.Errorf("bitcoin series = %+v", btc.Series)
+    }
+    if hr.Unit == nil || *hr.Unit != "TH/s" || len(hr.Series) != 2 || hr.Series[1].MarketCap != nil {
+        t.Errorf("hash-rate = %+v", hr)
+    }
+}
+
+func TestGraphQLIntrospection(t *testing.T) {
+    s := newTestServer(t)
+    code, result := post(t, s, `{
+  __schema { queryType { name } }
+  __type(name: "Asset") { fields { name args { name } } }
+}`, nil)
+    if code != http.StatusOK || len(result.Errors) > 0 {
+        t.Fatalf("status %d, errors %+v", code, result.Errors)
+    }
+
+    var schema struct {
+        QueryType struct{ Name string }
+    }
+    json.Unmarshal(result.Data["__schema"], &schema)
+    if schema.QueryType.Name != "Query" {
+        t.Errorf("query type = %q", schema.QueryType.Name)
+    }
+
+    var asset struct {
+        Fields []struct {
+            Name string
+            Args []struct{ Name string }
+        }
+    }
+    json.Unmarshal(result.Data["__type"], &asset)
+    args := make(map[string]int)
+    for _, f := range asset.Fields {
+        args[f.Name] = len(f.Args)
+    }
+    if len(asset.Fields) != 11 || args["series"] != 6 || args["aggregate"] != 3 {
+        t.Errorf("Asset fields = %v", args)
+    }
+}
+
+func TestGraphQLErrors(t *testing.T) {
+    s := newTestServer(t)
+    tests := []struct {
+        name   string
+        query  string
+        status int
+        error  string
+    }{
+        {"syntax", `{ assets { name `, http.StatusBadRequest, "syntax error"},
+        {"unknown field", `{ assets { price } }`, http.StatusBadRequest, `Cannot query field "price"`},
+        {"missing argument", `{ asset { name } }`, http.StatusBadRequest, `argument "name"`},
+        {"unknown asset", `{ assets(names: ["dogecoin"]) { name } }`, http.StatusOK, `unknown asset "dogecoin"`},
+        {"bad time", `{ asset(name: "bitcoin") { aggregate(from: "yesterday") { count } } }`, http.StatusOK, "yesterday"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            code, result := post(t, s, tt.query, nil)
+            if code != tt.status {
+                t.Errorf("status = %d, want %d", code, tt.status)
+            }
+            if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, tt.error) {
+                t.Errorf("errors = %+v, want %q", result.Errors, tt.error)
+            }
+        })
+    }
+}
+
+func TestGraphQLGet(t *testing.T) {
+    s := newTestServer(t)
+
+    // A bare GET returns the schema
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/graphql", nil))
+    if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "type Asset {") {
+        t.Errorf("GET schema: %d %s", rec.Code, rec.Body.String())
+    }
+
+    q := url.Values{"query": {`query($n: String!) { asset(name: $n) { count } }`}, "variables": {`{"n":"hash-rate"}`}}
+    rec = httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/graphql?"+q.Encode(), nil))
+    if rec.Code != http.StatusOK || rec.Header().Get("ETag") == "" || !strings.Contains(rec.Body.String(), `"count":3`) {
+        t.Errorf("GET query: %d %v %s", rec.Code, rec.Header(), rec.Body.String())
+    }
+} 
\ No newline at end of file
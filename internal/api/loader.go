@@ -0,0 +1,78 @@
+package api
+
+import (
+    "context"
+    "sync"
+
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// seriesKey identifies a stored series
+type seriesKey struct {
+    prefix string
+    format string
+}
+
+// loader loads stored series for a single request. Each series is read at
+// most once however often it is asked for, and loadMany reads a batch of
+// series concurrently, like a dataloader.
+type loader struct {
+    storage storage.Storage
+    mu      sync.Mutex
+    entries map[seriesKey]*loadEntry
+}
+
+type loadEntry struct {
+    done   chan struct{}
+    series Series
+    err    error
+}
+
+func newLoader(storage storage.Storage) *loader {
+    return &loader{
+        storage: storage,
+        entries: make(map[seriesKey]*loadEntry),
+    }
+}
+
+// load returns the series under key, reading it on first use
+func (l *loader) load(ctx context.Context, key seriesKey) (Series, error) {
+    l.mu.Lock()
+    entry, ok := l.entries[key]
+    if !ok {
+        entry = &loadEntry{done: make(chan struct{})}
+        l.entries[key] = entry
+    }
+    l.mu.Unlock()
+
+    if !ok {
+        entry.series, entry.err = loadSeries(ctx, l.storage, key.prefix, key.format)
+        close(entry.done)
+    }
+    select {
+    case <-entry.done:
+        return entry.series, entry.err
+    case <-ctx.Done():
+        return Series{}, ctx.Err()
+    }
+}
+
+// loadMany reads the series under keys concurrently and returns the first error
+func (l *loader) loadMany(ctx context.Context, keys []seriesKey) error {
+    errs := make([]error, len(keys))
+    var wg sync.WaitGroup
+    for i, key := range keys {
+        wg.Add(1)
+        go func(i int, key seriesKey) {
+            defer wg.Done()
+            _, errs[i] = l.load(ctx, key)
+        }(i, key)
+    }
+    wg.Wait()
+    for _, err := range errs {
+        if err != nil {
+            return err
+        }
+    }
+    return nil
+} 
\ No newline at end of file
@@ -0,0 +1,238 @@
+package api
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Point is a single observation of an asset. MarketCap and Volume are only
+// set for price histories that carry them.
+type Point struct {
+    Timestamp time.Time `json:"timestamp"`
+    Price     float64   `json:"price"`
+    MarketCap float64   `json:"market_cap,omitempty"`
+    Volume    float64   `json:"volume,omitempty"`
+}
+
+// Series is a stored series as served by the API, sorted by time
+type Series struct {
+    LastUpdated time.Time
+    Unit        string
+    Points      []Point
+}
+
+// loadSeries reads the series stored under prefix in the given format
+func loadSeries(ctx context.Context, s storage.Storage, prefix, format string) (Series, error) {
+    key := fmt.Sprintf("%s/latest.json", prefix)
+
+    var series Series
+    if format == FormatBitcoin {
+        var data models.BitcoinDailyData
+        if err := s.Load(ctx, key, &data); err != nil && !errors.Is(err, storage.ErrNotFound) {
+            return series, fmt.Errorf("failed to load %s: %w", key, err)
+        }
+        series.LastUpdated = data.LastUpdated
+        series.Points = make([]Point, 0, len(data.Data))
+        for _, p := range data.Data {
+            series.Points = append(series.Points, Point{
+                Timestamp: p.Timestamp.UTC(),
+                Price:     p.Price,
+                MarketCap: p.MarketCap,
+                Volume:    p.Volume24h,
+            })
+        }
+    } else {
+        var data models.MetricSeries
+        if err := s.Load(ctx, key, &data); err != nil && !errors.Is(err, storage.ErrNotFound) {
+            return series, fmt.Errorf("failed to load %s: %w", key, err)
+        }
+        series.LastUpdated = data.LastUpdated
+        series.Unit = data.Unit
+        series.Points = make([]Point, 0, len(data.Data))
+        for _, p := range data.Data {
+            series.Points = append(series.Points, Point{Timestamp: p.Timestamp.UTC(), Price: p.Value})
+        }
+    }
+
+    sort.SliceStable(series.Points, func(i, j int) bool {
+        return series.Points[i].Timestamp.Before(series.Points[j].Timestamp)
+    })
+    return series, nil
+}
+
+// between returns the points with from <= timestamp < to; zero bounds are open
+func between(points []Point, from, to time.Time) []Point {
+    start := 0
+    if !from.IsZero() {
+        start = sort.Search(len(points), func(i int) bool {
+            return !points[i].Timestamp.Before(from)
+        })
+    }
+    end := len(points)
+    if !to.IsZero() {
+        end = sort.Search(len(points), func(i int) bool {
+            return !points[i].Timestamp.Before(to)
+        })
+    }
+    if start >= end {
+        return nil
+    }
+    return points[start:end]
+}
+
+// convert multiplies each point by the rate in effect at its timestamp,
+// which is the last rate at or before it. Points before the first rate are
+// dropped. With invert set the rates are divided instead.
+func convert(points []Point, rates []Point, invert bool) []Point {
+    converted := make([]Point, 0, len(points))
+    j := -1
+    for _, p := range points {
+        for j+1 < len(rates) && !rates[j+1].Timestamp.After(p.Timestamp) {
+            j++
+        }
+        if j < 0 || rates[j].Price == 0 {
+            continue
+        }
+        factor := rates[j].Price
+        if invert {
+            factor = 1 / factor
+        }
+        converted = append(converted, Point{
+            Timestamp: p.Timestamp,
+            Price:     p.Price * factor,
+            MarketCap: p.MarketCap * factor,
+            Volume:    p.Volume * factor,
+        })
+    }
+    return converted
+}
+
+// Interval is a bucket size for downsampling: a number of minutes or hours,
+// or a calendar period of the resample package
+type Interval struct {
+    duration time.Duration
+    period   string
+}
+
+// calendarIntervals maps the calendar intervals to resample periods
+var calendarIntervals = map[string]string{
+    "1d": resample.PeriodDay,
+    "1w": resample.PeriodWeek,
+    "1M": resample.PeriodMonth,
+    "3M": resample.PeriodQuarter,
+    "1y": resample.PeriodYear,
+}
+
+// ParseInterval parses intervals like 15m, 4h, 1d, 1w, 1M, 3M and 1y.
+// Minutes and hours must make up less than a day.
+func ParseInterval(s string) (Interval, error) {
+    if period, ok := calendarIntervals[s]; ok {
+        return Interval{period: period}, nil
+    }
+    invalid := fmt.Errorf("invalid interval %q, use minutes or hours below a day (15m, 4h), 1d, 1w, 1M, 3M or 1y", s)
+    if len(s) < 2 {
+        return Interval{}, invalid
+    }
+    n, err := strconv.Atoi(s[:len(s)-1])
+    if err != nil || n <= 0 {
+        return Interval{}, invalid
+    }
+    var iv Interval
+    switch s[len(s)-1:] {
+    case "m":
+        iv.duration = time.Duration(n) * time.Minute
+    case "h":
+        iv.duration = time.Duration(n) * time.Hour
+    default:
+        return iv, invalid
+    }
+    if iv.duration >= 24*time.Hour {
+        return iv, invalid
+    }
+    return iv, nil
+}
+
+// downsample reduces points to one per interval, stamped with its start.
+// Calendar periods are resampled into bars (UTC), whose close, market cap
+// and volume become the point; volumes are daily, not summed within a day.
+// Shorter intervals keep the last point of each bucket.
+func downsample(points []Point, iv Interval) []Point {
+    if iv.period != "" {
+        bars, _ := resample.Resample(resamplePoints(points), iv.period, time.UTC)
+        sampled := make([]Point, 0, len(bars))
+        for _, bar := range bars {
+            sampled = append(sampled, Point{
+                Timestamp: bar.Start,
+                Price:     bar.Close,
+                MarketCap: bar.MarketCap,
+                Volume:    bar.Volume,
+            })
+        }
+        return sampled
+    }
+
+    var sampled []Point
+    for _, p := range points {
+        start := p.Timestamp.UTC().Truncate(iv.duration)
+        p.Timestamp = start
+        if n := len(sampled); n > 0 && sampled[n-1].Timestamp.Equal(start) {
+            sampled[n-1] = p
+            continue
+        }
+        sampled = append(sampled, p)
+    }
+    return sampled
+}
+
+// resamplePoints converts points for the resample package
+func resamplePoints(points []Point) []resample.Point {
+    input := make([]resample.Point, 0, len(points))
+    for _, p := range points {
+        input = append(input, resample.Point{
+            Timestamp: p.Timestamp,
+            Close:     p.Price,
+            Volume:    p.Volume,
+            MarketCap: p.MarketCap,
+        })
+    }
+    return input
+}
+
+// parseTime accepts a date (YYYY-MM-DD), an RFC 3339 timestamp or unix
+// seconds. A date used as an upper bound includes the whole day.
+func parseTime(s string, upper bool) (time.Time, error) {
+    if s == "" {
+        return time.Time{}, nil
+    }
+    if t, err := time.Parse("2006-01-02", s); err == nil {
+        if upper {
+            t = t.AddDate(0, 0, 1)
+        }
+        return t, nil
+    }
+    if t, err := time.Parse(time.RFC3339, s); err == nil {
+        if upper {
+            t = t.Add(time.Nanosecond)
+        }
+        return t.UTC(), nil
+    }
+    if !strings.ContainsAny(s, "-:") {
+        if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+            t := time.Unix(seconds, 0).UTC()
+            if upper {
+                t = t.Add(time.Nanosecond)
+            }
+            return t, nil
+        }
+    }
+    return time.Time{}, fmt.Errorf("invalid time %q, use YYYY-MM-DD, RFC 3339 or unix seconds", s)
+} 
\ No newline at end of file
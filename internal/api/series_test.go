@@ -0,0 +1,84 @@
+package api
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestPricesInterval(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    btc := models.BitcoinDailyData{Data: []models.BitcoinPrice{
+        {Timestamp: day, Price: 40000, Volume24h: 10},
+        {Timestamp: day.AddDate(0, 0, 1), Price: 41000, Volume24h: 20},
+        // The crawler's "now" point on the second day
+        {Timestamp: day.AddDate(0, 0, 1).Add(9 * time.Hour), Price: 42000, Volume24h: 25},
+        {Timestamp: day.AddDate(0, 0, 7), Price: 43000, Volume24h: 30},
+    }}
+    if err := s.Save(ctx, "crypto/bitcoin/latest.json", btc); err != nil {
+        t.Fatal(err)
+    }
+    server, err := NewServer(s, Config{Assets: []AssetConfig{{Name: "bitcoin", Prefix: "crypto/bitcoin", Format: FormatBitcoin}}})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        interval string
+        want     []Point
+    }{
+        {"1d", []Point{
+            {Timestamp: day, Price: 40000, Volume: 10},
+            {Timestamp: day.AddDate(0, 0, 1), Price: 42000, Volume: 25},
+            {Timestamp: day.AddDate(0, 0, 7), Price: 43000, Volume: 30},
+        }},
+        // 2024-01-01 is a Monday
+        {"1w", []Point{
+            {Timestamp: day, Price: 42000, Volume: 35},
+            {Timestamp: day.AddDate(0, 0, 7), Price: 43000, Volume: 30},
+        }},
+        {"1M", []Point{{Timestamp: day, Price: 43000, Volume: 65}}},
+        {"12h", []Point{
+            {Timestamp: day, Price: 40000, Volume: 10},
+            {Timestamp: day.AddDate(0, 0, 1), Price: 42000, Volume: 25},
+            {Timestamp: day.AddDate(0, 0, 7), Price: 43000, Volume: 30},
+        }},
+    }
+    for _, tt := range tests {
+        t.Run(tt.interval, func(t *testing.T) {
+            rec := httptest.NewRecorder()
+            server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/prices/bitcoin?interval="+tt.interval, nil))
+            if rec.Code != http.StatusOK {
+                t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+            }
+            var resp PricesResponse
+            if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+                t.Fatal(err)
+            }
+            if len(resp.Data) != len(tt.want) {
+                t.Fatalf("data = %+v, want %+v", resp.Data, tt.want)
+            }
+            for i, p := range resp.Data {
+                if !p.Timestamp.Equal(tt.want[i].Timestamp) || p.Price != tt.want[i].Price || p.Volume != tt.want[i].Volume {
+                    t.Errorf("point %d = %+v, want %+v", i, p, tt.want[i])
+                }
+            }
+        })
+    }
+
+    for _, interval := range []string{"7d", "2w", "24h", "1x"} {
+        rec := httptest.NewRecorder()
+        server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/prices/bitcoin?interval="+interval, nil))
+        if rec.Code != http.StatusBadRequest {
+            t.Errorf("interval %s: status %d, want 400", interval, rec.Code)
+        }
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,441 @@
+package api
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "net/http"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    graphql "github.com/graph-gophers/graphql-go"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// AssetInfo describes an asset listed by /v1/assets
+type AssetInfo struct {
+    Name        string     `json:"name"`
+    Currency    string     `json:"currency"`
+    Currencies  []string   `json:"currencies"`
+    Unit        string     `json:"unit,omitempty"`
+    First       *time.Time `json:"first,omitempty"`
+    Last        *time.Time `json:"last,omitempty"`
+    Points      int        `json:"points"`
+    LastUpdated time.Time  `json:"last_updated"`
+}
+
+// AssetsResponse is the body of /v1/assets
+type AssetsResponse struct {
+    Assets []AssetInfo `json:"assets"`
+}
+
+// Pagination describes the page returned by /v1/prices
+type Pagination struct {
+    Limit  int `json:"limit"`
+    Offset int `json:"offset"`
+    Total  int `json:"total"`
+    // Next is the path and query of the next page, empty on the last one
+    Next string `json:"next,omitempty"`
+}
+
+// PricesResponse is the body of /v1/prices/{asset}
+type PricesResponse struct {
+    Asset      string     `json:"asset"`
+    Currency   string     `json:"currency"`
+    Interval   string     `json:"interval,omitempty"`
+    Data       []Point    `json:"data"`
+    Pagination Pagination `json:"pagination"`
+}
+
+// LatestResponse is the body of /v1/latest/{asset}
+type LatestResponse struct {
+    Asset    string `json:"asset"`
+    Currency string `json:"currency"`
+    Point
+}
+
+// Server serves the stored series over a read-only HTTP API
+type Server struct {
+    storage storage.Storage
+    config  Config
+    assets  map[string]AssetConfig
+    schema  *graphql.Schema
+}
+
+// NewServer creates a new Server after validating the configuration
+func NewServer(storage storage.Storage, config Config) (*Server, error) {
+    config = config.withDefaults()
+    if err := config.Validate(); err != nil {
+        return nil, err
+    }
+    assets := make(map[string]AssetConfig, len(config.Assets))
+    for _, a := range config.Assets {
+        assets[a.Name] = a
+    }
+    s := &Server{
+        storage: storage,
+        config:  config,
+        assets:  assets,
+    }
+    schema, err := parseGraphQLSchema(s)
+    if err != nil {
+        return nil, err
+    }
+    s.schema = schema
+    return s, nil
+}
+
+// Handler returns the HTTP handler serving the API
+func (s *Server) Handler() http.Handler {
+    mux := http.NewServeMux()
+    mux.Handle("/v1/assets", readOnly(http.HandlerFunc(s.handleAssets)))
+    mux.Handle("/v1/prices/", readOnly(http.HandlerFunc(s.handlePrices)))
+    mux.Handle("/v1/latest/", readOnly(http.HandlerFunc(s.handleLatest)))
+    mux.Handle("/v1/bars/", readOnly(http.HandlerFunc(s.handleBars)))
+    // GraphQL queries are read-only too but may be POSTed
+    mux.HandleFunc("/v1/graphql", s.handleGraphQL)
+    return mux
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+    return s.config.Addr
+}
+
+// ListenAndServe serves the API on the configured address until ctx is done
+func (s *Server) ListenAndServe(ctx context.Context) error {
+    srv := &http.Server{
+        Addr:              s.config.Addr,
+        Handler:           s.Handler(),
+        ReadHeaderTimeout: 10 * time.Second,
+    }
+
+    errChan := make(chan error, 1)
+    go func() {
+        errChan <- srv.ListenAndServe()
+    }()
+
+    select {
+    case err := <-errChan:
+        return fmt.Errorf("failed to serve api: %w", err)
+    case <-ctx.Done():
+    }
+
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    if err := srv.Shutdown(shutdownCtx); err != nil {
+        return fmt.Errorf("failed to shut down api: %w", err)
+    }
+    return nil
+}
+
+// readOnly rejects every method but GET and HEAD
+func readOnly(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != http.MethodGet && r.Method != http.MethodHead {
+            w.Header().Set("Allow", "GET, HEAD")
+            writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+func (s *Server) handleAssets(w http.ResponseWriter, r *http.Request) {
+    names := make([]string, 0, len(s.assets))
+    for name := range s.assets {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+
+    l := newLoader(s.storage)
+    keys := make([]seriesKey, 0, len(names))
+    for _, name := range names {
+        keys = append(keys, seriesKey{s.assets[name].Prefix, s.assets[name].Format})
+    }
+    if err := l.loadMany(r.Context(), keys); err != nil {
+        s.internalError(w, err)
+        return
+    }
+
+    resp := AssetsResponse{Assets: make([]AssetInfo, 0, len(names))}
+    for _, name := range names {
+        info, err := s.assetInfo(r.Context(), l, s.assets[name])
+        if err != nil {
+            s.internalError(w, err)
+            return
+        }
+        resp.Assets = append(resp.Assets, info)
+    }
+
+    if wantsCSV(r) {
+        s.writeBody(w, r, contentTypeCSV, assetsCSV(resp.Assets))
+        return
+    }
+    s.writeJSON(w, r, resp)
+}
+
+func (s *Server) handlePrices(w http.ResponseWriter, r *http.Request) {
+    asset, ok := s.asset(w, r, "/v1/prices/")
+    if !ok {
+        return
+    }
+    query := r.URL.Query()
+
+    from, err := parseTime(query.Get("from"), false)
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "from: "+err.Error())
+        return
+    }
+    to, err := parseTime(query.Get("to"), true)
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "to: "+err.Error())
+        return
+    }
+    var interval Interval
+    if v := query.Get("interval"); v != "" {
+        if interval, err = ParseInterval(v); err != nil {
+            writeError(w, http.StatusBadRequest, err.Error())
+            return
+        }
+    }
+    limit, offset, err := s.page(query.Get("limit"), query.Get("offset"))
+    if err != nil {
+        writeError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+
+    currency, points, ok := s.points(w, r, asset)
+    if !ok {
+        return
+    }
+    points = between(points, from, to)
+    if query.Get("interval") != "" {
+        points = downsample(points, interval)
+    }
+
+    start, end, pagination := paginate(w, r, len(points), limit, offset)
+    resp := PricesResponse{
+        Asset:      asset.Name,
+        Currency:   currency,
+        Interval:   query.Get("interval"),
+        Data:       append([]Point{}, points[start:end]...),
+        Pagination: pagination,
+    }
+
+    if wantsCSV(r) {
+        s.writeBody(w, r, contentTypeCSV, pointsCSV(resp.Data))
+        return
+    }
+    s.writeJSON(w, r, resp)
+}
+
+func (s *Server) handleLatest(w http.ResponseWriter, r *http.Request) {
+    asset, ok := s.asset(w, r, "/v1/latest/")
+    if !ok {
+        return
+    }
+    currency, points, ok := s.points(w, r, asset)
+    if !ok {
+        return
+    }
+    if len(points) == 0 {
+        writeError(w, http.StatusNotFound, fmt.Sprintf("no data stored for %s", asset.Name))
+        return
+    }
+    latest := points[len(points)-1]
+
+    if wantsCSV(r) {
+        s.writeBody(w, r, contentTypeCSV, pointsCSV([]Point{latest}))
+        return
+    }
+    s.writeJSON(w, r, LatestResponse{
+        Asset:    asset.Name,
+        Currency: currency,
+        Point:    latest,
+    })
+}
+
+// assetInfo describes an asset from its stored series
+func (s *Server) assetInfo(ctx context.Context, l *loader, asset AssetConfig) (AssetInfo, error) {
+    series, err := l.load(ctx, seriesKey{asset.Prefix, asset.Format})
+    if err != nil {
+        return AssetInfo{}, err
+    }
+    info := AssetInfo{
+        Name:        asset.Name,
+        Currency:    asset.Currency,
+        Currencies:  s.currencies(asset),
+        Unit:        series.Unit,
+        Points:      len(series.Points),
+        LastUpdated: series.LastUpdated,
+    }
+    if n := len(series.Points); n > 0 {
+        first, last := series.Points[0].Timestamp, series.Points[n-1].Timestamp
+        info.First, info.Last = &first, &last
+    }
+    return info, nil
+}
+
+// asset resolves the asset named by the rest of the path after prefix
+func (s *Server) asset(w http.ResponseWriter, r *http.Request, prefix string) (AssetConfig, bool) {
+    name := strings.TrimPrefix(r.URL.Path, prefix)
+    asset, ok := s.assets[name]
+    if name == "" || strings.Contains(name, "/") || !ok {
+        writeError(w, http.StatusNotFound, fmt.Sprintf("unknown asset %q", name))
+        return asset, false
+    }
+    return asset, true
+}
+
+// CurrencyError is returned when no rate series converts an asset to the
+// requested currency
+type CurrencyError struct {
+    Asset    string
+    Currency string
+}
+
+func (e *CurrencyError) Error() string {
+    return fmt.Sprintf("%s is not available in %s", e.Asset, e.Currency)
+}
+
+// points loads the asset's series in the currency requested by the
+// currency parameter
+func (s *Server) points(w http.ResponseWriter, r *http.Request, asset AssetConfig) (string, []Point, bool) {
+    currency, points, err := s.assetPoints(r.Context(), newLoader(s.storage), asset, r.URL.Query().Get("currency"))
+    var currencyErr *CurrencyError
+    if errors.As(err, &currencyErr) {
+        writeError(w, http.StatusBadRequest, err.Error())
+        return "", nil, false
+    }
+    if err != nil {
+        s.internalError(w, err)
+        return "", nil, false
+    }
+    return currency, points, true
+}
+
+// assetPoints loads the asset's points converted to currency, which
+// defaults to the asset's own
+func (s *Server) assetPoints(ctx context.Context, l *loader, asset AssetConfig, currency string) (string, []Point, error) {
+    currency = strings.ToLower(currency)
+    if currency == "" {
+        currency = asset.Currency
+    }
+
+    series, err := l.load(ctx, seriesKey{asset.Prefix, asset.Format})
+    if err != nil {
+        return "", nil, err
+    }
+    if currency == asset.Currency {
+        return currency, series.Points, nil
+    }
+
+    rate, invert, ok := s.rate(asset.Currency, currency)
+    if !ok {
+        return "", nil, &CurrencyError{Asset: asset.Name, Currency: currency}
+    }
+    rates, err := l.load(ctx, seriesKey{rate.Prefix, rate.Format})
+    if err != nil {
+        return "", nil, err
+    }
+    return currency, convert(series.Points, rates.Points, invert), nil
+}
+
+// rate finds the exchange rate series converting from one currency to
+// another, reporting whether it has to be inverted
+func (s *Server) rate(from, to string) (RateConfig, bool, bool) {
+    for _, r := range s.config.Rates {
+        if r.From == from && r.To == to {
+            return r, false, true
+        }
+        if r.From == to && r.To == from {
+            return r, true, true
+        }
+    }
+    return RateConfig{}, false, false
+}
+
+// currencies lists the currencies an asset can be served in
+func (s *Server) currencies(asset AssetConfig) []string {
+    currencies := []string{asset.Currency}
+    seen := map[string]bool{asset.Currency: true}
+    for _, r := range s.config.Rates {
+        var other string
+        switch asset.Currency {
+        case r.From:
+            other = r.To
+        case r.To:
+            other = r.From
+        default:
+            continue
+        }
+        if !seen[other] {
+            seen[other] = true
+            currencies = append(currencies, other)
+        }
+    }
+    return currencies
+}
+
+// paginate returns the bounds of the requested page of total items and
+// sets the Link and X-Total-Count headers
+func paginate(w http.ResponseWriter, r *http.Request, total, limit, offset int) (int, int, Pagination) {
+    pagination := Pagination{
+        Limit:  limit,
+        Offset: offset,
+        Total:  total,
+    }
+    w.Header().Set("X-Total-Count", strconv.Itoa(total))
+    if offset >= total {
+        return total, total, pagination
+    }
+    end := offset + limit
+    if end > total {
+        end = total
+    }
+    if end < total {
+        next := *r.URL
+        q := next.Query()
+        q.Set("offset", strconv.Itoa(end))
+        q.Set("limit", strconv.Itoa(limit))
+        next.RawQuery = q.Encode()
+        pagination.Next = next.RequestURI()
+        w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", pagination.Next))
+    }
+    return offset, end, pagination
+}
+
+// page parses the limit and offset parameters
+func (s *Server) page(limitParam, offsetParam string) (int, int, error) {
+    limit, offset := s.config.PageSize, 0
+    if limitParam != "" {
+        n, err := strconv.Atoi(limitParam)
+        if err != nil || n <= 0 {
+            return 0, 0, fmt.Errorf("invalid limit %q", limitParam)
+        }
+        limit = n
+    }
+    if limit > s.config.MaxPageSize {
+        limit = s.config.MaxPageSize
+    }
+    if offsetParam != "" {
+        n, err := strconv.Atoi(offsetParam)
+        if err != nil || n < 0 {
+            return 0, 0, fmt.Errorf("invalid offset %q", offsetParam)
+        }
+        offset = n
+    }
+    return limit, offset, nil
+}
+
+func (s *Server) internalError(w http.ResponseWriter, err error) {
+    if errors.Is(err, context.Canceled) {
+        return
+    }
+    log.Printf("API request failed: %v", err)
+    writeError(w, http.StatusInternalServerError, "internal error")
+} 
\ No newline at end of file
@@ -0,0 +1,226 @@
+package backfill
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+const day = 24 * time.Hour
+
+// Source is a crawler that can fetch an explicit date range
+type Source interface {
+    // Name returns the crawler's name
+    Name() string
+
+    // StoredDays returns the days, truncated to UTC midnight, that already have data
+    StoredDays(ctx context.Context) ([]time.Time, error)
+
+    // FetchRange fetches [from, to) and stores the days not stored yet,
+    // returning how many were added
+    FetchRange(ctx context.Context, from, to time.Time) (int, error)
+}
+
+// Chunk is a range of missing days requested at once
+type Chunk struct {
+    From time.Time `json:"from" bson:"from"`
+    To   time.Time `json:"to" bson:"to"`
+    Done bool      `json:"done" bson:"done"`
+    // Added is how many days the chunk stored
+    Added int `json:"added" bson:"added"`
+}
+
+// State is the saved progress of a backfill, so an interrupted run resumes
+// from the last completed chunk
+type State struct {
+    Source string    `json:"source" bson:"source"`
+    From   time.Time `json:"from" bson:"from"`
+    To     time.Time `json:"to" bson:"to"`
+    Chunks []Chunk   `json:"chunks" bson:"chunks"`
+    // Unfillable are the completed chunks the source added nothing for, e.g.
+    // days before an asset was listed. Later runs leave them out.
+    Unfillable []Chunk   `json:"unfillable,omitempty" bson:"unfillable,omitempty"`
+    UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// Config holds configuration for a backfill run
+type Config struct {
+    // From and To bound the days to check, To exclusive. A zero From starts
+    // at the first stored day and a zero To ends today.
+    From time.Time
+    To   time.Time
+    // ChunkDays caps the days requested at once, defaults to 90
+    ChunkDays int
+    // Delay is waited between chunks to stay within API limits
+    Delay time.Duration
+}
+
+// Backfiller fills the missing days of a source
+type Backfiller struct {
+    storage storage.Storage
+    source  Source
+    config  Config
+}
+
+// NewBackfiller creates a new Backfiller
+func NewBackfiller(storage storage.Storage, source Source, config Config) *Backfiller {
+    if config.ChunkDays <= 0 {
+        config.ChunkDays = 90
+    }
+    return &Backfiller{
+        storage: storage,
+        source:  source,
+        config:  config,
+    }
+}
+
+// Run detects the missing days in the range and fetches them chunk by
+// chunk, saving progress after every chunk. It returns the days added.
+func (b *Backfiller) Run(ctx context.Context) (int, error) {
+    key := fmt.Sprintf("backfill/%s/state.json", b.source.Name())
+
+    stored, err := b.source.StoredDays(ctx)
+    if err != nil {
+        return 0, err
+    }
+    from, to := b.bounds(stored)
+    if !from.Before(to) {
+        return 0, fmt.Errorf("empty backfill range %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+    }
+
+    // Resume an unfinished run over the same range, otherwise plan afresh
+    var state State
+    if err := b.storage.Load(ctx, key, &state); err != nil && !errors.Is(err, storage.ErrNotFound) {
+        return 0, fmt.Errorf("failed to load backfill state: %w", err)
+    }
+    if state.From.Equal(from) && state.To.Equal(to) && !state.done() {
+        log.Printf("Backfill %s: resuming %s to %s", b.source.Name(), from.Format("2006-01-02"), to.Format("2006-01-02"))
+    } else {
+        missing := skipUnfillable(Missing(stored, from, to), state.Unfillable)
+        state = State{
+            Source:     b.source.Name(),
+            From:       from,
+            To:         to,
+            Chunks:     Plan(missing, b.config.ChunkDays),
+            Unfillable: state.Unfillable,
+        }
+    }
+
+    total := 0
+    for i := range state.Chunks {
+        chunk := &state.Chunks[i]
+        if chunk.Done {
+            continue
+        }
+        if i > 0 && b.config.Delay > 0 {
+            select {
+            case <-ctx.Done():
+                return total, ctx.Err()
+            case <-time.After(b.config.Delay):
+            }
+        }
+
+        added, err := b.source.FetchRange(ctx, chunk.From, chunk.To)
+        if err != nil {
+            return total, fmt.Errorf("chunk %s to %s: %w", chunk.From.Format("2006-01-02"), chunk.To.Format("2006-01-02"), err)
+        }
+        chunk.Done = true
+        chunk.Added = added
+        total += added
+        if added == 0 {
+            state.Unfillable = append(state.Unfillable, *chunk)
+        }
+        log.Printf("Backfill %s: chunk %d/%d %s to %s added %d days", b.source.Name(), i+1, len(state.Chunks),
+            chunk.From.Format("2006-01-02"), chunk.To.Format("2006-01-02"), added)
+
+        state.UpdatedAt = time.Now().UTC()
+        if err := b.storage.Save(ctx, key, state); err != nil {
+            return total, fmt.Errorf("failed to save backfill state: %w", err)
+        }
+    }
+
+    if len(state.Chunks) == 0 {
+        log.Printf("Backfill %s: no missing days", b.source.Name())
+    }
+    return total, nil
+}
+
+// bounds resolves the configured range, defaulting to the stored history up to today
+func (b *Backfiller) bounds(stored []time.Time) (time.Time, time.Time) {
+    from := b.config.From.UTC().Truncate(day)
+    if b.config.From.IsZero() {
+        for _, d := range stored {
+            if from.IsZero() || d.Before(from) {
+                from = d
+            }
+        }
+    }
+    to := b.config.To.UTC().Truncate(day)
+    if b.config.To.IsZero() {
+        to = time.Now().UTC().Truncate(day)
+    }
+    return from, to
+}
+
+func (s *State) done() bool {
+    for _, c := range s.Chunks {
+        if !c.Done {
+            return false
+        }
+    }
+    return true
+}
+
+// Missing returns the days in [from, to) that are not stored
+func Missing(stored []time.Time, from, to time.Time) []time.Time {
+    have := make(map[time.Time]bool, len(stored))
+    for _, d := range stored {
+        have[d.UTC().Truncate(day)] = true
+    }
+
+    var missing []time.Time
+    for d := from; d.Before(to); d = d.Add(day) {
+        if !have[d] {
+            missing = append(missing, d)
+        }
+    }
+    return missing
+}
+
+// skipUnfillable drops the days covered by the unfillable chunks
+func skipUnfillable(missing []time.Time, unfillable []Chunk) []time.Time {
+    var kept []time.Time
+    for _, d := range missing {
+        skip := false
+        for _, c := range unfillable {
+            if !d.Before(c.From) && d.Before(c.To) {
+                skip = true
+                break
+            }
+        }
+        if !skip {
+            kept = append(kept, d)
+        }
+    }
+    return kept
+}
+
+// Plan groups sorted missing days into runs of consecutive days and splits
+// every run into chunks of at most chunkDays
+func Plan(missing []time.Time, chunkDays int) []Chunk {
+    var chunks []Chunk
+    for i := 0; i < len(missing); {
+        start := missing[i]
+        j := i + 1
+        for j < len(missing) && j-i < chunkDays && missing[j].Equal(missing[j-1].Add(day)) {
+            j++
+        }
+        chunks = append(chunks, Chunk{From: start, To: missing[j-1].Add(day)})
+        i = j
+    }
+    return chunks
+} 
\ No newline at end of file
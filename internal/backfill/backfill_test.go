@@ -0,0 +1,156 @@
+package backfill
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func date(month time.Month, d int) time.Time {
+    return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// fakeSource stores every requested day from listed on, and fails the
+// request numbered failAt
+type fakeSource struct {
+    stored   map[time.Time]bool
+    listed   time.Time
+    requests [][2]time.Time
+    failAt   int
+}
+
+func (s *fakeSource) Name() string { return "fake" }
+
+func (s *fakeSource) StoredDays(ctx context.Context) ([]time.Time, error) {
+    var days []time.Time
+    for d := range s.stored {
+        days = append(days, d)
+    }
+    return days, nil
+}
+
+func (s *fakeSource) FetchRange(ctx context.Context, from, to time.Time) (int, error) {
+    s.requests = append(s.requests, [2]time.Time{from, to})
+    if len(s.requests) == s.failAt {
+        return 0, errors.New("upstream unavailable")
+    }
+    added := 0
+    for d := from; d.Before(to); d = d.Add(day) {
+        if !d.Before(s.listed) && !s.stored[d] {
+            s.stored[d] = true
+            added++
+        }
+    }
+    return added, nil
+}
+
+func TestMissing(t *testing.T) {
+    stored := []time.Time{date(1, 1), date(1, 3).Add(12 * time.Hour), date(1, 6)}
+    got := Missing(stored, date(1, 1), date(1, 7))
+    want := []time.Time{date(1, 2), date(1, 4), date(1, 5)}
+    if len(got) != len(want) {
+        t.Fatalf("Missing = %v, want %v", got, want)
+    }
+    for i := range want {
+        if !got[i].Equal(want[i]) {
+            t.Errorf("Missing[%d] = %s, want %s", i, got[i], want[i])
+        }
+    }
+}
+
+func TestPlan(t *testing.T) {
+    missing := []time.Time{date(1, 2), date(1, 4), date(1, 5), date(1, 6), date(1, 7), date(1, 8), date(1, 10)}
+    got := Plan(missing, 3)
+    want := []Chunk{
+        {From: date(1, 2), To: date(1, 3)},
+        {From: date(1, 4), To: date(1, 7)},
+        {From: date(1, 7), To: date(1, 9)},
+        {From: date(1, 10), To: date(1, 11)},
+    }
+    if len(got) != len(want) {
+        t.Fatalf("Plan = %+v, want %+v", got, want)
+    }
+    for i := range want {
+        if !got[i].From.Equal(want[i].From) || !got[i].To.Equal(want[i].To) {
+            t.Errorf("chunk %d = %s to %s, want %s to %s", i, got[i].From, got[i].To, want[i].From, want[i].To)
+        }
+    }
+}
+
+func TestRunResumesAfterInterrupt(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    source := &fakeSource{stored: map[time.Time]bool{date(1, 5): true}, failAt: 2}
+    config := Config{From: date(1, 1), To: date(1, 11), ChunkDays: 3}
+
+    added, err := NewBackfiller(s, source, config).Run(ctx)
+    if err == nil {
+        t.Fatal("Run succeeded despite the failing chunk")
+    }
+    if added != 3 {
+        t.Errorf("added %d days before the failure, want 3", added)
+    }
+
+    var state State
+    if err := s.Load(ctx, "backfill/fake/state.json", &state); err != nil {
+        t.Fatalf("Load state: %v", err)
+    }
+    if len(state.Chunks) != 4 || !state.Chunks[0].Done || state.Chunks[1].Done {
+        t.Fatalf("saved state = %+v, want the first of 4 chunks done", state.Chunks)
+    }
+
+    // The next run requests only the chunks that were not completed
+    source.requests = nil
+    source.failAt = 0
+    added, err = NewBackfiller(s, source, config).Run(ctx)
+    if err != nil {
+        t.Fatalf("Run: %v", err)
+    }
+    if added != 6 {
+        t.Errorf("resumed run added %d days, want 6", added)
+    }
+    if len(source.requests) != 3 || !source.requests[0][0].Equal(date(1, 4)) {
+        t.Errorf("resumed requests = %v, want the chunks from 4 January", source.requests)
+    }
+    if missing := Missing(mustStored(t, source), date(1, 1), date(1, 11)); len(missing) != 0 {
+        t.Errorf("days still missing: %v", missing)
+    }
+}
+
+func TestRunSkipsUnfillableDays(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    source := &fakeSource{stored: map[time.Time]bool{date(1, 10): true}, listed: date(1, 7)}
+    config := Config{From: date(1, 1), To: date(1, 11), ChunkDays: 3}
+
+    added, err := NewBackfiller(s, source, config).Run(ctx)
+    if err != nil {
+        t.Fatalf("Run: %v", err)
+    }
+    if added != 3 {
+        t.Errorf("added %d days, want 3", added)
+    }
+
+    // The days before listing came back empty and are not requested again,
+    // even over a different range
+    source.requests = nil
+    config.To = date(1, 12)
+    if _, err := NewBackfiller(s, source, config).Run(ctx); err != nil {
+        t.Fatalf("Run: %v", err)
+    }
+    if len(source.requests) != 1 || !source.requests[0][0].Equal(date(1, 11)) {
+        t.Errorf("requests = %v, want only 11 January", source.requests)
+    }
+}
+
+func mustStored(t *testing.T, source *fakeSource) []time.Time {
+    t.Helper()
+    days, err := source.StoredDays(context.Background())
+    if err != nil {
+        t.Fatal(err)
+    }
+    return days
+} 
\ No newline at end of file
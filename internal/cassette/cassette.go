@@ -0,0 +1,193 @@
+package cassette
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+// Modes supported by Load
+const (
+    // ModeReplay serves responses from the cassette and never touches the network
+    ModeReplay = "replay"
+    // ModeRecord sends requests upstream and records every response
+    ModeRecord = "record"
+)
+
+// ErrNoInteraction is returned in replay mode for a request the cassette
+// does not contain
+var ErrNoInteraction = errors.New("cassette: no recorded interaction")
+
+// redactedHeaders are dropped from recorded responses
+var redactedHeaders = []string{"Set-Cookie", "Date", "Age"}
+
+// Request identifies a recorded request
+type Request struct {
+    Method string `json:"method"`
+    URL    string `json:"url"`
+}
+
+// Response is a recorded response
+type Response struct {
+    StatusCode int         `json:"status_code"`
+    Header     http.Header `json:"header"`
+    Body       string      `json:"body"`
+}
+
+// Interaction is a request with the response it received
+type Interaction struct {
+    Request  Request  `json:"request"`
+    Response Response `json:"response"`
+}
+
+// Cassette records HTTP interactions to a JSON file and replays them, so
+// crawlers can run offline and deterministically. It is safe for
+// concurrent use.
+type Cassette struct {
+    path string
+    mode string
+
+    mu           sync.Mutex
+    interactions []Interaction
+    used         []bool
+}
+
+// Load opens the cassette at path. Replay mode reads the recorded
+// interactions; record mode starts empty and overwrites the file on Save.
+func Load(path, mode string) (*Cassette, error) {
+    c := &Cassette{path: path, mode: mode}
+    switch mode {
+    case ModeRecord:
+        return c, nil
+    case ModeReplay:
+    default:
+        return nil, fmt.Errorf("cassette: unknown mode %q", mode)
+    }
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read cassette: %w", err)
+    }
+    if err := json.Unmarshal(data, &c.interactions); err != nil {
+        return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+    }
+    c.used = make([]bool, len(c.interactions))
+    return c, nil
+}
+
+// Transport returns a RoundTripper that replays from the cassette, or in
+// record mode forwards to next and records the responses
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+    return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+        if c.mode == ModeReplay {
+            return c.replay(req)
+        }
+        return c.record(next, req)
+    })
+}
+
+// Save writes the recorded interactions; it does nothing in replay mode
+func (c *Cassette) Save() error {
+    if c.mode != ModeRecord {
+        return nil
+    }
+
+    // Keep URLs readable instead of escaping & as \u0026
+    var buf bytes.Buffer
+    enc := json.NewEncoder(&buf)
+    enc.SetEscapeHTML(false)
+    enc.SetIndent("", "  ")
+    c.mu.Lock()
+    err := enc.Encode(c.interactions)
+    c.mu.Unlock()
+    if err != nil {
+        return fmt.Errorf("failed to encode cassette: %w", err)
+    }
+
+    if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+        return fmt.Errorf("failed to create cassette directory: %w", err)
+    }
+    if err := os.WriteFile(c.path, buf.Bytes(), 0o644); err != nil {
+        return fmt.Errorf("failed to write cassette: %w", err)
+    }
+    return nil
+}
+
+// replay returns the first unused interaction matching the request, so
+// repeated requests to one URL are served in recorded order
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    url := req.URL.String()
+    for i, in := range c.interactions {
+        if c.used[i] || in.Request.Method != req.Method || in.Request.URL != url {
+            continue
+        }
+        c.used[i] = true
+        return in.Response.build(req), nil
+    }
+    return nil, &httpclient.PermanentError{Err: fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, url)}
+}
+
+// record forwards the request and keeps a copy of the response
+func (c *Cassette) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+    resp, err := next.RoundTrip(req)
+    if err != nil {
+        return nil, err
+    }
+
+    body, err := io.ReadAll(resp.Body)
+    resp.Body.Close()
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response body: %w", err)
+    }
+    resp.Body = io.NopCloser(bytes.NewReader(body))
+
+    header := resp.Header.Clone()
+    for _, name := range redactedHeaders {
+        header.Del(name)
+    }
+
+    c.mu.Lock()
+    c.interactions = append(c.interactions, Interaction{
+        Request: Request{Method: req.Method, URL: req.URL.String()},
+        Response: Response{
+            StatusCode: resp.StatusCode,
+            Header:     header,
+            Body:       string(body),
+        },
+    })
+    c.mu.Unlock()
+    return resp, nil
+}
+
+// build turns a recorded response into an http.Response
+func (r Response) build(req *http.Request) *http.Response {
+    return &http.Response{
+        Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+        StatusCode:    r.StatusCode,
+        Proto:         "HTTP/1.1",
+        ProtoMajor:    1,
+        ProtoMinor:    1,
+        Header:        r.Header.Clone(),
+        Body:          io.NopCloser(strings.NewReader(r.Body)),
+        ContentLength: int64(len(r.Body)),
+        Request:       req,
+    }
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+    return f(req)
+} 
\ No newline at end of file
@@ -0,0 +1,172 @@
+package cassette
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync/atomic"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+func get(t *testing.T, rt http.RoundTripper, method, url string) (*http.Response, string, error) {
+    t.Helper()
+    req, err := http.NewRequest(method, url, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp, err := rt.RoundTrip(req)
+    if err != nil {
+        return nil, "", err
+    }
+    defer resp.Body.Close()
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return resp, string(body), nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+    var hits int32
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        n := atomic.AddInt32(&hits, 1)
+        w.Header().Set("Content-Type", "application/json")
+        w.Header().Set("Set-Cookie", "session=secret")
+        w.WriteHeader(http.StatusCreated)
+        fmt.Fprintf(w, `{"method":%q,"call":%d}`, r.Method, n)
+    }))
+    defer server.Close()
+
+    path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+    url := server.URL + "/prices?a=1&b=2"
+    rec, err := Load(path, ModeRecord)
+    if err != nil {
+        t.Fatal(err)
+    }
+    rt := rec.Transport(http.DefaultTransport)
+    for _, method := range []string{http.MethodGet, http.MethodGet, http.MethodPost} {
+        // Recording passes the response on to the caller unchanged
+        resp, body, err := get(t, rt, method, url)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if resp.StatusCode != http.StatusCreated || resp.Header.Get("Set-Cookie") == "" || !strings.Contains(body, method) {
+            t.Errorf("recorded %s: %d %v %s", method, resp.StatusCode, resp.Header, body)
+        }
+    }
+    if err := rec.Save(); err != nil {
+        t.Fatal(err)
+    }
+
+    // The file is an indented list of interactions with readable URLs and
+    // without the cookies and dates of the live responses
+    raw, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !strings.Contains(string(raw), `"url": "`+url+`"`) || strings.Contains(string(raw), "secret") || strings.Contains(string(raw), `"Date"`) {
+        t.Errorf("cassette file:\n%s", raw)
+    }
+    var saved []Interaction
+    if err := json.Unmarshal(raw, &saved); err != nil {
+        t.Fatal(err)
+    }
+    if len(saved) != 3 || saved[0].Request.Method != http.MethodGet || saved[2].Request.Method != http.MethodPost ||
+        saved[0].Response.StatusCode != http.StatusCreated || saved[0].Response.Header.Get("Content-Type") != "application/json" {
+        t.Errorf("saved interactions = %+v", saved)
+    }
+
+    // Replay serves matching requests in recorded order without the server
+    server.Close()
+    before := atomic.LoadInt32(&hits)
+    cas, err := Load(path, ModeReplay)
+    if err != nil {
+        t.Fatal(err)
+    }
+    rt = cas.Transport(nil)
+    for _, want := range []struct{ method, body string }{
+        {http.MethodPost, `{"method":"POST","call":3}`},
+        {http.MethodGet, `{"method":"GET","call":1}`},
+        {http.MethodGet, `{"method":"GET","call":2}`},
+    } {
+        resp, body, err := get(t, rt, want.method, url)
+        if err != nil {
+            t.Fatalf("replay %s: %v", want.method, err)
+        }
+        if resp.StatusCode != http.StatusCreated || body != want.body || resp.Header.Get("Content-Type") != "application/json" {
+            t.Errorf("replayed %s: %d %s", want.method, resp.StatusCode, body)
+        }
+    }
+    if atomic.LoadInt32(&hits) != before {
+        t.Error("replay reached the server")
+    }
+}
+
+func TestReplayMiss(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "cassette.json")
+    recorded := `[{"request":{"method":"GET","url":"https://example.com/a?x=1"},"response":{"status_code":200,"body":"ok"}}]`
+    if err := os.WriteFile(path, []byte(recorded), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    cas, err := Load(path, ModeReplay)
+    if err != nil {
+        t.Fatal(err)
+    }
+    rt := cas.Transport(nil)
+
+    for _, tc := range []struct{ method, url string }{
+        {http.MethodGet, "https://example.com/a?x=2"},
+        {http.MethodGet, "https://example.com/b?x=1"},
+        {http.MethodHead, "https://example.com/a?x=1"},
+    } {
+        if _, _, err := get(t, rt, tc.method, tc.url); !errors.Is(err, ErrNoInteraction) {
+            t.Errorf("%s %s: err = %v, want ErrNoInteraction", tc.method, tc.url, err)
+        }
+    }
+
+    // An interaction is replayed once, and a miss is not worth retrying
+    if _, body, err := get(t, rt, http.MethodGet, "https://example.com/a?x=1"); err != nil || body != "ok" {
+        t.Fatalf("replay = %q, %v", body, err)
+    }
+    _, _, err = get(t, rt, http.MethodGet, "https://example.com/a?x=1")
+    var permanent *httpclient.PermanentError
+    if !errors.Is(err, ErrNoInteraction) || !errors.As(err, &permanent) {
+        t.Errorf("second replay err = %v, want a permanent ErrNoInteraction", err)
+    }
+
+    // Replay mode never rewrites the cassette
+    if err := cas.Save(); err != nil {
+        t.Fatal(err)
+    }
+    if raw, err := os.ReadFile(path); err != nil || string(raw) != recorded {
+        t.Errorf("cassette changed to %s, %v", raw, err)
+    }
+}
+
+func TestLoad(t *testing.T) {
+    dir := t.TempDir()
+    if _, err := Load(filepath.Join(dir, "missing.json"), ModeReplay); err == nil {
+        t.Error("replaying a missing cassette succeeded")
+    }
+    if _, err := Load(filepath.Join(dir, "missing.json"), ModeRecord); err != nil {
+        t.Errorf("recording a new cassette: %v", err)
+    }
+    if _, err := Load(filepath.Join(dir, "missing.json"), "live"); err == nil {
+        t.Error("an unknown mode was accepted")
+    }
+    path := filepath.Join(dir, "broken.json")
+    if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Load(path, ModeReplay); err == nil {
+        t.Error("a broken cassette was loaded")
+    }
+} 
\ No newline at end of file
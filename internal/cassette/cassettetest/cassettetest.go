@@ -0,0 +1,46 @@
+// Package cassettetest replays the cassettes of crawler tests. Importing it
+// registers the -record test flag, which refreshes the cassettes from the
+// live APIs instead:
+//
+//	go test ./internal/crawler/... ./internal/collector -record
+package cassettetest
+
+import (
+    "flag"
+    "net/http"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/cassette"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+var record = flag.Bool("record", false, "record the cassettes from the live APIs instead of replaying them")
+
+// Client returns a client replaying the cassette at path, or recording it
+// with -record. A recording is saved even when the test fails, since the
+// tests check invariants a fresh response may break rather than the
+// cassette itself.
+func Client(t testing.TB, path string) *httpclient.Client {
+    t.Helper()
+
+    mode := cassette.ModeReplay
+    if *record {
+        mode = cassette.ModeRecord
+    }
+    cas, err := cassette.Load(path, mode)
+    if err != nil {
+        t.Fatalf("failed to load cassette: %v", err)
+    }
+    t.Cleanup(func() {
+        if err := cas.Save(); err != nil {
+            t.Errorf("failed to save cassette: %v", err)
+        }
+    })
+
+    // Replays are deterministic, so a failed request is not retried
+    cfg := httpclient.Config{MaxRetries: -1}
+    if *record {
+        cfg = httpclient.Config{}
+    }
+    return httpclient.NewWithTransport(cfg, cas.Transport(http.DefaultTransport))
+} 
\ No newline at end of file
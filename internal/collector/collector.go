@@ -4,12 +4,14 @@ import (
     "context"
     "encoding/json"
     "fmt"
-    "net/http"
+    "log"
     "time"
 
     "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
     "github.com/yourusername/investutil-gocrawler/internal/models"
-    "github.com/yourusername/investutil-gocrawler/internal/queue"
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
 )
 
 // Collector defines the interface for data collectors
@@ -24,16 +26,25 @@ type Collector interface {
     Schedule() string
 }
 
+// Queue carries collected data from Collect to Process. queue.RabbitMQ
+// implements it.
+type Queue interface {
+    // Publish sends a message
+    Publish(ctx context.Context, body []byte) error
+    // Consume passes every message to handler until ctx is done
+    Consume(ctx context.Context, handler func(context.Context, []byte) error) error
+}
+
 // BaseCollector provides common functionality for collectors
 type BaseCollector struct {
     name     string
     schedule string
     db       database.Database
-    queue    *queue.RabbitMQ
+    queue    Queue
 }
 
 // NewBaseCollector creates a new BaseCollector
-func NewBaseCollector(name, schedule string, db database.Database, queue *queue.RabbitMQ) *BaseCollector {
+func NewBaseCollector(name, schedule string, db database.Database, queue Queue) *BaseCollector {
     return &BaseCollector{
         name:     name,
         schedule: schedule,
@@ -52,44 +63,48 @@ func (b *BaseCollector) Schedule() string {
     return b.schedule
 }
 
+const coinGeckoBaseURL = "https://api.coingecko.com/api/v3"
+
 // BitcoinCollector implements bitcoin price data collector
 type BitcoinCollector struct {
     *BaseCollector
-    client *http.Client
+    client  *httpclient.Client
+    baseURL string
 }
 
-// NewBitcoinCollector creates a new BitcoinCollector
-func NewBitcoinCollector(db database.Database, queue *queue.RabbitMQ, schedule string) *BitcoinCollector {
+// NewBitcoinCollector creates a new BitcoinCollector; an empty baseURL uses
+// the CoinGecko API
+func NewBitcoinCollector(db database.Database, queue Queue, client *httpclient.Client, schedule, baseURL string) *BitcoinCollector {
+    if baseURL == "" {
+        baseURL = coinGeckoBaseURL
+    }
     return &BitcoinCollector{
         BaseCollector: NewBaseCollector("bitcoin-price", schedule, db, queue),
-        client: &http.Client{
-            Timeout: time.Second * 30,
-        },
+        client:        client,
+        baseURL:       baseURL,
     }
 }
 
-// Collect implements Collector.Collect for BitcoinCollector
-func (c *BitcoinCollector) Collect(ctx context.Context) error {
-    url := "https://api.coingecko.com/api/v3/coins/bitcoin/market_chart?vs_currency=usd&days=max&interval=daily"
-    
-    req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-    if err != nil {
-        return fmt.Errorf("failed to create request: %w", err)
-    }
-
-    resp, err := c.client.Do(req)
-    if err != nil {
-        return fmt.Errorf("failed to fetch data: %w", err)
-    }
-    defer resp.Body.Close()
+// Collect implements Collector.Collect for BitcoinCollector. The fetch and
+// the publish are traced under one span, whose context travels with the
+// message to the processor.
+func (c *BitcoinCollector) Collect(ctx context.Context) (err error) {
+    ctx, span := tracing.Tracer().Start(ctx, "collect "+c.Name())
+    defer func() { tracing.End(span, err) }()
 
-    if resp.StatusCode != http.StatusOK {
-        return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-    }
+    url := c.baseURL + "/coins/bitcoin/market_chart?vs_currency=usd&days=max&interval=daily"
 
     var geckoResp models.CoinGeckoResponse
-    if err := json.NewDecoder(resp.Body).Decode(&geckoResp); err != nil {
-        return fmt.Errorf("failed to decode response: %w", err)
+    if err := c.client.GetJSON(ctx, url, &geckoResp); err != nil {
+        return err
+    }
+    if err := validation.CoinGecko(c.Name(), &geckoResp); err != nil {
+        if event := validation.NewDriftEvent(err); event != nil {
+            if saveErr := c.db.SaveDriftEvent(ctx, *event); saveErr != nil {
+                log.Printf("Failed to save drift event: %v", saveErr)
+            }
+        }
+        return err
     }
 
     // Convert response to our data model
@@ -127,7 +142,7 @@ func (c *BitcoinCollector) Collect(ctx context.Context) error {
 
 // Process implements Collector.Process for BitcoinCollector
 func (c *BitcoinCollector) Process(ctx context.Context) error {
-    return c.queue.Consume(ctx, func(data []byte) error {
+    return c.queue.Consume(ctx, func(ctx context.Context, data []byte) error {
         var priceData models.BitcoinDailyData
         if err := json.Unmarshal(data, &priceData); err != nil {
             return fmt.Errorf("failed to unmarshal data: %w", err)
@@ -0,0 +1,250 @@
+package collector
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log"
+    "net/url"
+    "sort"
+    "strconv"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
+)
+
+// Supported exchanges for MarketConfig.Exchange
+const (
+    ExchangeBinance  = "binance"
+    ExchangeCoinbase = "coinbase"
+    ExchangeKraken   = "kraken"
+)
+
+const defaultOrderBookDepth = 20
+
+var defaultExchangeURLs = map[string]string{
+    ExchangeBinance:  "https://api.binance.com",
+    ExchangeCoinbase: "https://api.exchange.coinbase.com",
+    ExchangeKraken:   "https://api.kraken.com",
+}
+
+// binanceDepthLimits are the limit values accepted by the Binance depth endpoint
+var binanceDepthLimits = []int{5, 10, 20, 50, 100, 500, 1000, 5000}
+
+// OrderBookConfig holds configuration for OrderBookCollector
+type OrderBookConfig struct {
+    Schedule string `yaml:"schedule"`
+    // Depth is the number of levels kept per side
+    Depth int `yaml:"depth"`
+    // Interval makes Collect snapshot repeatedly until cancelled; zero snapshots once
+    Interval time.Duration  `yaml:"interval"`
+    Markets  []MarketConfig `yaml:"markets"`
+}
+
+// MarketConfig identifies an order book on an exchange
+type MarketConfig struct {
+    Exchange string `yaml:"exchange"`
+    // Symbol uses the exchange's own notation, e.g. BTCUSDT, BTC-USD or XBTUSD
+    Symbol  string `yaml:"symbol"`
+    BaseURL string `yaml:"base_url"`
+}
+
+// OrderBookCollector snapshots top-N order book depth from exchange REST endpoints
+type OrderBookCollector struct {
+    *BaseCollector
+    client *httpclient.Client
+    config *OrderBookConfig
+}
+
+// NewOrderBookCollector creates a new OrderBookCollector
+func NewOrderBookCollector(db database.Database, queue Queue, client *httpclient.Client, config *OrderBookConfig) *OrderBookCollector {
+    return &OrderBookCollector{
+        BaseCollector: NewBaseCollector("orderbook", config.Schedule, db, queue),
+        client:        client,
+        config:        config,
+    }
+}
+
+// Collect implements Collector.Collect for OrderBookCollector
+func (c *OrderBookCollector) Collect(ctx context.Context) error {
+    if c.config.Interval <= 0 {
+        return c.snapshotAll(ctx)
+    }
+
+    ticker := time.NewTicker(c.config.Interval)
+    defer ticker.Stop()
+
+    for {
+        if err := c.snapshotAll(ctx); err != nil {
+            log.Printf("Collector %s: %v", c.Name(), err)
+        }
+
+        select {
+        case <-ctx.Done():
+            return nil
+        case <-ticker.C:
+        }
+    }
+}
+
+// snapshotAll snapshots every configured market and publishes the results.
+// A failing market does not stop the others.
+func (c *OrderBookCollector) snapshotAll(ctx context.Context) (err error) {
+    ctx, span := tracing.Tracer().Start(ctx, "collect "+c.Name())
+    defer func() { tracing.End(span, err) }()
+
+    var errs []error
+    for _, market := range c.config.Markets {
+        snapshot, err := c.snapshot(ctx, market)
+        if err != nil {
+            errs = append(errs, fmt.Errorf("%s %s: %w", market.Exchange, market.Symbol, err))
+            continue
+        }
+
+        body, err := json.Marshal(snapshot)
+        if err != nil {
+            return fmt.Errorf("failed to marshal snapshot: %w", err)
+        }
+        if err := c.queue.Publish(ctx, body); err != nil {
+            return fmt.Errorf("failed to publish snapshot: %w", err)
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// snapshot fetches and normalizes the order book of a single market
+func (c *OrderBookCollector) snapshot(ctx context.Context, market MarketConfig) (*models.OrderBookSnapshot, error) {
+    depth := c.config.Depth
+    if depth <= 0 {
+        depth = defaultOrderBookDepth
+    }
+
+    baseURL := market.BaseURL
+    if baseURL == "" {
+        baseURL = defaultExchangeURLs[market.Exchange]
+    }
+
+    snapshot := &models.OrderBookSnapshot{
+        Exchange:  market.Exchange,
+        Symbol:    market.Symbol,
+        Timestamp: time.Now().UTC(),
+    }
+
+    var bids, asks [][]interface{}
+    switch market.Exchange {
+    case ExchangeBinance:
+        limit := binanceDepthLimits[len(binanceDepthLimits)-1]
+        for _, l := range binanceDepthLimits {
+            if l >= depth {
+                limit = l
+                break
+            }
+        }
+        var resp models.BinanceDepthResponse
+        reqURL := fmt.Sprintf("%s/api/v3/depth?symbol=%s&limit=%d", baseURL, url.QueryEscape(market.Symbol), limit)
+        if err := c.client.GetJSON(ctx, reqURL, &resp); err != nil {
+            return nil, err
+        }
+        snapshot.Sequence = resp.LastUpdateID
+        bids, asks = resp.Bids, resp.Asks
+
+    case ExchangeCoinbase:
+        var resp models.CoinbaseBookResponse
+        reqURL := fmt.Sprintf("%s/products/%s/book?level=2", baseURL, url.PathEscape(market.Symbol))
+        if err := c.client.GetJSON(ctx, reqURL, &resp); err != nil {
+            return nil, err
+        }
+        snapshot.Sequence = resp.Sequence
+        bids, asks = resp.Bids, resp.Asks
+
+    case ExchangeKraken:
+        var resp models.KrakenDepthResponse
+        reqURL := fmt.Sprintf("%s/0/public/Depth?pair=%s&count=%d", baseURL, url.QueryEscape(market.Symbol), depth)
+        if err := c.client.GetJSON(ctx, reqURL, &resp); err != nil {
+            return nil, err
+        }
+        if len(resp.Error) > 0 {
+            return nil, fmt.Errorf("api error: %v", resp.Error)
+        }
+        // Kraken keys the result by its own pair name, e.g. XXBTZUSD for XBTUSD
+        for _, book := range resp.Result {
+            bids, asks = book.Bids, book.Asks
+            break
+        }
+
+    default:
+        return nil, fmt.Errorf("unsupported exchange %q", market.Exchange)
+    }
+
+    var err error
+    if snapshot.Bids, err = parseLevels(bids, depth, true); err != nil {
+        return nil, fmt.Errorf("invalid bids: %w", err)
+    }
+    if snapshot.Asks, err = parseLevels(asks, depth, false); err != nil {
+        return nil, fmt.Errorf("invalid asks: %w", err)
+    }
+
+    return snapshot, nil
+}
+
+// parseLevels converts raw [price, quantity, ...] entries into sorted levels truncated to depth
+func parseLevels(raw [][]interface{}, depth int, descending bool) ([]models.OrderBookLevel, error) {
+    levels := make([]models.OrderBookLevel, 0, len(raw))
+    for i, entry := range raw {
+        if len(entry) < 2 {
+            return nil, fmt.Errorf("level %d: expected price and quantity", i)
+        }
+        price, err := parseNumber(entry[0])
+        if err != nil {
+            return nil, fmt.Errorf("level %d: price: %w", i, err)
+        }
+        quantity, err := parseNumber(entry[1])
+        if err != nil {
+            return nil, fmt.Errorf("level %d: quantity: %w", i, err)
+        }
+        levels = append(levels, models.OrderBookLevel{Price: price, Quantity: quantity})
+    }
+
+    sort.Slice(levels, func(i, j int) bool {
+        if descending {
+            return levels[i].Price > levels[j].Price
+        }
+        return levels[i].Price < levels[j].Price
+    })
+    if len(levels) > depth {
+        levels = levels[:depth]
+    }
+    return levels, nil
+}
+
+// parseNumber accepts exchange numbers sent either as JSON strings or numbers
+func parseNumber(v interface{}) (float64, error) {
+    switch n := v.(type) {
+    case string:
+        return strconv.ParseFloat(n, 64)
+    case float64:
+        return n, nil
+    default:
+        return 0, fmt.Errorf("unexpected type %T", v)
+    }
+}
+
+// Process implements Collector.Process for OrderBookCollector
+func (c *OrderBookCollector) Process(ctx context.Context) error {
+    return c.queue.Consume(ctx, func(ctx context.Context, data []byte) error {
+        var snapshot models.OrderBookSnapshot
+        if err := json.Unmarshal(data, &snapshot); err != nil {
+            return fmt.Errorf("failed to unmarshal snapshot: %w", err)
+        }
+
+        if err := c.db.SaveOrderBookSnapshot(ctx, snapshot); err != nil {
+            return fmt.Errorf("failed to save snapshot: %w", err)
+        }
+
+        return nil
+    })
+} 
\ No newline at end of file
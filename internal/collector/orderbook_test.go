@@ -0,0 +1,162 @@
+package collector
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Responses in each exchange's shape, with levels out of order and deeper
+// than the configured depth
+const (
+    binanceDepth = `{"lastUpdateId":1027024,` +
+        `"bids":[["4.00000000","431.00000000"],["4.20000000","12.00000000"],["3.90000000","1.00000000"]],` +
+        `"asks":[["4.00000200","12.00000000"],["4.00000100","3.50000000"],["4.10000000","7.00000000"]]}`
+    coinbaseBook = `{"sequence":13051505638,` +
+        `"bids":[["6247.58","6.3578146",2],["6251.52","0.01",1],["6240.00","1.5",3]],` +
+        `"asks":[["6251.53","2.1",4],["6260.10","0.2",1],["6251.60","0.4",2]],"time":"2024-01-01T00:00:00Z"}`
+    krakenDepth = `{"error":[],"result":{"XXBTZUSD":{` +
+        `"bids":[["42000.10000","1.500",1704067200],["42010.00000","0.250",1704067201],["41990.00000","3.000",1704067199]],` +
+        `"asks":[["42020.00000","0.800",1704067200],["42011.00000","1.100",1704067202],["42050.00000","2.000",1704067203]]}}}`
+)
+
+func TestOrderBookSnapshots(t *testing.T) {
+    var queries []string
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        queries = append(queries, r.URL.RequestURI())
+        switch {
+        case r.URL.Path == "/api/v3/depth":
+            w.Write([]byte(binanceDepth))
+        case strings.HasPrefix(r.URL.Path, "/products/"):
+            w.Write([]byte(coinbaseBook))
+        case r.URL.Path == "/0/public/Depth":
+            w.Write([]byte(krakenDepth))
+        default:
+            http.NotFound(w, r)
+        }
+    }))
+    defer server.Close()
+
+    q := newMemQueue()
+    c := NewOrderBookCollector(newMemDatabase(), q, httpclient.New(httpclient.Config{MaxRetries: -1}), &OrderBookConfig{
+        Depth: 2,
+        Markets: []MarketConfig{
+            {Exchange: ExchangeBinance, Symbol: "BTCUSDT", BaseURL: server.URL},
+            {Exchange: ExchangeCoinbase, Symbol: "BTC-USD", BaseURL: server.URL},
+            {Exchange: ExchangeKraken, Symbol: "XBTUSD", BaseURL: server.URL},
+        },
+    })
+    if err := c.Collect(context.Background()); err != nil {
+        t.Fatalf("Collect: %v", err)
+    }
+
+    wantQueries := []string{
+        "/api/v3/depth?symbol=BTCUSDT&limit=5",
+        "/products/BTC-USD/book?level=2",
+        "/0/public/Depth?pair=XBTUSD&count=2",
+    }
+    if strings.Join(queries, " ") != strings.Join(wantQueries, " ") {
+        t.Errorf("queries = %v, want %v", queries, wantQueries)
+    }
+
+    tests := []struct {
+        exchange string
+        sequence int64
+        bids     []models.OrderBookLevel
+        asks     []models.OrderBookLevel
+    }{
+        {ExchangeBinance, 1027024,
+            []models.OrderBookLevel{{Price: 4.2, Quantity: 12}, {Price: 4, Quantity: 431}},
+            []models.OrderBookLevel{{Price: 4.000001, Quantity: 3.5}, {Price: 4.000002, Quantity: 12}}},
+        {ExchangeCoinbase, 13051505638,
+            []models.OrderBookLevel{{Price: 6251.52, Quantity: 0.01}, {Price: 6247.58, Quantity: 6.3578146}},
+            []models.OrderBookLevel{{Price: 6251.53, Quantity: 2.1}, {Price: 6251.6, Quantity: 0.4}}},
+        {ExchangeKraken, 0,
+            []models.OrderBookLevel{{Price: 42010, Quantity: 0.25}, {Price: 42000.1, Quantity: 1.5}},
+            []models.OrderBookLevel{{Price: 42011, Quantity: 1.1}, {Price: 42020, Quantity: 0.8}}},
+    }
+    for _, tt := range tests {
+        var snapshot models.OrderBookSnapshot
+        if err := json.Unmarshal(<-q.messages, &snapshot); err != nil {
+            t.Fatal(err)
+        }
+        if snapshot.Exchange != tt.exchange || snapshot.Sequence != tt.sequence {
+            t.Errorf("snapshot %s/%d, want %s/%d", snapshot.Exchange, snapshot.Sequence, tt.exchange, tt.sequence)
+        }
+        if !equalLevels(snapshot.Bids, tt.bids) || !equalLevels(snapshot.Asks, tt.asks) {
+            t.Errorf("%s: bids %v asks %v, want %v %v", tt.exchange, snapshot.Bids, snapshot.Asks, tt.bids, tt.asks)
+        }
+    }
+}
+
+func TestOrderBookFailingMarket(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/0/public/Depth" {
+            w.Write([]byte(`{"error":["EQuery:Unknown asset pair"],"result":{}}`))
+            return
+        }
+        w.Write([]byte(binanceDepth))
+    }))
+    defer server.Close()
+
+    q := newMemQueue()
+    c := NewOrderBookCollector(newMemDatabase(), q, httpclient.New(httpclient.Config{MaxRetries: -1}), &OrderBookConfig{
+        Markets: []MarketConfig{
+            {Exchange: ExchangeKraken, Symbol: "NOPE", BaseURL: server.URL},
+            {Exchange: ExchangeBinance, Symbol: "BTCUSDT", BaseURL: server.URL},
+        },
+    })
+    err := c.Collect(context.Background())
+    if err == nil || !strings.Contains(err.Error(), "EQuery:Unknown asset pair") {
+        t.Errorf("Collect = %v, want the Kraken error", err)
+    }
+    if len(q.messages) != 1 {
+        t.Errorf("%d snapshots published, want the Binance one", len(q.messages))
+    }
+}
+
+func TestParseLevels(t *testing.T) {
+    raw := [][]interface{}{{"1.5", "2"}, {3.0, 1.0}, {"2", "0.5", 7}}
+
+    asks, err := parseLevels(raw, 10, false)
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := []models.OrderBookLevel{{Price: 1.5, Quantity: 2}, {Price: 2, Quantity: 0.5}, {Price: 3, Quantity: 1}}
+    if !equalLevels(asks, want) {
+        t.Errorf("asks = %v, want %v", asks, want)
+    }
+
+    bids, err := parseLevels(raw, 2, true)
+    if err != nil {
+        t.Fatal(err)
+    }
+    want = []models.OrderBookLevel{{Price: 3, Quantity: 1}, {Price: 2, Quantity: 0.5}}
+    if !equalLevels(bids, want) {
+        t.Errorf("bids = %v, want %v", bids, want)
+    }
+
+    for _, bad := range [][][]interface{}{{{"1"}}, {{"x", "1"}}, {{"1", true}}} {
+        if _, err := parseLevels(bad, 10, false); err == nil {
+            t.Errorf("parseLevels(%v) succeeded", bad)
+        }
+    }
+}
+
+func equalLevels(a, b []models.OrderBookLevel) bool {
+    if len(a) != len(b) {
+        return false
+    }
+    for i := range a {
+        if a[i] != b[i] {
+            return false
+        }
+    }
+    return true
+} 
\ No newline at end of file
@@ -0,0 +1,144 @@
+package collector
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/stream"
+)
+
+// StreamConfig holds configuration for StreamCollector
+type StreamConfig struct {
+    Feeds []stream.FeedConfig `yaml:"feeds"`
+    // BarInterval is the bar size, one minute by default
+    BarInterval time.Duration `yaml:"bar_interval"`
+    // Grace keeps a bar open after its interval for late trades
+    Grace     time.Duration `yaml:"grace"`
+    Heartbeat time.Duration `yaml:"heartbeat"`
+}
+
+// StreamCollector aggregates WebSocket trade and ticker feeds into bars
+type StreamCollector struct {
+    *BaseCollector
+    config *StreamConfig
+}
+
+// NewStreamCollector creates a new StreamCollector. Streaming runs
+// continuously, so the collector has no schedule.
+func NewStreamCollector(db database.Database, queue Queue, config *StreamConfig) *StreamCollector {
+    return &StreamCollector{
+        BaseCollector: NewBaseCollector("stream", "", db, queue),
+        config:        config,
+    }
+}
+
+// Collect implements Collector.Collect for StreamCollector. It streams until
+// ctx is done and publishes every completed bar.
+func (c *StreamCollector) Collect(ctx context.Context) error {
+    interval := c.config.BarInterval
+    if interval <= 0 {
+        interval = time.Minute
+    }
+    grace := c.config.Grace
+    if grace <= 0 {
+        grace = 2 * time.Second
+    }
+
+    var clients []*stream.Client
+    exchanges := make(map[*stream.Client]string)
+    for _, fc := range c.config.Feeds {
+        feed, err := stream.NewFeed(fc)
+        if err != nil {
+            return err
+        }
+        client := stream.NewClient(feed, c.config.Heartbeat)
+        clients = append(clients, client)
+        exchanges[client] = feed.Exchange()
+    }
+    if len(clients) == 0 {
+        return fmt.Errorf("no feeds configured")
+    }
+
+    // Feeds deliver on their own goroutines; the aggregator runs on this one
+    events := make(chan stream.Event, 1024)
+    var wg sync.WaitGroup
+    for _, client := range clients {
+        wg.Add(1)
+        go func(client *stream.Client) {
+            defer wg.Done()
+            client.Run(ctx, func(e stream.Event) {
+                select {
+                case events <- e:
+                case <-ctx.Done():
+                }
+            })
+        }(client)
+    }
+    defer wg.Wait()
+
+    agg := stream.NewAggregator(interval, grace)
+    ticker := time.NewTicker(time.Second)
+    defer ticker.Stop()
+
+    for {
+        var bars []models.Bar
+        select {
+        case <-ctx.Done():
+            // Bars still open are incomplete and are not published
+            return nil
+        case e := <-events:
+            var missed int64
+            bars, missed = agg.Add(e)
+            // A gap means the exchange dropped messages; start afresh
+            if missed > 0 {
+                for _, client := range clients {
+                    if exchanges[client] == e.Exchange {
+                        client.Resync()
+                    }
+                }
+            }
+        case now := <-ticker.C:
+            bars = agg.Flush(now)
+        }
+
+        for _, bar := range bars {
+            if err := c.publish(ctx, bar); err != nil {
+                log.Printf("Collector %s: %v", c.Name(), err)
+            }
+        }
+    }
+}
+
+// publish sends a completed bar to the queue
+func (c *StreamCollector) publish(ctx context.Context, bar models.Bar) error {
+    body, err := json.Marshal(bar)
+    if err != nil {
+        return fmt.Errorf("failed to marshal bar: %w", err)
+    }
+    if err := c.queue.Publish(ctx, body); err != nil {
+        return fmt.Errorf("failed to publish bar: %w", err)
+    }
+    return nil
+}
+
+// Process implements Collector.Process for StreamCollector
+func (c *StreamCollector) Process(ctx context.Context) error {
+    return c.queue.Consume(ctx, func(ctx context.Context, data []byte) error {
+        var bar models.Bar
+        if err := json.Unmarshal(data, &bar); err != nil {
+            return fmt.Errorf("failed to unmarshal bar: %w", err)
+        }
+
+        if err := c.db.SaveBar(ctx, bar); err != nil {
+            return fmt.Errorf("failed to save bar: %w", err)
+        }
+
+        return nil
+    })
+} 
\ No newline at end of file
@@ -6,8 +6,18 @@ import (
     "path/filepath"
 
     "gopkg.in/yaml.v3"
+    "github.com/yourusername/investutil-gocrawler/internal/alerts"
+    "github.com/yourusername/investutil-gocrawler/internal/api"
+    "github.com/yourusername/investutil-gocrawler/internal/collector"
     "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/events"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/indicators"
+    "github.com/yourusername/investutil-gocrawler/internal/metrics"
+    "github.com/yourusername/investutil-gocrawler/internal/notify"
     "github.com/yourusername/investutil-gocrawler/internal/queue"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
 )
 
 // Config represents the application configuration
@@ -16,11 +26,46 @@ type Config struct {
         MongoDB database.Config `yaml:"mongodb"`
     } `yaml:"database"`
     Queue struct {
-        RabbitMQ queue.Config `yaml:"rabbitmq"`
+        RabbitMQ  queue.Config `yaml:"rabbitmq"`
+        OrderBook queue.Config `yaml:"orderbook"`
+        Stream    queue.Config `yaml:"stream"`
     } `yaml:"queue"`
+    HTTP      httpclient.Config `yaml:"http"`
     Collector struct {
         Schedule string `yaml:"schedule"`
+        // BaseURL overrides the CoinGecko API of the bitcoin-price
+        // collector, e.g. for a local test server
+        BaseURL   string                    `yaml:"base_url"`
+        OrderBook collector.OrderBookConfig `yaml:"orderbook"`
+        Stream    collector.StreamConfig    `yaml:"stream"`
     } `yaml:"collector"`
+    // Storage is read by the api mode, which serves what the crawlers stored,
+    // and keeps the state of the indicators, alerts and run notifications
+    Storage struct {
+        MongoDB storage.MongoDBConfig `yaml:"mongodb"`
+    } `yaml:"storage"`
+    API api.Config `yaml:"api"`
+    // Push streams the points stored by the processor to subscribers
+    Push events.Config `yaml:"push"`
+    // Indicators computes derived series of the prices saved by the processor
+    // and stores them in the storage layer under Prefix
+    Indicators struct {
+        Prefix            string `yaml:"prefix"`
+        indicators.Config `yaml:",inline"`
+    } `yaml:"indicators"`
+    // Alerts evaluates alert rules on the prices saved by the processor. The
+    // indicator rules read the series under Prefix, which also keeps the
+    // alert state.
+    Alerts struct {
+        Prefix        string `yaml:"prefix"`
+        alerts.Config `yaml:",inline"`
+    } `yaml:"alerts"`
+    // Notifications reports failed and recovered collector runs
+    Notifications notify.RunConfig `yaml:"notifications"`
+    // Metrics serves the Prometheus metrics of the collector, processor or API
+    Metrics metrics.Config `yaml:"metrics"`
+    // Tracing exports the spans of a batch from collection to storage
+    Tracing tracing.Config `yaml:"tracing"`
 }
 
 // Load loads configuration from a YAML file
@@ -0,0 +1,356 @@
+package analytics
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Return frequencies
+const (
+    FrequencyDaily  = "daily"
+    FrequencyWeekly = "weekly"
+)
+
+// PairConfig declares a pair of assets whose returns are correlated. The
+// beta is that of Asset against Benchmark.
+type PairConfig struct {
+    Asset     string `yaml:"asset"`
+    Benchmark string `yaml:"benchmark"`
+}
+
+// Config holds configuration for an analytics job
+type Config struct {
+    Name     string `yaml:"name"`
+    Schedule string `yaml:"schedule"`
+    // DataPath receives the returns and the correlation matrices
+    DataPath string                 `yaml:"data_path"`
+    Assets   []crawler.SeriesSource `yaml:"assets"`
+    // Pairs defaults to every pair of assets
+    Pairs []PairConfig `yaml:"pairs"`
+    // DailyWindows are the rolling windows in days, defaults to [30, 90]
+    DailyWindows []int `yaml:"daily_windows"`
+    // WeeklyWindows are the rolling windows in weeks, defaults to [26, 52]
+    WeeklyWindows []int `yaml:"weekly_windows"`
+}
+
+// Validate checks the configuration for missing or unknown settings
+func (c *Config) Validate() error {
+    if c.Name == "" {
+        return fmt.Errorf("analytics name is required")
+    }
+    if c.DataPath == "" {
+        return fmt.Errorf("analytics %s: data_path is required", c.Name)
+    }
+    if len(c.Assets) < 2 {
+        return fmt.Errorf("analytics %s: at least two assets are required", c.Name)
+    }
+    seen := make(map[string]bool, len(c.Assets))
+    for _, a := range c.Assets {
+        if err := a.Validate(); err != nil {
+            return fmt.Errorf("analytics %s: asset %w", c.Name, err)
+        }
+        if seen[a.Name] {
+            return fmt.Errorf("analytics %s: asset %s is declared twice", c.Name, a.Name)
+        }
+        seen[a.Name] = true
+    }
+    for _, p := range c.Pairs {
+        if !seen[p.Asset] || !seen[p.Benchmark] {
+            return fmt.Errorf("analytics %s: pair %s/%s refers to an unknown asset", c.Name, p.Asset, p.Benchmark)
+        }
+        if p.Asset == p.Benchmark {
+            return fmt.Errorf("analytics %s: pair %s/%s needs two different assets", c.Name, p.Asset, p.Benchmark)
+        }
+    }
+    for _, w := range append(append([]int{}, c.DailyWindows...), c.WeeklyWindows...) {
+        if w < 2 {
+            return fmt.Errorf("analytics %s: windows must be at least 2", c.Name)
+        }
+    }
+    return nil
+}
+
+// Job computes the log returns of stored assets and the rolling
+// correlations and betas between pairs of them. It runs on the scheduler
+// like a crawler but only reads from storage.
+type Job struct {
+    *crawler.BaseCrawler
+    storage storage.Storage
+    config  *Config
+}
+
+// NewJob creates a new Job after validating the configuration
+func NewJob(storage storage.Storage, config *Config) (*Job, error) {
+    if err := config.Validate(); err != nil {
+        return nil, err
+    }
+    if len(config.Pairs) == 0 {
+        for i, a := range config.Assets {
+            for _, b := range config.Assets[i+1:] {
+                config.Pairs = append(config.Pairs, PairConfig{Asset: a.Name, Benchmark: b.Name})
+            }
+        }
+    }
+    if len(config.DailyWindows) == 0 {
+        config.DailyWindows = []int{30, 90}
+    }
+    if len(config.WeeklyWindows) == 0 {
+        config.WeeklyWindows = []int{26, 52}
+    }
+    return &Job{
+        BaseCrawler: crawler.NewBaseCrawler("analytics-"+config.Name, config.Schedule),
+        storage:     storage,
+        config:      config,
+    }, nil
+}
+
+// Crawl stores the log returns of every asset under
+// <data_path>/returns/<frequency>/<asset>/ and the correlation matrices under
+// <data_path>/correlations/<frequency>/<window>/, one file per year holding a
+// matrix per date and latest.json holding the newest matrix
+func (j *Job) Crawl(ctx context.Context) error {
+    prices := make(map[string][]models.MetricPoint, len(j.config.Assets))
+    for _, a := range j.config.Assets {
+        points, err := crawler.LoadPoints(ctx, j.storage, a)
+        if err != nil {
+            return fmt.Errorf("asset %s: %w", a.Name, err)
+        }
+        prices[a.Name] = points
+    }
+
+    for _, freq := range []string{FrequencyDaily, FrequencyWeekly} {
+        closes := make(map[string][]models.MetricPoint, len(prices))
+        for _, a := range j.config.Assets {
+            closes[a.Name] = Closes(prices[a.Name], freq)
+            series := models.MetricSeries{Metric: "log-return", Unit: "log"}
+            prefix := fmt.Sprintf("%s/returns/%s/%s", j.config.DataPath, freq, a.Name)
+            // Appending to an empty series stores every return and replaces each yearly file
+            if _, err := crawler.AppendSeries(ctx, j.storage, prefix, series, LogReturns(closes[a.Name])); err != nil {
+                return fmt.Errorf("failed to save %s returns of %s: %w", freq, a.Name, err)
+            }
+        }
+
+        windows := j.config.DailyWindows
+        if freq == FrequencyWeekly {
+            windows = j.config.WeeklyWindows
+        }
+        for _, window := range windows {
+            if err := j.saveMatrices(ctx, freq, window, j.matrices(closes, window)); err != nil {
+                return err
+            }
+        }
+    }
+
+    j.UpdateLastRun()
+    return nil
+}
+
+// matrices computes a correlation matrix for every date at which a pair has
+// a full window of common returns
+func (j *Job) matrices(closes map[string][]models.MetricPoint, window int) []models.CorrelationMatrix {
+    index := make(map[string]int, len(j.config.Assets))
+    for i, a := range j.config.Assets {
+        index[a.Name] = i
+    }
+    n := len(j.config.Assets)
+
+    byDate := make(map[time.Time]*models.CorrelationMatrix)
+    matrix := func(t time.Time) *models.CorrelationMatrix {
+        m, ok := byDate[t]
+        if !ok {
+            m = &models.CorrelationMatrix{
+                Date:         t,
+                Correlations: emptyMatrix(n),
+                Betas:        emptyMatrix(n),
+            }
+            byDate[t] = m
+        }
+        return m
+    }
+
+    for _, pair := range j.config.Pairs {
+        a, b := index[pair.Asset], index[pair.Benchmark]
+        dates, ra, rb := alignedReturns(closes[pair.Asset], closes[pair.Benchmark])
+        for end := window; end <= len(dates); end++ {
+            corr, betaA, betaB, ok := Rolling(ra[end-window:end], rb[end-window:end])
+            if !ok {
+                continue
+            }
+            m := matrix(dates[end-1])
+            m.Correlations[a][b], m.Correlations[b][a] = floatPtr(corr), floatPtr(corr)
+            m.Betas[a][b], m.Betas[b][a] = floatPtr(betaA), floatPtr(betaB)
+        }
+    }
+
+    matrices := make([]models.CorrelationMatrix, 0, len(byDate))
+    for _, m := range byDate {
+        for i := 0; i < n; i++ {
+            m.Correlations[i][i], m.Betas[i][i] = floatPtr(1), floatPtr(1)
+        }
+        matrices = append(matrices, *m)
+    }
+    sort.Slice(matrices, func(a, b int) bool {
+        return matrices[a].Date.Before(matrices[b].Date)
+    })
+    return matrices
+}
+
+// saveMatrices stores the matrices of one frequency and window by year
+func (j *Job) saveMatrices(ctx context.Context, freq string, window int, matrices []models.CorrelationMatrix) error {
+    names := make([]string, 0, len(j.config.Assets))
+    for _, a := range j.config.Assets {
+        names = append(names, a.Name)
+    }
+    prefix := fmt.Sprintf("%s/correlations/%s/%d", j.config.DataPath, freq, window)
+    series := func(matrices []models.CorrelationMatrix) models.CorrelationSeries {
+        return models.CorrelationSeries{
+            LastUpdated: time.Now().UTC(),
+            Frequency:   freq,
+            Window:      window,
+            Assets:      names,
+            Matrices:    matrices,
+        }
+    }
+
+    years := make(map[int][]models.CorrelationMatrix)
+    for _, m := range matrices {
+        years[m.Date.Year()] = append(years[m.Date.Year()], m)
+    }
+    for year, yearly := range years {
+        key := fmt.Sprintf("%s/%d.json", prefix, year)
+        if err := j.storage.Save(ctx, key, series(yearly)); err != nil {
+            return fmt.Errorf("failed to save %s correlations: %w", freq, err)
+        }
+    }
+
+    latest := matrices
+    if len(latest) > 0 {
+        latest = latest[len(latest)-1:]
+    }
+    if err := j.storage.Save(ctx, prefix+"/latest.json", series(latest)); err != nil {
+        return fmt.Errorf("failed to save %s correlations: %w", freq, err)
+    }
+    return nil
+}
+
+// Closes returns the last positive value of each UTC day or week, stamped
+// at the start of the day or the Monday of the week
+func Closes(points []models.MetricPoint, freq string) []models.MetricPoint {
+    sorted := append([]models.MetricPoint(nil), points...)
+    sort.SliceStable(sorted, func(a, b int) bool {
+        return sorted[a].Timestamp.Before(sorted[b].Timestamp)
+    })
+
+    period := resample.PeriodDay
+    if freq == FrequencyWeekly {
+        period = resample.PeriodWeek
+    }
+    var closes []models.MetricPoint
+    for _, p := range sorted {
+        if p.Value <= 0 {
+            continue
+        }
+        t := resample.PeriodStart(p.Timestamp, period, time.UTC)
+        if n := len(closes); n > 0 && closes[n-1].Timestamp.Equal(t) {
+            closes[n-1].Value = p.Value
+            continue
+        }
+        closes = append(closes, models.MetricPoint{Timestamp: t, Value: p.Value})
+    }
+    return closes
+}
+
+// LogReturns returns the log return between each close and the previous one
+func LogReturns(closes []models.MetricPoint) []models.MetricPoint {
+    if len(closes) < 2 {
+        return nil
+    }
+    returns := make([]models.MetricPoint, 0, len(closes)-1)
+    for i := 1; i < len(closes); i++ {
+        returns = append(returns, models.MetricPoint{
+            Timestamp: closes[i].Timestamp,
+            Value:     math.Log(closes[i].Value / closes[i-1].Value),
+        })
+    }
+    return returns
+}
+
+// alignedReturns returns the log returns of two assets between the dates on
+// which both have a close, so that assets trading on different calendars,
+// e.g. BTC and SPY, are compared over the same spans
+func alignedReturns(a, b []models.MetricPoint) ([]time.Time, []float64, []float64) {
+    var common []time.Time
+    var ca, cb []float64
+    for i, k := 0, 0; i < len(a) && k < len(b); {
+        switch {
+        case a[i].Timestamp.Before(b[k].Timestamp):
+            i++
+        case b[k].Timestamp.Before(a[i].Timestamp):
+            k++
+        default:
+            common = append(common, a[i].Timestamp)
+            ca = append(ca, a[i].Value)
+            cb = append(cb, b[k].Value)
+            i++
+            k++
+        }
+    }
+    if len(common) < 2 {
+        return nil, nil, nil
+    }
+
+    dates := common[1:]
+    ra := make([]float64, len(dates))
+    rb := make([]float64, len(dates))
+    for i := range dates {
+        ra[i] = math.Log(ca[i+1] / ca[i])
+        rb[i] = math.Log(cb[i+1] / cb[i])
+    }
+    return dates, ra, rb
+}
+
+// Rolling returns the correlation of two return windows, the beta of a
+// against b and the beta of b against a. ok is false when either window
+// does not vary.
+func Rolling(a, b []float64) (corr, betaA, betaB float64, ok bool) {
+    n := float64(len(a))
+    var meanA, meanB float64
+    for i := range a {
+        meanA += a[i]
+        meanB += b[i]
+    }
+    meanA /= n
+    meanB /= n
+
+    var cov, varA, varB float64
+    for i := range a {
+        da, db := a[i]-meanA, b[i]-meanB
+        cov += da * db
+        varA += da * da
+        varB += db * db
+    }
+    if varA == 0 || varB == 0 {
+        return 0, 0, 0, false
+    }
+    return cov / math.Sqrt(varA*varB), cov / varB, cov / varA, true
+}
+
+func emptyMatrix(n int) [][]*float64 {
+    m := make([][]*float64, n)
+    for i := range m {
+        m[i] = make([]*float64, n)
+    }
+    return m
+}
+
+func floatPtr(v float64) *float64 {
+    return &v
+} 
\ No newline at end of file
@@ -0,0 +1,205 @@
+package analytics
+
+import (
+    "context"
+    "math"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func at(month time.Month, day, hour int) time.Time {
+    return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func near(a, b float64) bool {
+    return math.Abs(a-b) < 1e-9
+}
+
+func TestCloses(t *testing.T) {
+    points := []models.MetricPoint{
+        {Timestamp: at(1, 3, 0), Value: 30},
+        {Timestamp: at(1, 1, 20), Value: 12},
+        {Timestamp: at(1, 1, 10), Value: 11},
+        {Timestamp: at(1, 2, 5), Value: 0},
+        {Timestamp: at(1, 7, 23), Value: 70},
+        {Timestamp: at(1, 8, 1), Value: 80},
+    }
+
+    tests := []struct {
+        freq  string
+        dates []time.Time
+        want  []float64
+    }{
+        // The last positive value of each day, stamped at midnight
+        {FrequencyDaily, []time.Time{at(1, 1, 0), at(1, 3, 0), at(1, 7, 0), at(1, 8, 0)}, []float64{12, 30, 70, 80}},
+        // 1 January 2024 is a Monday, and Sunday the 7th closes its week
+        {FrequencyWeekly, []time.Time{at(1, 1, 0), at(1, 8, 0)}, []float64{70, 80}},
+    }
+    for _, tt := range tests {
+        closes := Closes(points, tt.freq)
+        if len(closes) != len(tt.want) {
+            t.Fatalf("%s closes = %+v", tt.freq, closes)
+        }
+        for i := range closes {
+            if !closes[i].Timestamp.Equal(tt.dates[i]) || closes[i].Value != tt.want[i] {
+                t.Errorf("%s close %d = %+v, want %v at %s", tt.freq, i, closes[i], tt.want[i], tt.dates[i])
+            }
+        }
+    }
+}
+
+func TestLogReturns(t *testing.T) {
+    closes := []models.MetricPoint{
+        {Timestamp: at(1, 1, 0), Value: 100},
+        {Timestamp: at(1, 2, 0), Value: 110},
+        {Timestamp: at(1, 3, 0), Value: 99},
+    }
+    returns := LogReturns(closes)
+    if len(returns) != 2 {
+        t.Fatalf("returns = %+v", returns)
+    }
+    if !returns[0].Timestamp.Equal(at(1, 2, 0)) || !near(returns[0].Value, math.Log(1.1)) || !near(returns[1].Value, math.Log(0.9)) {
+        t.Errorf("returns = %+v", returns)
+    }
+    if LogReturns(closes[:1]) != nil {
+        t.Error("a single close has no returns")
+    }
+}
+
+func TestAlignedReturns(t *testing.T) {
+    var daily, weekdays []models.MetricPoint
+    for d := 1; d <= 7; d++ {
+        daily = append(daily, models.MetricPoint{Timestamp: at(1, d, 0), Value: float64(100 + d)})
+    }
+    for _, d := range []int{1, 2, 3, 5} {
+        weekdays = append(weekdays, models.MetricPoint{Timestamp: at(1, d, 0), Value: float64(10 * d)})
+    }
+
+    dates, ra, rb := alignedReturns(daily, weekdays)
+    if len(dates) != 3 || !dates[2].Equal(at(1, 5, 0)) {
+        t.Fatalf("dates = %v, want the 2nd, 3rd and 5th", dates)
+    }
+    // The return to the 5th spans the 4th, which only one asset has
+    if !near(ra[2], math.Log(105.0/103)) || !near(rb[2], math.Log(50.0/30)) {
+        t.Errorf("returns to the 5th = %v, %v", ra[2], rb[2])
+    }
+    if dates, _, _ := alignedReturns(daily, weekdays[:1]); dates != nil {
+        t.Errorf("one common date gave returns at %v", dates)
+    }
+}
+
+func TestRolling(t *testing.T) {
+    tests := []struct {
+        name               string
+        a, b               []float64
+        corr, betaA, betaB float64
+    }{
+        {"reference", []float64{1, 2, 3}, []float64{1, 3, 2}, 0.5, 0.5, 0.5},
+        {"double", []float64{0.01, -0.02, 0.03, 0}, []float64{0.02, -0.04, 0.06, 0}, 1, 0.5, 2},
+        {"opposite", []float64{0.01, -0.02, 0.03}, []float64{-0.01, 0.02, -0.03}, -1, -1, -1},
+    }
+    for _, tt := range tests {
+        corr, betaA, betaB, ok := Rolling(tt.a, tt.b)
+        if !ok || !near(corr, tt.corr) || !near(betaA, tt.betaA) || !near(betaB, tt.betaB) {
+            t.Errorf("%s: Rolling = %v, %v, %v, %v, want %v, %v, %v", tt.name, corr, betaA, betaB, ok, tt.corr, tt.betaA, tt.betaB)
+        }
+    }
+    if _, _, _, ok := Rolling([]float64{1, 1, 1}, []float64{1, 2, 3}); ok {
+        t.Error("Rolling accepted a window without variance")
+    }
+}
+
+func TestJobStoresMatricesByYear(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+
+    // BTC trades every day and SPY on weekdays, from 20 December to 10 January
+    var btc models.BitcoinDailyData
+    var spy, gold models.MetricSeries
+    for d := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC); !d.After(at(1, 10, 0)); d = d.AddDate(0, 0, 1) {
+        price := 100 * math.Exp(math.Sin(float64(d.YearDay())))
+        btc.Data = append(btc.Data, models.BitcoinPrice{Timestamp: d, Price: 2 * price})
+        if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+            spy.Data = append(spy.Data, models.MetricPoint{Timestamp: d, Value: price})
+        }
+    }
+    gold.Data = []models.MetricPoint{{Timestamp: at(1, 2, 0), Value: 2000}}
+    for key, v := range map[string]interface{}{"btc/latest.json": btc, "spy/latest.json": spy, "gold/latest.json": gold} {
+        if err := s.Save(ctx, key, v); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    job, err := NewJob(s, &Config{
+        Name:     "test",
+        DataPath: "analytics",
+        Assets: []crawler.SeriesSource{
+            {Name: "btc", Prefix: "btc", Format: crawler.FormatBitcoin},
+            {Name: "spy", Prefix: "spy"},
+            {Name: "gold", Prefix: "gold"},
+        },
+        Pairs:         []PairConfig{{Asset: "btc", Benchmark: "spy"}},
+        DailyWindows:  []int{3},
+        WeeklyWindows: []int{2},
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := job.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    returns, err := crawler.LoadSeries(ctx, s, "analytics/returns/daily/btc")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(returns.Data) != len(btc.Data)-1 {
+        t.Errorf("stored %d daily BTC returns, want %d", len(returns.Data), len(btc.Data)-1)
+    }
+
+    var y2023, y2024, latest models.CorrelationSeries
+    for key, v := range map[string]*models.CorrelationSeries{
+        "analytics/correlations/daily/3/2023.json":   &y2023,
+        "analytics/correlations/daily/3/2024.json":   &y2024,
+        "analytics/correlations/daily/3/latest.json": &latest,
+    } {
+        if err := s.Load(ctx, key, v); err != nil {
+            t.Fatalf("Load %s: %v", key, err)
+        }
+    }
+    for _, m := range y2023.Matrices {
+        if m.Date.Year() != 2023 {
+            t.Errorf("2023 file holds a matrix of %s", m.Date)
+        }
+    }
+    for _, m := range y2024.Matrices {
+        if m.Date.Year() != 2024 {
+            t.Errorf("2024 file holds a matrix of %s", m.Date)
+        }
+    }
+    if len(y2023.Matrices) == 0 || len(y2024.Matrices) == 0 {
+        t.Fatalf("%d matrices in 2023 and %d in 2024", len(y2023.Matrices), len(y2024.Matrices))
+    }
+
+    if len(latest.Matrices) != 1 || !latest.Matrices[0].Date.Equal(at(1, 10, 0)) {
+        t.Fatalf("latest = %+v, want the matrix of 10 January", latest.Matrices)
+    }
+    if len(latest.Assets) != 3 || latest.Assets[0] != "btc" || latest.Frequency != FrequencyDaily || latest.Window != 3 {
+        t.Errorf("latest header = %+v", latest)
+    }
+    m := latest.Matrices[0]
+    // BTC is twice SPY on their common dates, so their returns match
+    if c := m.Correlations[0][1]; c == nil || !near(*c, 1) || m.Betas[0][1] == nil || !near(*m.Betas[0][1], 1) {
+        t.Errorf("BTC/SPY correlation %v and beta %v, want 1", m.Correlations[0][1], m.Betas[0][1])
+    }
+    if m.Correlations[0][2] != nil || m.Correlations[2][1] != nil {
+        t.Error("gold is in no pair and must have no correlations")
+    }
+    if d := m.Correlations[2][2]; d == nil || *d != 1 {
+        t.Errorf("diagonal = %v, want 1", d)
+    }
+} 
\ No newline at end of file
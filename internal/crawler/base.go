@@ -2,9 +2,14 @@ package crawler
 
 import (
     "context"
+    "errors"
     "time"
 )
 
+// ErrNoChange is returned by Crawl when the upstream data has not changed
+// since the previous run, so nothing was saved
+var ErrNoChange = errors.New("no change since last crawl")
+
 // Crawler defines the interface that all crawlers must implement
 type Crawler interface {
     // Name returns the crawler's name
@@ -0,0 +1,173 @@
+package crypto
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
+)
+
+// StoredDays implements backfill.Source.StoredDays
+func (c *BitcoinCrawler) StoredDays(ctx context.Context) ([]time.Time, error) {
+    data, err := c.load(ctx)
+    if err != nil {
+        return nil, err
+    }
+    days := make([]time.Time, 0, len(data.Data))
+    for _, p := range data.Data {
+        days = append(days, p.Timestamp.UTC().Truncate(24*time.Hour))
+    }
+    return days, nil
+}
+
+// FetchRange implements backfill.Source.FetchRange using the CoinGecko
+// market_chart/range endpoint. Ranges of up to 90 days come back hourly, so
+// the first point of each day is kept, matching the daily history.
+func (c *BitcoinCrawler) FetchRange(ctx context.Context, from, to time.Time) (int, error) {
+    url := fmt.Sprintf("%s/coins/%s/market_chart/range?vs_currency=usd&from=%d&to=%d",
+        c.baseURL(), bitcoinID, from.Unix(), to.Unix())
+
+    var geckoResp models.CoinGeckoResponse
+    if err := c.client.GetJSON(ctx, url, &geckoResp); err != nil {
+        return 0, err
+    }
+    // A range before the asset was listed is legitimately empty
+    if len(geckoResp.Prices) == 0 {
+        return 0, nil
+    }
+    if err := validation.CoinGecko(c.Name(), &geckoResp); err != nil {
+        validation.RecordDrift(ctx, c.storage, err)
+        return 0, err
+    }
+
+    daily := make(map[time.Time]models.BitcoinPrice)
+    for _, p := range convertPrices(&geckoResp) {
+        day := p.Timestamp.UTC().Truncate(24 * time.Hour)
+        if first, ok := daily[day]; !ok || p.Timestamp.Before(first.Timestamp) {
+            daily[day] = p
+        }
+    }
+    fresh := make([]models.BitcoinPrice, 0, len(daily))
+    for day, p := range daily {
+        p.Timestamp = day
+        fresh = append(fresh, p)
+    }
+
+    data, err := c.load(ctx)
+    if err != nil {
+        return 0, err
+    }
+    _, added, err := c.merge(ctx, data, fresh, false)
+    return added, err
+}
+
+// merge merges fresh points into the stored history by timestamp and
+// returns the merged history and the number of days added. With overwrite,
+// fresh points replace stored ones at the same time, and stored intraday
+// points of the days they cover, such as the previous crawl's "now" point;
+// otherwise only days not stored yet are added. latest.json and the yearly
+// files of the years that changed are rewritten.
+func (c *BitcoinCrawler) merge(ctx context.Context, data models.BitcoinDailyData, fresh []models.BitcoinPrice, overwrite bool) (models.BitcoinDailyData, int, error) {
+    byTime := make(map[time.Time]int, len(data.Data))
+    days := make(map[time.Time]bool, len(data.Data))
+    for i, p := range data.Data {
+        byTime[p.Timestamp.UTC()] = i
+        days[p.Timestamp.UTC().Truncate(24*time.Hour)] = true
+    }
+
+    freshDays := make(map[time.Time]bool, len(fresh))
+    for _, p := range fresh {
+        freshDays[p.Timestamp.UTC().Truncate(24*time.Hour)] = true
+    }
+
+    merged := make([]models.BitcoinPrice, 0, len(data.Data)+len(fresh))
+    years := make(map[int]bool)
+    if overwrite {
+        freshTimes := make(map[time.Time]bool, len(fresh))
+        for _, p := range fresh {
+            freshTimes[p.Timestamp.UTC()] = true
+        }
+        for _, p := range data.Data {
+            t := p.Timestamp.UTC()
+            day := t.Truncate(24 * time.Hour)
+            switch {
+            case freshTimes[t]:
+                // Replaced by the fresh point below
+            case !t.Equal(day) && freshDays[day]:
+                years[t.Year()] = true
+            default:
+                merged = append(merged, p)
+            }
+        }
+    } else {
+        merged = append(merged, data.Data...)
+    }
+
+    added := 0
+    for _, p := range fresh {
+        t := p.Timestamp.UTC()
+        day := t.Truncate(24 * time.Hour)
+        if i, ok := byTime[t]; ok {
+            if overwrite {
+                merged = append(merged, p)
+                if old := data.Data[i]; old.Price != p.Price || old.MarketCap != p.MarketCap || old.Volume24h != p.Volume24h {
+                    years[t.Year()] = true
+                }
+            }
+            continue
+        }
+        if days[day] && !overwrite {
+            continue
+        }
+        merged = append(merged, p)
+        years[t.Year()] = true
+        if !days[day] {
+            days[day] = true
+            added++
+        }
+    }
+    if len(years) == 0 {
+        return data, 0, nil
+    }
+
+    sort.Slice(merged, func(i, j int) bool {
+        return merged[i].Timestamp.Before(merged[j].Timestamp)
+    })
+    data = models.BitcoinDailyData{LastUpdated: time.Now().UTC(), Data: merged}
+
+    key := fmt.Sprintf("%s/latest.json", c.config.DataPath)
+    if err := c.storage.Save(ctx, key, data); err != nil {
+        return data, 0, fmt.Errorf("failed to save data: %w", err)
+    }
+    for year := range years {
+        yearly := models.BitcoinDailyData{LastUpdated: data.LastUpdated}
+        for _, p := range data.Data {
+            if p.Timestamp.UTC().Year() == year {
+                yearly.Data = append(yearly.Data, p)
+            }
+        }
+        yearlyKey := fmt.Sprintf("%s/%d/btc-%d.json", c.config.DataPath, year, year)
+        if err := c.storage.Save(ctx, yearlyKey, yearly); err != nil {
+            return data, 0, fmt.Errorf("failed to save yearly data: %w", err)
+        }
+    }
+    if err := c.materialize(ctx, data); err != nil {
+        return data, 0, err
+    }
+    return data, added, nil
+}
+
+// load reads the stored history, returning empty data when nothing is stored
+func (c *BitcoinCrawler) load(ctx context.Context) (models.BitcoinDailyData, error) {
+    var data models.BitcoinDailyData
+    key := fmt.Sprintf("%s/latest.json", c.config.DataPath)
+    if err := c.storage.Load(ctx, key, &data); err != nil && !errors.Is(err, storage.ErrNotFound) {
+        return data, fmt.Errorf("failed to load existing data: %w", err)
+    }
+    return data, nil
+} 
\ No newline at end of file
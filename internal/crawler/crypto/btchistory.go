@@ -2,14 +2,21 @@ package crypto
 
 import (
     "context"
-    "encoding/json"
+    "errors"
     "fmt"
-    "net/http"
+    "log"
     "time"
 
+    "github.com/yourusername/investutil-gocrawler/internal/alerts"
     "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/httpcache"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/indicators"
     "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/quality"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
     "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
 )
 
 const (
@@ -21,84 +28,88 @@ const (
 type BitcoinCrawler struct {
     *crawler.BaseCrawler
     storage storage.Storage
-    client  *http.Client
+    client  *httpclient.Client
     config  *Config
+    alerts  *alerts.Engine
 }
 
 // Config holds configuration for BitcoinCrawler
 type Config struct {
     DataPath string `yaml:"data_path"`
     Schedule string `yaml:"schedule"`
+    // BaseURL overrides the CoinGecko API, e.g. for a local test server
+    BaseURL string `yaml:"base_url"`
+    // Quality enables data quality checks of the price series
+    Quality *quality.Config `yaml:"quality"`
+    // Resample materializes calendar bars of the history after each crawl
+    Resample *resample.Config `yaml:"resample"`
+    // Indicators computes derived series of the history after each crawl
+    Indicators *indicators.Config `yaml:"indicators"`
+    // Alerts evaluates alert rules on the history after each crawl
+    Alerts *alerts.Config `yaml:"alerts"`
 }
 
 // NewBitcoinCrawler creates a new BitcoinCrawler instance
-func NewBitcoinCrawler(storage storage.Storage, config *Config) *BitcoinCrawler {
-    return &BitcoinCrawler{
+func NewBitcoinCrawler(storage storage.Storage, client *httpclient.Client, config *Config) *BitcoinCrawler {
+    c := &BitcoinCrawler{
         BaseCrawler: crawler.NewBaseCrawler("bitcoin-history", config.Schedule),
         storage:     storage,
-        client: &http.Client{
-            Timeout: time.Second * 30,
-        },
-        config: config,
+        client:      client,
+        config:      config,
     }
+    if config.Alerts != nil {
+        c.alerts = alerts.NewEngine(storage, config.DataPath, *config.Alerts)
+    }
+    return c
 }
 
 // Crawl implements the main crawling logic
 func (c *BitcoinCrawler) Crawl(ctx context.Context) error {
     // Fetch data from CoinGecko
     url := fmt.Sprintf("%s/coins/%s/market_chart?vs_currency=usd&days=max&interval=daily",
-        coinGeckoBaseURL, bitcoinID)
-    
-    req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-    if err != nil {
-        return fmt.Errorf("failed to create request: %w", err)
-    }
+        c.baseURL(), bitcoinID)
 
-    resp, err := c.client.Do(req)
-    if err != nil {
-        return fmt.Errorf("failed to fetch data: %w", err)
+    // The full history is large, so skip the crawl when it has not changed
+    var geckoResp models.CoinGeckoResponse
+    condCtx, pending := httpcache.Conditional(ctx)
+    if err := c.client.GetJSON(condCtx, url, &geckoResp); err != nil {
+        if errors.Is(err, httpcache.ErrNotModified) {
+            c.UpdateLastRun()
+            return crawler.ErrNoChange
+        }
+        return err
     }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != http.StatusOK {
-        return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+    if err := validation.CoinGecko(c.Name(), &geckoResp); err != nil {
+        validation.RecordDrift(ctx, c.storage, err)
+        return err
     }
 
-    var geckoResp models.CoinGeckoResponse
-    if err := json.NewDecoder(resp.Body).Decode(&geckoResp); err != nil {
-        return fmt.Errorf("failed to decode response: %w", err)
+    // Merge into the stored history, which keeps the ranges filled in by
+    // backfill that the response does not cover
+    fresh := convertPrices(&geckoResp)
+    history, err := c.load(ctx)
+    if err != nil {
+        return err
     }
-
-    // Convert response to our data model
-    var prices []models.BitcoinPrice
-    for i := 0; i < len(geckoResp.Prices); i++ {
-        timestamp := time.UnixMilli(int64(geckoResp.Prices[i][0]))
-        price := models.BitcoinPrice{
-            Timestamp: timestamp,
-            Price:     geckoResp.Prices[i][1],
-            MarketCap: geckoResp.MarketCaps[i][1],
-            Volume24h: geckoResp.TotalVolumes[i][1],
+    if c.config.Quality != nil {
+        if err := c.checkQuality(ctx, history, fresh); err != nil {
+            return err
         }
-        prices = append(prices, price)
     }
-
-    // Prepare data for storage
-    data := models.BitcoinDailyData{
-        LastUpdated: time.Now().UTC(),
-        Data:        prices,
+    data, added, err := c.merge(ctx, history, fresh, true)
+    if err != nil {
+        return err
     }
-
-    // Save to storage
-    key := fmt.Sprintf("%s/latest.json", c.config.DataPath)
-    if err := c.storage.Save(ctx, key, data); err != nil {
-        return fmt.Errorf("failed to save data: %w", err)
+    crawler.RecordRecords(c.Name(), len(fresh), added)
+    // Only a stored history may answer the next crawl with "no change"
+    if err := pending.Commit(ctx); err != nil {
+        log.Printf("HTTP cache: %v", err)
     }
-
-    // Save yearly data
-    year := time.Now().Format("2006")
-    yearlyKey := fmt.Sprintf("%s/%s/btc-%s.json", c.config.DataPath, year, year)
-    if err := c.storage.Save(ctx, yearlyKey, data); err != nil {
-        return fmt.Errorf("failed to save yearly data: %w", err)
+    if c.alerts != nil {
+        // The prices are stored, so failed alerts do not fail the crawl
+        if _, err := c.alerts.Evaluate(ctx, alerts.FromBitcoin(data.Data)); err != nil {
+            log.Printf("Failed to evaluate alerts: %v", err)
+        }
     }
 
     // Update last run time
@@ -106,7 +117,63 @@ func (c *BitcoinCrawler) Crawl(ctx context.Context) error {
     return nil
 }
 
+// baseURL returns the configured CoinGecko API base URL
+func (c *BitcoinCrawler) baseURL() string {
+    if c.config.BaseURL != "" {
+        return c.config.BaseURL
+    }
+    return coinGeckoBaseURL
+}
+
+// convertPrices converts a validated response to our data model
+func convertPrices(resp *models.CoinGeckoResponse) []models.BitcoinPrice {
+    prices := make([]models.BitcoinPrice, 0, len(resp.Prices))
+    for i := 0; i < len(resp.Prices); i++ {
+        prices = append(prices, models.BitcoinPrice{
+            Timestamp: time.UnixMilli(int64(resp.Prices[i][0])),
+            Price:     resp.Prices[i][1],
+            MarketCap: resp.MarketCaps[i][1],
+            Volume24h: resp.TotalVolumes[i][1],
+        })
+    }
+    return prices
+}
+
 // Name returns the crawler name
 func (c *BitcoinCrawler) Name() string {
     return "bitcoin-history"
+}
+
+// checkQuality checks the price series before the fresh points are merged
+// into it. Points after the last stored one form the new batch.
+func (c *BitcoinCrawler) checkQuality(ctx context.Context, history models.BitcoinDailyData, fresh []models.BitcoinPrice) error {
+    var since time.Time
+    if n := len(history.Data); n > 0 {
+        since = history.Data[n-1].Timestamp
+    }
+
+    series := make([]models.MetricPoint, 0, len(fresh))
+    for _, p := range fresh {
+        series = append(series, models.MetricPoint{Timestamp: p.Timestamp, Value: p.Price})
+    }
+    report := quality.Check(c.Name(), series, since, *c.config.Quality)
+    return quality.Apply(ctx, c.storage, *c.config.Quality, report, models.BitcoinDailyData{LastUpdated: time.Now().UTC(), Data: fresh})
+}
+
+// materialize stores the configured aggregates and indicators of the price
+// history
+func (c *BitcoinCrawler) materialize(ctx context.Context, data models.BitcoinDailyData) error {
+    points := resample.FromBitcoin(data.Data)
+    if c.config.Resample != nil {
+        if err := resample.Materialize(ctx, c.storage, c.config.DataPath, "btc", points, *c.config.Resample); err != nil {
+            return err
+        }
+    }
+    if c.config.Indicators != nil {
+        pipeline := indicators.NewPipeline(c.storage, c.config.DataPath, *c.config.Indicators)
+        if _, err := pipeline.Update(ctx, points); err != nil {
+            return fmt.Errorf("failed to update indicators: %w", err)
+        }
+    }
+    return nil
 } 
\ No newline at end of file
@@ -0,0 +1,138 @@
+package crypto
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/cassette/cassettetest"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestBitcoinCrawlerReplay(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    c := NewBitcoinCrawler(s, cassettetest.Client(t, "testdata/bitcoin-history.json"), &Config{DataPath: "crypto/bitcoin"})
+
+    if err := c.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    // Check what holds for any recording, so -record can refresh the cassette
+    var latest models.BitcoinDailyData
+    if err := s.Load(ctx, "crypto/bitcoin/latest.json", &latest); err != nil {
+        t.Fatalf("load latest: %v", err)
+    }
+    if len(latest.Data) == 0 {
+        t.Fatal("stored no prices")
+    }
+    years := make(map[int]int)
+    for i, p := range latest.Data {
+        if i > 0 && !p.Timestamp.After(latest.Data[i-1].Timestamp) {
+            t.Fatalf("point %d at %v does not follow %v", i, p.Timestamp, latest.Data[i-1].Timestamp)
+        }
+        if p.Price <= 0 {
+            t.Errorf("point %d = %+v", i, p)
+        }
+        years[p.Timestamp.UTC().Year()]++
+    }
+
+    // Every year is stored on its own
+    for year, n := range years {
+        var yearly models.BitcoinDailyData
+        if err := s.Load(ctx, fmt.Sprintf("crypto/bitcoin/%d/btc-%d.json", year, year), &yearly); err != nil {
+            t.Fatalf("load %d: %v", year, err)
+        }
+        if len(yearly.Data) != n {
+            t.Errorf("%d holds %d points, want %d", year, len(yearly.Data), n)
+        }
+    }
+}
+
+func TestBitcoinCrawlerMergesIntoHistory(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != "/coins/bitcoin/market_chart" {
+            http.NotFound(w, r)
+            return
+        }
+        w.Header().Set("Content-Type", "application/json")
+        fmt.Fprint(w, `{"prices":[[1704067200000,42280.23],[1704153600000,44187.14],[1704240000000,44961.6]],`+
+            `"market_caps":[[1704067200000,827888213461.2],[1704153600000,865253785912.5],[1704240000000,880593498312.4]],`+
+            `"total_volumes":[[1704067200000,12724473290.7],[1704153600000,23180744124.3],[1704240000000,38001842356.1]]}`)
+    }))
+    defer server.Close()
+    c := NewBitcoinCrawler(s, httpclient.New(httpclient.Config{MaxRetries: -1}), &Config{DataPath: "crypto/bitcoin", BaseURL: server.URL})
+
+    // A backfilled day the response does not cover and the intraday "now"
+    // point of an earlier crawl
+    backfilled := models.BitcoinPrice{Timestamp: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), Price: 42152.1}
+    now := models.BitcoinPrice{Timestamp: time.Date(2024, 1, 3, 15, 4, 0, 0, time.UTC), Price: 43000}
+    history := models.BitcoinDailyData{Data: []models.BitcoinPrice{backfilled, now}}
+    if err := s.Save(ctx, "crypto/bitcoin/latest.json", history); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := c.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    var latest models.BitcoinDailyData
+    if err := s.Load(ctx, "crypto/bitcoin/latest.json", &latest); err != nil {
+        t.Fatal(err)
+    }
+    var got []time.Time
+    for _, p := range latest.Data {
+        got = append(got, p.Timestamp.UTC())
+    }
+    want := []time.Time{
+        backfilled.Timestamp,
+        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+        time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+        time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+    }
+    if len(got) != len(want) {
+        t.Fatalf("stored %v, want %v", got, want)
+    }
+    for i := range want {
+        if !got[i].Equal(want[i]) {
+            t.Fatalf("stored %v, want %v", got, want)
+        }
+    }
+}
+
+func TestMergeCountsAddedDays(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    c := NewBitcoinCrawler(s, nil, &Config{DataPath: "crypto/bitcoin"})
+
+    day := func(d, h int) time.Time { return time.Date(2024, 1, d, h, 0, 0, 0, time.UTC) }
+    // Midnight and the "now" point share a day
+    history := models.BitcoinDailyData{Data: []models.BitcoinPrice{
+        {Timestamp: day(2, 0), Price: 2},
+        {Timestamp: day(2, 15), Price: 2.5},
+    }}
+    fresh := []models.BitcoinPrice{{Timestamp: day(1, 0), Price: 1}, {Timestamp: day(3, 0), Price: 3}}
+
+    data, added, err := c.merge(ctx, history, fresh, false)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if added != 2 {
+        t.Errorf("added = %d, want 2", added)
+    }
+    if len(data.Data) != 4 {
+        t.Errorf("merged %d points, want 4", len(data.Data))
+    }
+
+    // Days already stored are not added again
+    if _, added, err = c.merge(ctx, data, fresh, false); err != nil || added != 0 {
+        t.Errorf("second merge added %d, %v; want 0", added, err)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,138 @@
+package jsonapi
+
+import (
+    "fmt"
+    "net/url"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/quality"
+    "github.com/yourusername/investutil-gocrawler/internal/ratelimit"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
+)
+
+// Pagination styles supported by PaginationConfig.Style
+const (
+    PaginationNone   = "none"
+    PaginationPage   = "page"
+    PaginationOffset = "offset"
+    PaginationCursor = "cursor"
+)
+
+// SourceConfig declares a JSON API source. URL and Params values are Go
+// templates evaluated with the fields of TemplateData, e.g.
+// "{{.Since.Unix}}" or "{{.Since.Format \"2006-01-02\"}}". Header values
+// have environment variables expanded so secrets stay out of config files.
+type SourceConfig struct {
+    Name       string            `yaml:"name"`
+    Schedule   string            `yaml:"schedule"`
+    DataPath   string            `yaml:"data_path"`
+    URL        string            `yaml:"url"`
+    Params     map[string]string `yaml:"params"`
+    Headers    map[string]string `yaml:"headers"`
+    Timeout    time.Duration     `yaml:"timeout"`
+    Start      string            `yaml:"start"`
+    Extract    ExtractConfig     `yaml:"extract"`
+    Pagination PaginationConfig  `yaml:"pagination"`
+    // UserAgents are rotated across requests instead of the client default
+    UserAgents []string `yaml:"user_agents"`
+    // Validation checks the extracted points before they are stored
+    Validation validation.Rules `yaml:"validation"`
+    // Quality enables data quality checks of each field's series
+    Quality *quality.Config `yaml:"quality"`
+    // Resample materializes calendar bars of each field's series
+    Resample *resample.Config `yaml:"resample"`
+    // RateLimit overrides the limits of the URL's host
+    RateLimit *ratelimit.HostConfig `yaml:"rate_limit"`
+}
+
+// ExtractConfig declares how points are read from a response. Paths use a
+// small JSONPath subset: "$", ".key", "['key']" and "[index]".
+type ExtractConfig struct {
+    // Items is the path of the array holding one element per point
+    Items string `yaml:"items"`
+    // Timestamp is the path of the timestamp, relative to an item
+    Timestamp string `yaml:"timestamp"`
+    // TimestampUnit is s, ms, us, ns, rfc3339 or a Go time layout
+    TimestampUnit string `yaml:"timestamp_unit"`
+    // Fields maps a metric name to the path of its value, relative to an item
+    Fields map[string]string `yaml:"fields"`
+    Unit   string            `yaml:"unit"`
+}
+
+// PaginationConfig declares how further pages are requested
+type PaginationConfig struct {
+    Style string `yaml:"style"`
+    // Param is the query parameter carrying the page number, offset or cursor
+    Param string `yaml:"param"`
+    // SizeParam and Size set the page size; a shorter page ends pagination
+    SizeParam string `yaml:"size_param"`
+    Size      int    `yaml:"size"`
+    // Start is the first page number for page style pagination
+    Start int `yaml:"start"`
+    // CursorPath is the path of the next cursor in the response
+    CursorPath string `yaml:"cursor_path"`
+    MaxPages   int    `yaml:"max_pages"`
+}
+
+// Host returns the host of the source URL, used to key its rate limit
+func (s *SourceConfig) Host() (string, error) {
+    u, err := url.Parse(s.URL)
+    if err != nil {
+        return "", fmt.Errorf("source %s: invalid url: %w", s.Name, err)
+    }
+    if u.Hostname() == "" {
+        return "", fmt.Errorf("source %s: url has no host", s.Name)
+    }
+    return u.Hostname(), nil
+}
+
+// Validate checks the source configuration for missing or unknown settings
+func (s *SourceConfig) Validate() error {
+    if s.Name == "" {
+        return fmt.Errorf("source name is required")
+    }
+    if s.URL == "" {
+        return fmt.Errorf("source %s: url is required", s.Name)
+    }
+    if s.DataPath == "" {
+        return fmt.Errorf("source %s: data_path is required", s.Name)
+    }
+    if s.Extract.Timestamp == "" {
+        return fmt.Errorf("source %s: extract.timestamp is required", s.Name)
+    }
+    if len(s.Extract.Fields) == 0 {
+        return fmt.Errorf("source %s: at least one extract field is required", s.Name)
+    }
+    if s.Quality != nil {
+        if err := s.Quality.Validate(); err != nil {
+            return fmt.Errorf("source %s: %w", s.Name, err)
+        }
+    }
+    if s.Resample != nil {
+        if err := s.Resample.Validate(); err != nil {
+            return fmt.Errorf("source %s: %w", s.Name, err)
+        }
+    }
+    if s.Start != "" {
+        if _, err := time.Parse("2006-01-02", s.Start); err != nil {
+            return fmt.Errorf("source %s: invalid start date: %w", s.Name, err)
+        }
+    }
+
+    switch s.Pagination.Style {
+    case "", PaginationNone:
+    case PaginationPage, PaginationOffset:
+        if s.Pagination.Param == "" {
+            return fmt.Errorf("source %s: pagination param is required", s.Name)
+        }
+    case PaginationCursor:
+        if s.Pagination.Param == "" || s.Pagination.CursorPath == "" {
+            return fmt.Errorf("source %s: pagination param and cursor_path are required", s.Name)
+        }
+    default:
+        return fmt.Errorf("source %s: unknown pagination style %q", s.Name, s.Pagination.Style)
+    }
+
+    return nil
+} 
\ No newline at end of file
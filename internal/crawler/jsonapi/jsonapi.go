@@ -0,0 +1,388 @@
+package jsonapi
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "net/http"
+    "net/url"
+    "os"
+    "sort"
+    "strconv"
+    "strings"
+    "text/template"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/httpcache"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/proxy"
+    "github.com/yourusername/investutil-gocrawler/internal/quality"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
+)
+
+const defaultMaxPages = 100
+
+// TemplateData is available to URL and parameter templates
+type TemplateData struct {
+    // Since is the last stored timestamp, or the configured start date
+    Since time.Time
+    Now   time.Time
+}
+
+// SourceCrawler crawls a JSON API source described by a SourceConfig
+type SourceCrawler struct {
+    *crawler.BaseCrawler
+    storage    storage.Storage
+    client     *httpclient.Client
+    config     *SourceConfig
+    url        *template.Template
+    params     map[string]*template.Template
+    userAgents *httpclient.UserAgents
+}
+
+// NewSourceCrawler creates a new SourceCrawler after validating the source configuration
+func NewSourceCrawler(storage storage.Storage, client *httpclient.Client, config *SourceConfig) (*SourceCrawler, error) {
+    if err := config.Validate(); err != nil {
+        return nil, err
+    }
+
+    urlTmpl, err := template.New("url").Parse(config.URL)
+    if err != nil {
+        return nil, fmt.Errorf("source %s: invalid url template: %w", config.Name, err)
+    }
+    params := make(map[string]*template.Template, len(config.Params))
+    for name, value := range config.Params {
+        t, err := template.New(name).Parse(value)
+        if err != nil {
+            return nil, fmt.Errorf("source %s: invalid template for param %s: %w", config.Name, name, err)
+        }
+        params[name] = t
+    }
+
+    return &SourceCrawler{
+        BaseCrawler: crawler.NewBaseCrawler(config.Name, config.Schedule),
+        storage:     storage,
+        client:      client,
+        config:      config,
+        url:         urlTmpl,
+        params:      params,
+        userAgents:  httpclient.NewUserAgents(config.UserAgents),
+    }, nil
+}
+
+// Crawl fetches every page of the source and appends new points to each field's series
+func (c *SourceCrawler) Crawl(ctx context.Context) error {
+    fields := make([]string, 0, len(c.config.Extract.Fields))
+    for name := range c.config.Extract.Fields {
+        fields = append(fields, name)
+    }
+    sort.Strings(fields)
+
+    // Resume from the field that is furthest behind
+    existing := make(map[string]models.MetricSeries, len(fields))
+    var since time.Time
+    for i, name := range fields {
+        series, err := crawler.LoadSeries(ctx, c.storage, c.prefix(name))
+        if err != nil {
+            return fmt.Errorf("field %s: %w", name, err)
+        }
+        existing[name] = series
+
+        var last time.Time
+        if n := len(series.Data); n > 0 {
+            last = series.Data[n-1].Timestamp
+        }
+        if i == 0 || last.Before(since) {
+            since = last
+        }
+    }
+    if since.IsZero() && c.config.Start != "" {
+        since, _ = time.Parse("2006-01-02", c.config.Start)
+    }
+
+    points, pending, err := c.fetch(ctx, TemplateData{Since: since, Now: time.Now().UTC()})
+    if err != nil {
+        if errors.Is(err, httpcache.ErrNotModified) {
+            c.UpdateLastRun()
+            return crawler.ErrNoChange
+        }
+        return err
+    }
+
+    v := validation.New(c.Name())
+    c.config.Validation.Check(v, points)
+    if err := v.Err(); err != nil {
+        validation.RecordDrift(ctx, c.storage, err)
+        return err
+    }
+
+    // A field whose batch fails the quality checks is skipped, the others are stored
+    var errs []error
+    for _, name := range fields {
+        series := existing[name]
+        series.Metric = name
+        series.Unit = c.config.Extract.Unit
+        if c.config.Quality != nil {
+            if err := c.checkQuality(ctx, name, series, points[name]); err != nil {
+                errs = append(errs, fmt.Errorf("field %s: %w", name, err))
+                continue
+            }
+        }
+        fresh := crawler.FreshPoints(series, points[name])
+        saved, err := crawler.AppendSeries(ctx, c.storage, c.prefix(name), series, fresh)
+        if err != nil {
+            return fmt.Errorf("field %s: %w", name, err)
+        }
+        crawler.RecordRecords(c.Name(), len(points[name]), saved)
+        if c.config.Resample != nil && len(fresh) > 0 {
+            all := append(series.Data[:len(series.Data):len(series.Data)], fresh...)
+            if err := resample.Materialize(ctx, c.storage, c.prefix(name), name, resample.FromSeries(all), *c.config.Resample); err != nil {
+                return fmt.Errorf("field %s: %w", name, err)
+            }
+        }
+    }
+    if len(errs) > 0 {
+        return errors.Join(errs...)
+    }
+    // Only stored points may answer the next crawl with "no change"
+    if err := pending.Commit(ctx); err != nil {
+        log.Printf("HTTP cache: %v", err)
+    }
+
+    c.UpdateLastRun()
+    return nil
+}
+
+// checkQuality checks a field's series with the new points appended
+func (c *SourceCrawler) checkQuality(ctx context.Context, field string, series models.MetricSeries, points []models.MetricPoint) error {
+    var since time.Time
+    if n := len(series.Data); n > 0 {
+        since = series.Data[n-1].Timestamp
+    }
+    fresh := crawler.FreshPoints(series, points)
+    merged := append(series.Data[:len(series.Data):len(series.Data)], fresh...)
+
+    report := quality.Check(fmt.Sprintf("%s-%s", c.Name(), field), merged, since, *c.config.Quality)
+    return quality.Apply(ctx, c.storage, *c.config.Quality, report, fresh)
+}
+
+// prefix returns the storage prefix of a field's series
+func (c *SourceCrawler) prefix(field string) string {
+    return fmt.Sprintf("%s/%s", c.config.DataPath, field)
+}
+
+// fetch walks all pages and returns the extracted points per field, along
+// with the cache entry of the first page to commit once they are stored
+func (c *SourceCrawler) fetch(ctx context.Context, data TemplateData) (map[string][]models.MetricPoint, *httpcache.Pending, error) {
+    p := c.config.Pagination
+    maxPages := p.MaxPages
+    if maxPages <= 0 {
+        maxPages = defaultMaxPages
+    }
+
+    points := make(map[string][]models.MetricPoint)
+    var pending *httpcache.Pending
+    page := p.Start
+    offset := 0
+    cursor := ""
+
+    for i := 0; i < maxPages; i++ {
+        extra := url.Values{}
+        switch p.Style {
+        case PaginationPage:
+            extra.Set(p.Param, strconv.Itoa(page))
+        case PaginationOffset:
+            extra.Set(p.Param, strconv.Itoa(offset))
+        case PaginationCursor:
+            if cursor != "" {
+                extra.Set(p.Param, cursor)
+            }
+        }
+        if p.SizeParam != "" && p.Size > 0 {
+            extra.Set(p.SizeParam, strconv.Itoa(p.Size))
+        }
+
+        // An unchanged first page means there is nothing new to fetch
+        pageCtx := ctx
+        if i == 0 {
+            pageCtx, pending = httpcache.Conditional(ctx)
+        }
+        doc, err := c.get(pageCtx, data, extra)
+        if err != nil {
+            return nil, nil, fmt.Errorf("page %d: %w", i+1, err)
+        }
+
+        n, err := c.extract(doc, points)
+        if err != nil {
+            return nil, nil, fmt.Errorf("page %d: %w", i+1, err)
+        }
+
+        // Decide whether there is another page
+        switch p.Style {
+        case PaginationPage, PaginationOffset:
+            if n == 0 || (p.Size > 0 && n < p.Size) {
+                return points, pending, nil
+            }
+            page++
+            offset += n
+        case PaginationCursor:
+            next, err := lookup(doc, p.CursorPath)
+            if err != nil || next == nil {
+                return points, pending, nil
+            }
+            cursor = strings.TrimSpace(fmt.Sprint(next))
+            if cursor == "" {
+                return points, pending, nil
+            }
+        default:
+            return points, pending, nil
+        }
+    }
+
+    return nil, nil, fmt.Errorf("source %s: more than %d pages", c.config.Name, maxPages)
+}
+
+// get requests a single page and decodes the JSON document
+func (c *SourceCrawler) get(ctx context.Context, data TemplateData, extra url.Values) (interface{}, error) {
+    var buf bytes.Buffer
+    if err := c.url.Execute(&buf, data); err != nil {
+        return nil, fmt.Errorf("failed to render url: %w", err)
+    }
+    u, err := url.Parse(buf.String())
+    if err != nil {
+        return nil, fmt.Errorf("invalid url %q: %w", buf.String(), err)
+    }
+
+    query := u.Query()
+    for name, t := range c.params {
+        buf.Reset()
+        if err := t.Execute(&buf, data); err != nil {
+            return nil, fmt.Errorf("failed to render param %s: %w", name, err)
+        }
+        query.Set(name, buf.String())
+    }
+    for name, values := range extra {
+        query[name] = values
+    }
+    u.RawQuery = query.Encode()
+
+    // The source timeout bounds the whole request, including retries
+    if c.config.Timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+        defer cancel()
+    }
+    // Keep the source on one proxy when the pool is sticky
+    ctx = proxy.WithSession(ctx, c.config.Name)
+    // Cache under the unrendered templates, so a URL embedding {{.Now}}
+    // keeps a single entry per page
+    keyQuery := url.Values{}
+    for name, value := range c.config.Params {
+        keyQuery.Set(name, value)
+    }
+    for name, values := range extra {
+        keyQuery[name] = values
+    }
+    ctx = httpcache.WithKey(ctx, c.config.URL+"?"+keyQuery.Encode())
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+    req.Header.Set("Accept", "application/json")
+    if ua := c.userAgents.Next(); ua != "" {
+        req.Header.Set("User-Agent", ua)
+    }
+    for name, value := range c.config.Headers {
+        req.Header.Set(name, os.ExpandEnv(value))
+    }
+
+    var doc interface{}
+    if err := c.client.DoJSON(req, &doc); err != nil {
+        return nil, err
+    }
+    return doc, nil
+}
+
+// extract appends the points of one page to points and returns the number of items
+func (c *SourceCrawler) extract(doc interface{}, points map[string][]models.MetricPoint) (int, error) {
+    e := c.config.Extract
+
+    raw, err := lookup(doc, e.Items)
+    if err != nil {
+        return 0, fmt.Errorf("items %q: %w", e.Items, err)
+    }
+    items, ok := raw.([]interface{})
+    if !ok {
+        return 0, fmt.Errorf("items %q: expected array, got %T", e.Items, raw)
+    }
+
+    for i, item := range items {
+        tsRaw, err := lookup(item, e.Timestamp)
+        if err != nil {
+            return 0, fmt.Errorf("item %d: timestamp: %w", i, err)
+        }
+        ts, err := parseTimestamp(tsRaw, e.TimestampUnit)
+        if err != nil {
+            return 0, fmt.Errorf("item %d: timestamp: %w", i, err)
+        }
+
+        for name, path := range e.Fields {
+            v, err := lookup(item, path)
+            if err != nil {
+                return 0, fmt.Errorf("item %d: field %s: %w", i, name, err)
+            }
+            if v == nil {
+                continue
+            }
+            value, err := toFloat(v)
+            if err != nil {
+                return 0, fmt.Errorf("item %d: field %s: %w", i, name, err)
+            }
+            points[name] = append(points[name], models.MetricPoint{Timestamp: ts, Value: value})
+        }
+    }
+
+    return len(items), nil
+}
+
+// parseTimestamp converts a JSON timestamp in the given unit to UTC time
+func parseTimestamp(v interface{}, unit string) (time.Time, error) {
+    if s, ok := v.(string); ok {
+        switch unit {
+        case "", "s", "ms", "us", "ns":
+            // Numeric timestamps are sometimes sent as strings
+        case "rfc3339":
+            t, err := time.Parse(time.RFC3339, s)
+            return t.UTC(), err
+        default:
+            t, err := time.Parse(unit, s)
+            return t.UTC(), err
+        }
+    }
+
+    f, err := toFloat(v)
+    if err != nil {
+        return time.Time{}, err
+    }
+    n := int64(f)
+
+    switch unit {
+    case "", "s":
+        return time.Unix(n, 0).UTC(), nil
+    case "ms":
+        return time.UnixMilli(n).UTC(), nil
+    case "us":
+        return time.UnixMicro(n).UTC(), nil
+    case "ns":
+        return time.Unix(0, n).UTC(), nil
+    default:
+        return time.Time{}, fmt.Errorf("numeric timestamp with unit %q", unit)
+    }
+} 
\ No newline at end of file
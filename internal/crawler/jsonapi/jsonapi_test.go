@@ -0,0 +1,131 @@
+package jsonapi
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/cassette/cassettetest"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/httpcache"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
+)
+
+func TestSourceCrawlerReplay(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    c, err := NewSourceCrawler(s, cassettetest.Client(t, "testdata/eth-usd.json"), &SourceConfig{
+        Name:     "eth-usd",
+        DataPath: "crypto/ethereum",
+        URL:      "https://api.kraken.com/0/public/OHLC",
+        Params: map[string]string{
+            "pair":     "XETHZUSD",
+            "interval": "1440",
+            "since":    "{{.Since.Unix}}",
+        },
+        Start: "2024-01-01",
+        Extract: ExtractConfig{
+            Items:         "$.result.XETHZUSD",
+            Timestamp:     "[0]",
+            TimestampUnit: "s",
+            Fields:        map[string]string{"close": "[4]", "volume": "[6]"},
+            Unit:          "USD",
+        },
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := c.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    closes, err := crawler.LoadSeries(ctx, s, "crypto/ethereum/close")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(closes.Data) == 0 {
+        t.Fatal("stored no closes")
+    }
+    for i, p := range closes.Data {
+        if i > 0 && !p.Timestamp.After(closes.Data[i-1].Timestamp) {
+            t.Fatalf("close %d at %v does not follow %v", i, p.Timestamp, closes.Data[i-1].Timestamp)
+        }
+        if p.Value <= 0 || p.Timestamp.Before(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+            t.Errorf("close %d = %+v", i, p)
+        }
+    }
+    volumes, err := crawler.LoadSeries(ctx, s, "crypto/ethereum/volume")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(volumes.Data) != len(closes.Data) {
+        t.Errorf("stored %d volumes for %d closes", len(volumes.Data), len(closes.Data))
+    }
+}
+
+func TestSourceCrawlerConditional(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+
+    // The first response fails validation, later ones carry a valid point
+    var requests int
+    var ifNoneMatch []string
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        requests++
+        ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+        value, etag := 5, `"v2"`
+        if requests == 1 {
+            value, etag = -1, `"v1"`
+        }
+        if r.Header.Get("If-None-Match") == etag {
+            w.WriteHeader(http.StatusNotModified)
+            return
+        }
+        w.Header().Set("ETag", etag)
+        fmt.Fprintf(w, `{"items":[{"t":1704067200,"v":%d}]}`, value)
+    }))
+    defer server.Close()
+
+    client := httpclient.New(httpclient.Config{MaxRetries: -1})
+    client.Use(httpcache.Middleware(httpcache.NewStorageCache(s)))
+    zero := 0.0
+    c, err := NewSourceCrawler(s, client, &SourceConfig{
+        Name:     "conditional",
+        DataPath: "test/conditional",
+        URL:      server.URL,
+        Params:   map[string]string{"to": "{{.Now.Unix}}"},
+        Extract: ExtractConfig{
+            Items:         "$.items",
+            Timestamp:     ".t",
+            TimestampUnit: "s",
+            Fields:        map[string]string{"value": ".v"},
+        },
+        Validation: validation.Rules{Fields: map[string]validation.Bounds{"value": {Min: &zero}}},
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := c.Crawl(ctx); err == nil {
+        t.Fatal("Crawl stored an invalid response")
+    }
+    if err := c.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+    // The URL changes with {{.Now}}, yet the stored response revalidates
+    if err := c.Crawl(ctx); !errors.Is(err, crawler.ErrNoChange) {
+        t.Errorf("Crawl of an unchanged response = %v, want ErrNoChange", err)
+    }
+
+    want := []string{"", "", `"v2"`}
+    if fmt.Sprint(ifNoneMatch) != fmt.Sprint(want) {
+        t.Errorf("If-None-Match sent %q, want %q: a failed crawl must not be committed", ifNoneMatch, want)
+    }
+} 
\ No newline at end of file
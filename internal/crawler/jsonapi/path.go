@@ -0,0 +1,92 @@
+package jsonapi
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// lookup walks a decoded JSON document along a path such as
+// "$.data.items", "prices[0]" or "$['market data'].close"
+func lookup(doc interface{}, path string) (interface{}, error) {
+    path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+    cur := doc
+
+    for len(path) > 0 {
+        var key string
+        var index = -1
+
+        switch path[0] {
+        case '.':
+            path = path[1:]
+            end := strings.IndexAny(path, ".[")
+            if end < 0 {
+                end = len(path)
+            }
+            key, path = path[:end], path[end:]
+        case '[':
+            end := strings.IndexByte(path, ']')
+            if end < 0 {
+                return nil, fmt.Errorf("unterminated bracket in path")
+            }
+            inner := path[1:end]
+            path = path[end+1:]
+            if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+                key = inner[1 : len(inner)-1]
+            } else {
+                i, err := strconv.Atoi(inner)
+                if err != nil {
+                    return nil, fmt.Errorf("invalid index %q", inner)
+                }
+                index = i
+            }
+        default:
+            // A path may start with a bare key
+            end := strings.IndexAny(path, ".[")
+            if end < 0 {
+                end = len(path)
+            }
+            key, path = path[:end], path[end:]
+        }
+
+        if index >= 0 {
+            arr, ok := cur.([]interface{})
+            if !ok {
+                return nil, fmt.Errorf("cannot index %T", cur)
+            }
+            if index >= len(arr) {
+                return nil, fmt.Errorf("index %d out of range", index)
+            }
+            cur = arr[index]
+            continue
+        }
+
+        obj, ok := cur.(map[string]interface{})
+        if !ok {
+            return nil, fmt.Errorf("cannot read key %q from %T", key, cur)
+        }
+        v, ok := obj[key]
+        if !ok {
+            return nil, fmt.Errorf("key %q not found", key)
+        }
+        cur = v
+    }
+
+    return cur, nil
+}
+
+// toFloat converts a JSON number or numeric string to float64
+func toFloat(v interface{}) (float64, error) {
+    switch n := v.(type) {
+    case float64:
+        return n, nil
+    case string:
+        f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+        if err != nil {
+            return 0, fmt.Errorf("invalid number %q", n)
+        }
+        return f, nil
+    default:
+        return 0, fmt.Errorf("expected number, got %T", v)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,50 @@
+package crawler
+
+import (
+    "errors"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/metrics"
+)
+
+var (
+    runsTotal = metrics.NewCounterVec("crawler_runs_total",
+        "Crawler runs by outcome: success, no_change, paused or failure", "crawler", "outcome")
+    runDuration = metrics.NewHistogramVec("crawler_run_duration_seconds",
+        "Duration of crawler runs", []float64{.1, .5, 1, 5, 10, 30, 60, 120, 300, 600}, "crawler")
+    lastSuccess = metrics.NewGaugeVec("crawler_last_success_timestamp_seconds",
+        "Unix time of the last successful or unchanged crawler run", "crawler")
+    recordsFetched = metrics.NewCounterVec("crawler_records_fetched_total",
+        "Records returned by the upstream APIs and sites", "crawler")
+    recordsSaved = metrics.NewCounterVec("crawler_records_saved_total",
+        "Records written to storage", "crawler")
+)
+
+// RecordRun records the outcome of a run of the named crawler in the metrics
+func RecordRun(name string, duration time.Duration, err error) {
+    outcome := "success"
+    switch {
+    case errors.Is(err, ErrNoChange):
+        outcome = "no_change"
+    case err != nil:
+        outcome = "failure"
+    }
+    runsTotal.WithLabelValues(name, outcome).Inc()
+    runDuration.WithLabelValues(name).Observe(duration.Seconds())
+    if outcome != "failure" {
+        lastSuccess.WithLabelValues(name).SetToCurrentTime()
+    }
+}
+
+// RecordPause records a run of the named crawler that stopped because its
+// request budget is exhausted
+func RecordPause(name string, duration time.Duration) {
+    runsTotal.WithLabelValues(name, "paused").Inc()
+    runDuration.WithLabelValues(name).Observe(duration.Seconds())
+}
+
+// RecordRecords adds to the records fetched and saved by the named crawler
+func RecordRecords(name string, fetched, saved int) {
+    recordsFetched.WithLabelValues(name).Add(float64(fetched))
+    recordsSaved.WithLabelValues(name).Add(float64(saved))
+} 
\ No newline at end of file
@@ -0,0 +1,151 @@
+package onchain
+
+import (
+    "context"
+    "fmt"
+    "net/url"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
+)
+
+const (
+    blockchainBaseURL = "https://api.blockchain.info"
+)
+
+// DefaultMetrics lists the charts crawled when Config.Metrics is empty
+var DefaultMetrics = []string{
+    "hash-rate",
+    "difficulty",
+    "n-transactions",
+    "mempool-size",
+    "transaction-fees",
+}
+
+// Config holds configuration for BlockchainCrawler
+type Config struct {
+    DataPath string   `yaml:"data_path"`
+    Schedule string   `yaml:"schedule"`
+    BaseURL  string   `yaml:"base_url"`
+    Metrics  []string `yaml:"metrics"`
+}
+
+// BlockchainCrawler crawls Bitcoin on-chain metrics from a blockchain.info style charts API
+type BlockchainCrawler struct {
+    *crawler.BaseCrawler
+    storage storage.Storage
+    client  *httpclient.Client
+    config  *Config
+}
+
+// NewBlockchainCrawler creates a new BlockchainCrawler instance
+func NewBlockchainCrawler(storage storage.Storage, client *httpclient.Client, config *Config) *BlockchainCrawler {
+    return &BlockchainCrawler{
+        BaseCrawler: crawler.NewBaseCrawler("bitcoin-onchain", config.Schedule),
+        storage:     storage,
+        client:      client,
+        config:      config,
+    }
+}
+
+// Crawl fetches every configured metric and appends new points to its series
+func (c *BlockchainCrawler) Crawl(ctx context.Context) error {
+    metrics := c.config.Metrics
+    if len(metrics) == 0 {
+        metrics = DefaultMetrics
+    }
+
+    for _, metric := range metrics {
+        if err := c.crawlMetric(ctx, metric); err != nil {
+            return fmt.Errorf("metric %s: %w", metric, err)
+        }
+    }
+
+    c.UpdateLastRun()
+    return nil
+}
+
+// crawlMetric updates the stored series of a single metric
+func (c *BlockchainCrawler) crawlMetric(ctx context.Context, metric string) error {
+    prefix := fmt.Sprintf("%s/%s", c.config.DataPath, metric)
+
+    existing, err := crawler.LoadSeries(ctx, c.storage, prefix)
+    if err != nil {
+        return err
+    }
+
+    var since time.Time
+    if n := len(existing.Data); n > 0 {
+        since = existing.Data[n-1].Timestamp
+    }
+
+    chart, err := c.fetchChart(ctx, metric, since)
+    if err != nil {
+        return err
+    }
+
+    // A full history must not be empty; an incremental one may be
+    v := validation.New(c.Name())
+    if since.IsZero() {
+        v.NonEmpty(metric, len(chart.Values))
+    }
+    points := make([]models.MetricPoint, 0, len(chart.Values))
+    timestamps := make([]time.Time, 0, len(chart.Values))
+    for _, value := range chart.Values {
+        point := models.MetricPoint{
+            Timestamp: time.Unix(int64(value.X), 0).UTC(),
+            Value:     value.Y,
+        }
+        v.Range(metric, point.Value, nil, nil)
+        points = append(points, point)
+        timestamps = append(timestamps, point.Timestamp)
+    }
+    v.Increasing(metric, timestamps)
+    if err := v.Err(); err != nil {
+        validation.RecordDrift(ctx, c.storage, err)
+        return err
+    }
+
+    existing.Metric = metric
+    existing.Unit = chart.Unit
+    saved, err := crawler.AppendSeries(ctx, c.storage, prefix, existing, points)
+    if err != nil {
+        return err
+    }
+    crawler.RecordRecords(c.Name(), len(points), saved)
+
+    return nil
+}
+
+// fetchChart requests a chart, starting at since when it is set
+func (c *BlockchainCrawler) fetchChart(ctx context.Context, metric string, since time.Time) (*models.BlockchainChartResponse, error) {
+    baseURL := c.config.BaseURL
+    if baseURL == "" {
+        baseURL = blockchainBaseURL
+    }
+
+    params := url.Values{}
+    params.Set("format", "json")
+    params.Set("sampled", "false")
+    if since.IsZero() {
+        params.Set("timespan", "all")
+    } else {
+        params.Set("start", since.Format("2006-01-02"))
+    }
+
+    reqURL := fmt.Sprintf("%s/charts/%s?%s", baseURL, url.PathEscape(metric), params.Encode())
+
+    var chart models.BlockchainChartResponse
+    if err := c.client.GetJSON(ctx, reqURL, &chart); err != nil {
+        return nil, err
+    }
+    if chart.Status != "" && chart.Status != "ok" {
+        return nil, fmt.Errorf("unexpected chart status: %s", chart.Status)
+    }
+
+    return &chart, nil
+} 
\ No newline at end of file
@@ -0,0 +1,149 @@
+package onchain
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
+    "sort"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/cassette/cassettetest"
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestBlockchainCrawlerReplay(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    c := NewBlockchainCrawler(s, cassettetest.Client(t, "testdata/bitcoin-onchain.json"), &Config{
+        DataPath: "onchain/bitcoin",
+        Metrics:  []string{"hash-rate", "n-transactions"},
+    })
+
+    if err := c.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    for metric, unit := range map[string]string{"hash-rate": "Hash Rate TH/s", "n-transactions": "Transactions"} {
+        series, err := crawler.LoadSeries(ctx, s, "onchain/bitcoin/"+metric)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if series.Metric != metric || series.Unit != unit || len(series.Data) == 0 {
+            t.Fatalf("%s: stored %s in %q with %d points", metric, series.Metric, series.Unit, len(series.Data))
+        }
+        years := make(map[int]int)
+        for i, p := range series.Data {
+            if i > 0 && !p.Timestamp.After(series.Data[i-1].Timestamp) {
+                t.Fatalf("%s: point %d at %v does not follow %v", metric, i, p.Timestamp, series.Data[i-1].Timestamp)
+            }
+            years[p.Timestamp.UTC().Year()]++
+        }
+        for year, n := range years {
+            var yearly models.MetricSeries
+            if err := s.Load(ctx, fmt.Sprintf("onchain/bitcoin/%s/%d/%s-%d.json", metric, year, metric, year), &yearly); err != nil {
+                t.Fatalf("%s: load %d: %v", metric, year, err)
+            }
+            if len(yearly.Data) != n {
+                t.Errorf("%s: %d holds %d points, want %d", metric, year, len(yearly.Data), n)
+            }
+        }
+    }
+}
+
+func TestBlockchainCrawlerMetrics(t *testing.T) {
+    day := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+    units := map[string]string{
+        "hash-rate":        "Hash Rate TH/s",
+        "difficulty":       "Difficulty",
+        "n-transactions":   "Transactions",
+        "mempool-size":     "Bytes",
+        "transaction-fees": "BTC",
+    }
+
+    var mu sync.Mutex
+    queries := make(map[string][]url.Values)
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        metric := strings.TrimPrefix(r.URL.Path, "/charts/")
+        unit, ok := units[metric]
+        if !ok {
+            http.NotFound(w, r)
+            return
+        }
+        mu.Lock()
+        queries[metric] = append(queries[metric], r.URL.Query())
+        mu.Unlock()
+
+        // A full history spans the turn of the year; an incremental one
+        // repeats the last stored day and adds the next
+        days := []int{0, 1, 2}
+        if r.URL.Query().Get("start") != "" {
+            days = []int{2, 3}
+        }
+        var values []map[string]float64
+        for _, d := range days {
+            values = append(values, map[string]float64{"x": float64(day.AddDate(0, 0, d).Unix()), "y": float64(100 + d)})
+        }
+        json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "name": metric, "unit": unit, "values": values})
+    }))
+    defer server.Close()
+
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    c := NewBlockchainCrawler(s, httpclient.New(httpclient.Config{MaxRetries: -1}), &Config{
+        DataPath: "onchain/bitcoin",
+        BaseURL:  server.URL,
+    })
+    for i := 0; i < 2; i++ {
+        if err := c.Crawl(ctx); err != nil {
+            t.Fatalf("Crawl %d: %v", i+1, err)
+        }
+    }
+
+    for metric, unit := range units {
+        q := queries[metric]
+        if len(q) != 2 || q[0].Get("timespan") != "all" || q[1].Get("start") != "2024-01-02" || q[1].Get("format") != "json" {
+            t.Errorf("%s: queries = %v", metric, q)
+        }
+
+        prefix := "onchain/bitcoin/" + metric
+        series, err := crawler.LoadSeries(ctx, s, prefix)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if series.Metric != metric || series.Unit != unit || len(series.Data) != 4 || series.Data[3].Value != 103 {
+            t.Errorf("%s: latest = %+v", metric, series)
+        }
+
+        keys, err := s.List(ctx, prefix+"/")
+        if err != nil {
+            t.Fatal(err)
+        }
+        sort.Strings(keys)
+        want := []string{
+            fmt.Sprintf("%s/2023/%s-2023.json", prefix, metric),
+            fmt.Sprintf("%s/2024/%s-2024.json", prefix, metric),
+            prefix + "/latest.json",
+        }
+        if strings.Join(keys, " ") != strings.Join(want, " ") {
+            t.Errorf("%s: keys = %v, want %v", metric, keys, want)
+        }
+        for year, n := range map[int]int{2023: 1, 2024: 3} {
+            var yearly models.MetricSeries
+            if err := s.Load(ctx, fmt.Sprintf("%s/%d/%s-%d.json", prefix, year, metric, year), &yearly); err != nil {
+                t.Fatal(err)
+            }
+            if len(yearly.Data) != n {
+                t.Errorf("%s: %d holds %d points, want %d", metric, year, len(yearly.Data), n)
+            }
+        }
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,280 @@
+package reconcile
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "math"
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Consensus methods supported by Config.Method
+const (
+    MethodMedian   = "median"
+    MethodPriority = "priority"
+)
+
+// Config holds configuration for a reconciliation job
+type Config struct {
+    Name     string `yaml:"name"`
+    Schedule string `yaml:"schedule"`
+    // DataPath receives the consensus series and the deviation report
+    DataPath string `yaml:"data_path"`
+    // Sources are listed in priority order
+    Sources []crawler.SeriesSource `yaml:"sources"`
+    // Method is median or priority, defaults to median
+    Method string `yaml:"method"`
+    // Threshold is the relative deviation from the consensus that is flagged, defaults to 0.01
+    Threshold float64 `yaml:"threshold"`
+    // Interval is the bucket timestamps are aligned to, defaults to a day
+    Interval time.Duration `yaml:"interval"`
+    Unit     string        `yaml:"unit"`
+}
+
+// Validate checks the configuration for missing or unknown settings
+func (c *Config) Validate() error {
+    if c.Name == "" {
+        return fmt.Errorf("reconcile name is required")
+    }
+    if c.DataPath == "" {
+        return fmt.Errorf("reconcile %s: data_path is required", c.Name)
+    }
+    if len(c.Sources) < 2 {
+        return fmt.Errorf("reconcile %s: at least two sources are required", c.Name)
+    }
+    for _, s := range c.Sources {
+        if err := s.Validate(); err != nil {
+            return fmt.Errorf("reconcile %s: source %w", c.Name, err)
+        }
+    }
+    switch c.Method {
+    case "", MethodMedian, MethodPriority:
+    default:
+        return fmt.Errorf("reconcile %s: unknown method %q", c.Name, c.Method)
+    }
+    return nil
+}
+
+// Job reconciles the series of one asset from several sources. It runs on
+// the scheduler like a crawler but only reads from storage.
+type Job struct {
+    *crawler.BaseCrawler
+    storage storage.Storage
+    config  *Config
+}
+
+// NewJob creates a new Job after validating the configuration
+func NewJob(storage storage.Storage, config *Config) (*Job, error) {
+    if err := config.Validate(); err != nil {
+        return nil, err
+    }
+    if config.Method == "" {
+        config.Method = MethodMedian
+    }
+    if config.Threshold <= 0 {
+        config.Threshold = 0.01
+    }
+    if config.Interval <= 0 {
+        config.Interval = 24 * time.Hour
+    }
+    return &Job{
+        BaseCrawler: crawler.NewBaseCrawler("reconcile-"+config.Name, config.Schedule),
+        storage:     storage,
+        config:      config,
+    }, nil
+}
+
+// Crawl aligns the sources, stores the consensus series under
+// <data_path>/consensus and the deviations under <data_path>/deviations
+func (j *Job) Crawl(ctx context.Context) error {
+    aligned := make(map[time.Time]map[string]float64)
+    names := make([]string, 0, len(j.config.Sources))
+    for _, src := range j.config.Sources {
+        points, err := crawler.LoadPoints(ctx, j.storage, src)
+        if err != nil {
+            return fmt.Errorf("source %s: %w", src.Name, err)
+        }
+        names = append(names, src.Name)
+
+        // The last point of a bucket wins, e.g. a daily close
+        for _, p := range points {
+            bucket := p.Timestamp.UTC().Truncate(j.config.Interval)
+            if aligned[bucket] == nil {
+                aligned[bucket] = make(map[string]float64)
+            }
+            aligned[bucket][src.Name] = p.Value
+        }
+    }
+
+    timestamps := make([]time.Time, 0, len(aligned))
+    for t := range aligned {
+        timestamps = append(timestamps, t)
+    }
+    sort.Slice(timestamps, func(a, b int) bool {
+        return timestamps[a].Before(timestamps[b])
+    })
+
+    report := models.ReconciliationReport{
+        LastUpdated: time.Now().UTC(),
+        Method:      j.config.Method,
+        Threshold:   j.config.Threshold,
+        Sources:     names,
+        Stats:       make(map[string]models.SourceDeviation, len(names)),
+    }
+    consensus := make([]models.MetricPoint, 0, len(timestamps))
+    disagreements := 0
+    for _, t := range timestamps {
+        point := j.reconcile(t, aligned[t], names)
+        consensus = append(consensus, models.MetricPoint{Timestamp: t, Value: point.Consensus})
+        if len(point.Values) < 2 {
+            continue
+        }
+
+        addStats(report.Stats, point)
+        if len(point.Flagged) > 0 {
+            disagreements++
+        }
+        report.Points = append(report.Points, point)
+    }
+
+    if disagreements > 0 {
+        log.Printf("Reconcile %s: sources disagree by more than %.2f%% at %d of %d timestamps",
+            j.config.Name, j.config.Threshold*100, disagreements, len(report.Points))
+    }
+
+    // The consensus is recomputed in full, so rewrite every year
+    series := models.MetricSeries{Metric: "consensus", Unit: j.config.Unit}
+    if _, err := crawler.AppendSeries(ctx, j.storage, fmt.Sprintf("%s/consensus", j.config.DataPath), series, consensus); err != nil {
+        return fmt.Errorf("failed to save consensus: %w", err)
+    }
+
+    if err := j.saveDeviations(ctx, report); err != nil {
+        return err
+    }
+
+    j.UpdateLastRun()
+    return nil
+}
+
+// saveDeviations splits the report by year like the other series: each
+// <data_path>/deviations/<year>/deviations-<year>.json holds the points and
+// statistics of its year, and latest.json the statistics over all years with
+// the points of the newest one.
+func (j *Job) saveDeviations(ctx context.Context, report models.ReconciliationReport) error {
+    prefix := fmt.Sprintf("%s/deviations", j.config.DataPath)
+
+    var years []int
+    yearly := make(map[int]*models.ReconciliationReport)
+    for _, point := range report.Points {
+        year := point.Timestamp.UTC().Year()
+        r, ok := yearly[year]
+        if !ok {
+            r = &models.ReconciliationReport{
+                LastUpdated: report.LastUpdated,
+                Method:      report.Method,
+                Threshold:   report.Threshold,
+                Sources:     report.Sources,
+                Stats:       make(map[string]models.SourceDeviation, len(report.Sources)),
+            }
+            yearly[year] = r
+            years = append(years, year)
+        }
+        r.Points = append(r.Points, point)
+        addStats(r.Stats, point)
+    }
+
+    for _, year := range years {
+        key := fmt.Sprintf("%s/%d/deviations-%d.json", prefix, year, year)
+        if err := j.storage.Save(ctx, key, yearly[year]); err != nil {
+            return fmt.Errorf("failed to save yearly deviations: %w", err)
+        }
+    }
+
+    // Points are sorted, so the last year seen is the newest
+    latest := report
+    latest.Points = nil
+    if n := len(years); n > 0 {
+        latest.Points = yearly[years[n-1]].Points
+    }
+    key := fmt.Sprintf("%s/latest.json", prefix)
+    if err := j.storage.Save(ctx, key, latest); err != nil {
+        return fmt.Errorf("failed to save deviations: %w", err)
+    }
+    return nil
+}
+
+// addStats folds a reconciled point into the per-source statistics
+func addStats(stats map[string]models.SourceDeviation, point models.ReconciledPoint) {
+    for name, dev := range point.Deviations {
+        s := stats[name]
+        abs := math.Abs(dev)
+        s.Points++
+        s.MeanAbsDeviation += (abs - s.MeanAbsDeviation) / float64(s.Points)
+        s.MaxAbsDeviation = math.Max(s.MaxAbsDeviation, abs)
+        stats[name] = s
+    }
+    for _, name := range point.Flagged {
+        s := stats[name]
+        s.Flagged++
+        stats[name] = s
+    }
+}
+
+// reconcile computes the consensus of one bucket and each source's deviation
+func (j *Job) reconcile(t time.Time, values map[string]float64, names []string) models.ReconciledPoint {
+    point := models.ReconciledPoint{
+        Timestamp:  t,
+        Values:     values,
+        Deviations: make(map[string]float64, len(values)),
+    }
+
+    switch j.config.Method {
+    case MethodPriority:
+        for _, name := range names {
+            if v, ok := values[name]; ok {
+                point.Consensus = v
+                break
+            }
+        }
+    default:
+        point.Consensus = median(values)
+    }
+
+    if point.Consensus == 0 {
+        return point
+    }
+    for _, name := range names {
+        v, ok := values[name]
+        if !ok {
+            continue
+        }
+        dev := (v - point.Consensus) / point.Consensus
+        point.Deviations[name] = dev
+        if math.Abs(dev) > j.config.Threshold {
+            point.Flagged = append(point.Flagged, name)
+        }
+    }
+    return point
+}
+
+func median(values map[string]float64) float64 {
+    sorted := make([]float64, 0, len(values))
+    for _, v := range values {
+        sorted = append(sorted, v)
+    }
+    sort.Float64s(sorted)
+
+    n := len(sorted)
+    if n == 0 {
+        return 0
+    }
+    if n%2 == 1 {
+        return sorted[n/2]
+    }
+    return (sorted[n/2-1] + sorted[n/2]) / 2
+} 
\ No newline at end of file
@@ -0,0 +1,75 @@
+package reconcile
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestJobSplitsDeviationsByYear(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+
+    days := []time.Time{
+        time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC),
+        time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+    }
+    values := map[string][]float64{
+        "a": {100, 100, 100},
+        "b": {100, 110, 100},
+    }
+    for name, vs := range values {
+        series := models.MetricSeries{Metric: "close"}
+        for i, v := range vs {
+            series.Data = append(series.Data, models.MetricPoint{Timestamp: days[i], Value: v})
+        }
+        if err := s.Save(ctx, "src/"+name+"/latest.json", series); err != nil {
+            t.Fatalf("Save: %v", err)
+        }
+    }
+
+    job, err := NewJob(s, &Config{
+        Name:     "test",
+        DataPath: "out",
+        Method:   MethodPriority,
+        Sources: []crawler.SeriesSource{
+            {Name: "a", Prefix: "src/a"},
+            {Name: "b", Prefix: "src/b"},
+        },
+    })
+    if err != nil {
+        t.Fatalf("NewJob: %v", err)
+    }
+    if err := job.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    var y2023, y2024, latest models.ReconciliationReport
+    if err := s.Load(ctx, "out/deviations/2023/deviations-2023.json", &y2023); err != nil {
+        t.Fatalf("Load 2023: %v", err)
+    }
+    if err := s.Load(ctx, "out/deviations/2024/deviations-2024.json", &y2024); err != nil {
+        t.Fatalf("Load 2024: %v", err)
+    }
+    if err := s.Load(ctx, "out/deviations/latest.json", &latest); err != nil {
+        t.Fatalf("Load latest: %v", err)
+    }
+
+    if len(y2023.Points) != 2 || y2023.Stats["b"].Points != 2 || y2023.Stats["b"].Flagged != 1 {
+        t.Errorf("unexpected 2023 report: %d points, stats %+v", len(y2023.Points), y2023.Stats["b"])
+    }
+    if len(y2024.Points) != 1 || y2024.Stats["b"].Points != 1 || y2024.Stats["b"].Flagged != 0 {
+        t.Errorf("unexpected 2024 report: %d points, stats %+v", len(y2024.Points), y2024.Stats["b"])
+    }
+    if len(latest.Points) != 1 || !latest.Points[0].Timestamp.Equal(days[2]) {
+        t.Errorf("latest should hold the 2024 points, got %+v", latest.Points)
+    }
+    if latest.Stats["b"].Points != 3 || latest.Stats["b"].Flagged != 1 {
+        t.Errorf("latest should hold the stats over all years, got %+v", latest.Stats["b"])
+    }
+} 
\ No newline at end of file
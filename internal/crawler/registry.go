@@ -0,0 +1,54 @@
+package crawler
+
+import (
+    "fmt"
+    "sync"
+)
+
+// Registry holds the crawlers known to a process, keyed by name
+type Registry struct {
+    mu       sync.RWMutex
+    crawlers map[string]Crawler
+    order    []string
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+    return &Registry{
+        crawlers: make(map[string]Crawler),
+    }
+}
+
+// Register adds a crawler; names must be unique
+func (r *Registry) Register(c Crawler) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.crawlers[c.Name()]; ok {
+        return fmt.Errorf("crawler %s already registered", c.Name())
+    }
+    r.crawlers[c.Name()] = c
+    r.order = append(r.order, c.Name())
+    return nil
+}
+
+// Get returns the crawler registered under name
+func (r *Registry) Get(name string) (Crawler, bool) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    c, ok := r.crawlers[name]
+    return c, ok
+}
+
+// All returns the registered crawlers in registration order
+func (r *Registry) All() []Crawler {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    all := make([]Crawler, 0, len(r.order))
+    for _, name := range r.order {
+        all = append(all, r.crawlers[name])
+    }
+    return all
+} 
\ No newline at end of file
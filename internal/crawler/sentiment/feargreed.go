@@ -0,0 +1,99 @@
+package sentiment
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
+)
+
+const (
+    fearGreedBaseURL = "https://api.alternative.me"
+    fearGreedName    = "fear-greed"
+)
+
+// FearGreedIndex fetches the Alternative.me Crypto Fear & Greed index
+type FearGreedIndex struct {
+    client  *httpclient.Client
+    baseURL string
+}
+
+// NewFearGreedIndex creates a new FearGreedIndex; an empty baseURL uses the
+// Alternative.me API
+func NewFearGreedIndex(client *httpclient.Client, baseURL string) *FearGreedIndex {
+    if baseURL == "" {
+        baseURL = fearGreedBaseURL
+    }
+    return &FearGreedIndex{
+        client:  client,
+        baseURL: baseURL,
+    }
+}
+
+// Name implements Index.Name
+func (f *FearGreedIndex) Name() string {
+    return fearGreedName
+}
+
+// Fetch implements Index.Fetch
+func (f *FearGreedIndex) Fetch(ctx context.Context, since time.Time) ([]models.SentimentValue, error) {
+    // limit=0 returns the full history; otherwise ask for the days we are missing
+    limit := 0
+    if !since.IsZero() {
+        limit = int(time.Since(since).Hours()/24) + 1
+    }
+
+    url := fmt.Sprintf("%s/fng/?limit=%d&format=json", f.baseURL, limit)
+
+    var fngResp models.FearGreedResponse
+    if err := f.client.GetJSON(ctx, url, &fngResp); err != nil {
+        return nil, err
+    }
+    if fngResp.Metadata.Error != nil {
+        return nil, fmt.Errorf("api error: %s", *fngResp.Metadata.Error)
+    }
+
+    // Convert response to our data model
+    values := make([]models.SentimentValue, 0, len(fngResp.Data))
+    for _, d := range fngResp.Data {
+        seconds, err := strconv.ParseInt(d.Timestamp, 10, 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid timestamp %q: %w", d.Timestamp, err)
+        }
+        value, err := strconv.Atoi(d.Value)
+        if err != nil {
+            return nil, fmt.Errorf("invalid value %q: %w", d.Value, err)
+        }
+        values = append(values, models.SentimentValue{
+            Timestamp:      time.Unix(seconds, 0).UTC(),
+            Value:          value,
+            Classification: d.ValueClassification,
+        })
+    }
+
+    return values, validateFearGreed(values)
+}
+
+// validateFearGreed checks the readings: at least one, values between 0 and
+// 100, a classification and no repeated days. The API lists newest first.
+func validateFearGreed(values []models.SentimentValue) error {
+    v := validation.New(fearGreedName)
+    v.NonEmpty("data", len(values))
+
+    seen := make(map[time.Time]bool, len(values))
+    for _, value := range values {
+        if value.Value < 0 || value.Value > 100 {
+            v.Add("value", validation.RuleRange, "value %d is outside 0-100", value.Value)
+        }
+        v.Required("value_classification", value.Classification != "")
+        if seen[value.Timestamp] {
+            v.Add("timestamp", validation.RuleUnique, "timestamp %s is repeated", value.Timestamp.Format(time.RFC3339))
+        }
+        seen[value.Timestamp] = true
+    }
+    return v.Err()
+} 
\ No newline at end of file
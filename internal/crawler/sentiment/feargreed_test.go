@@ -0,0 +1,51 @@
+package sentiment
+
+import (
+    "context"
+    "fmt"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/cassette/cassettetest"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestFearGreedCrawlerReplay(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    index := NewFearGreedIndex(cassettetest.Client(t, "testdata/sentiment-fear-greed.json"), "")
+    c := NewSentimentCrawler(s, index, &Config{DataPath: "sentiment/fear-greed"})
+
+    if err := c.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    var data models.SentimentData
+    if err := s.Load(ctx, "sentiment/fear-greed/latest.json", &data); err != nil {
+        t.Fatal(err)
+    }
+    if len(data.Data) == 0 {
+        t.Fatal("stored no readings")
+    }
+    // The API lists newest first; the series is stored oldest first
+    years := make(map[int]int)
+    for i, v := range data.Data {
+        if i > 0 && !v.Timestamp.After(data.Data[i-1].Timestamp) {
+            t.Fatalf("reading %d at %v does not follow %v", i, v.Timestamp, data.Data[i-1].Timestamp)
+        }
+        if v.Value < 0 || v.Value > 100 || v.Classification == "" {
+            t.Errorf("reading %d = %+v", i, v)
+        }
+        years[v.Timestamp.UTC().Year()]++
+    }
+
+    for year, n := range years {
+        var yearly models.SentimentData
+        if err := s.Load(ctx, fmt.Sprintf("sentiment/fear-greed/%d/fear-greed-%d.json", year, year), &yearly); err != nil {
+            t.Fatalf("load %d: %v", year, err)
+        }
+        if len(yearly.Data) != n {
+            t.Errorf("%d holds %d readings, want %d", year, len(yearly.Data), n)
+        }
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,124 @@
+package sentiment
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/validation"
+)
+
+// Index defines a source of daily sentiment index readings
+type Index interface {
+    // Name returns the index name, used in crawler names and storage keys
+    Name() string
+
+    // Fetch returns readings newer than since, in any order.
+    // A zero since requests the full history.
+    Fetch(ctx context.Context, since time.Time) ([]models.SentimentValue, error)
+}
+
+// Config holds configuration for SentimentCrawler
+type Config struct {
+    DataPath string `yaml:"data_path"`
+    Schedule string `yaml:"schedule"`
+    // BaseURL overrides the index API, e.g. for a local test server
+    BaseURL string `yaml:"base_url"`
+}
+
+// SentimentCrawler crawls a sentiment index incrementally into storage
+type SentimentCrawler struct {
+    *crawler.BaseCrawler
+    storage storage.Storage
+    index   Index
+    config  *Config
+}
+
+// NewSentimentCrawler creates a new SentimentCrawler for the given index
+func NewSentimentCrawler(storage storage.Storage, index Index, config *Config) *SentimentCrawler {
+    return &SentimentCrawler{
+        BaseCrawler: crawler.NewBaseCrawler("sentiment-"+index.Name(), config.Schedule),
+        storage:     storage,
+        index:       index,
+        config:      config,
+    }
+}
+
+// Crawl fetches readings newer than the last stored one and saves the merged series
+func (c *SentimentCrawler) Crawl(ctx context.Context) error {
+    key := fmt.Sprintf("%s/latest.json", c.config.DataPath)
+
+    // Load what we already have to find the last timestamp
+    var existing models.SentimentData
+    if err := c.storage.Load(ctx, key, &existing); err != nil && !errors.Is(err, storage.ErrNotFound) {
+        return fmt.Errorf("failed to load existing data: %w", err)
+    }
+
+    var since time.Time
+    if n := len(existing.Data); n > 0 {
+        since = existing.Data[n-1].Timestamp
+    }
+
+    values, err := c.index.Fetch(ctx, since)
+    if err != nil {
+        validation.RecordDrift(ctx, c.storage, err)
+        return fmt.Errorf("failed to fetch %s: %w", c.index.Name(), err)
+    }
+
+    // Keep only readings we have not stored yet
+    var fresh []models.SentimentValue
+    for _, v := range values {
+        if v.Timestamp.After(since) {
+            fresh = append(fresh, v)
+        }
+    }
+    sort.Slice(fresh, func(i, j int) bool {
+        return fresh[i].Timestamp.Before(fresh[j].Timestamp)
+    })
+
+    if len(fresh) == 0 {
+        crawler.RecordRecords(c.Name(), len(values), 0)
+        c.UpdateLastRun()
+        return nil
+    }
+
+    data := models.SentimentData{
+        LastUpdated: time.Now().UTC(),
+        Index:       c.index.Name(),
+        Data:        append(existing.Data, fresh...),
+    }
+
+    if err := c.storage.Save(ctx, key, data); err != nil {
+        return fmt.Errorf("failed to save data: %w", err)
+    }
+
+    // Rewrite the yearly files touched by the new readings
+    years := make(map[int]bool)
+    for _, v := range fresh {
+        years[v.Timestamp.UTC().Year()] = true
+    }
+    for year := range years {
+        yearly := models.SentimentData{
+            LastUpdated: data.LastUpdated,
+            Index:       data.Index,
+        }
+        for _, v := range data.Data {
+            if v.Timestamp.UTC().Year() == year {
+                yearly.Data = append(yearly.Data, v)
+            }
+        }
+        yearlyKey := fmt.Sprintf("%s/%d/%s-%d.json", c.config.DataPath, year, c.index.Name(), year)
+        if err := c.storage.Save(ctx, yearlyKey, yearly); err != nil {
+            return fmt.Errorf("failed to save yearly data: %w", err)
+        }
+    }
+    crawler.RecordRecords(c.Name(), len(values), len(fresh))
+
+    c.UpdateLastRun()
+    return nil
+} 
\ No newline at end of file
@@ -0,0 +1,146 @@
+package crawler
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Formats of the series read by SeriesSource
+const (
+    FormatSeries  = "series"
+    FormatBitcoin = "bitcoin"
+)
+
+// SeriesSource declares a stored series read by a job, such as the
+// reconciliation or analytics jobs
+type SeriesSource struct {
+    Name string `yaml:"name"`
+    // Prefix is the storage prefix holding latest.json
+    Prefix string `yaml:"prefix"`
+    // Format is series (a metric series) or bitcoin (the Bitcoin price history)
+    Format string `yaml:"format"`
+}
+
+// Validate checks that the source is named and its format known
+func (s SeriesSource) Validate() error {
+    if s.Name == "" || s.Prefix == "" {
+        return fmt.Errorf("name and prefix are required")
+    }
+    switch s.Format {
+    case "", FormatSeries, FormatBitcoin:
+        return nil
+    default:
+        return fmt.Errorf("%s has unknown format %q", s.Name, s.Format)
+    }
+}
+
+// LoadPoints loads the points stored for src, the Bitcoin history giving
+// its prices. It returns no points when nothing has been stored yet.
+func LoadPoints(ctx context.Context, s storage.Storage, src SeriesSource) ([]models.MetricPoint, error) {
+    if src.Format != FormatBitcoin {
+        series, err := LoadSeries(ctx, s, src.Prefix)
+        if err != nil {
+            return nil, err
+        }
+        return series.Data, nil
+    }
+
+    var data models.BitcoinDailyData
+    key := fmt.Sprintf("%s/latest.json", src.Prefix)
+    if err := s.Load(ctx, key, &data); err != nil && !errors.Is(err, storage.ErrNotFound) {
+        return nil, fmt.Errorf("failed to load existing data: %w", err)
+    }
+    points := make([]models.MetricPoint, 0, len(data.Data))
+    for _, p := range data.Data {
+        points = append(points, models.MetricPoint{Timestamp: p.Timestamp, Value: p.Price})
+    }
+    return points, nil
+}
+
+// LoadSeries loads the series stored under prefix, returning an empty series
+// when nothing has been stored yet
+func LoadSeries(ctx context.Context, s storage.Storage, prefix string) (models.MetricSeries, error) {
+    var series models.MetricSeries
+    key := fmt.Sprintf("%s/latest.json", prefix)
+    if err := s.Load(ctx, key, &series); err != nil && !errors.Is(err, storage.ErrNotFound) {
+        return series, fmt.Errorf("failed to load existing data: %w", err)
+    }
+    return series, nil
+}
+
+// FreshPoints returns the points newer than the last stored one, sorted by time
+func FreshPoints(existing models.MetricSeries, points []models.MetricPoint) []models.MetricPoint {
+    var since time.Time
+    if n := len(existing.Data); n > 0 {
+        since = existing.Data[n-1].Timestamp
+    }
+
+    var fresh []models.MetricPoint
+    for _, p := range points {
+        if p.Timestamp.After(since) {
+            fresh = append(fresh, p)
+        }
+    }
+    sort.Slice(fresh, func(i, j int) bool {
+        return fresh[i].Timestamp.Before(fresh[j].Timestamp)
+    })
+    return fresh
+}
+
+// AppendSeries appends the points newer than the last stored one to the series
+// under prefix. It rewrites <prefix>/latest.json and the yearly files
+// <prefix>/<year>/<metric>-<year>.json touched by the new points, and returns
+// how many points were appended.
+func AppendSeries(ctx context.Context, s storage.Storage, prefix string, existing models.MetricSeries, points []models.MetricPoint) (int, error) {
+    fresh := FreshPoints(existing, points)
+    if len(fresh) == 0 {
+        return 0, nil
+    }
+
+    data := existing
+    data.LastUpdated = time.Now().UTC()
+    data.Data = append(existing.Data, fresh...)
+
+    key := fmt.Sprintf("%s/latest.json", prefix)
+    if err := s.Save(ctx, key, data); err != nil {
+        return 0, fmt.Errorf("failed to save data: %w", err)
+    }
+
+    // Rewrite the yearly files touched by the new points
+    if err := SaveYearly(ctx, s, prefix, data, fresh); err != nil {
+        return 0, err
+    }
+    return len(fresh), nil
+}
+
+// SaveYearly rewrites the yearly files of series under prefix for the years
+// of points, leaving an empty file for a year the series no longer covers
+func SaveYearly(ctx context.Context, s storage.Storage, prefix string, data models.MetricSeries, points []models.MetricPoint) error {
+    years := make(map[int]bool)
+    for _, p := range points {
+        years[p.Timestamp.UTC().Year()] = true
+    }
+    for year := range years {
+        yearly := models.MetricSeries{
+            LastUpdated: data.LastUpdated,
+            Metric:      data.Metric,
+            Unit:        data.Unit,
+        }
+        for _, p := range data.Data {
+            if p.Timestamp.UTC().Year() == year {
+                yearly.Data = append(yearly.Data, p)
+            }
+        }
+        yearlyKey := fmt.Sprintf("%s/%d/%s-%d.json", prefix, year, data.Metric, year)
+        if err := s.Save(ctx, yearlyKey, yearly); err != nil {
+            return fmt.Errorf("failed to save yearly data: %w", err)
+        }
+    }
+    return nil
+} 
\ No newline at end of file
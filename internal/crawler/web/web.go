@@ -0,0 +1,75 @@
+package web
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/scraper"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Config holds configuration for WebCrawler
+type Config struct {
+    DataPath string             `yaml:"data_path"`
+    Schedule string             `yaml:"schedule"`
+    Site     scraper.SiteConfig `yaml:"site"`
+}
+
+// WebCrawler crawls an HTML site without an API using a declarative scraper
+type WebCrawler struct {
+    *crawler.BaseCrawler
+    storage storage.Storage
+    scraper *scraper.Scraper
+    config  *Config
+}
+
+// NewWebCrawler creates a new WebCrawler instance
+func NewWebCrawler(storage storage.Storage, client *httpclient.Client, config *Config) (*WebCrawler, error) {
+    s, err := scraper.NewScraper(client, &config.Site)
+    if err != nil {
+        return nil, fmt.Errorf("invalid site config: %w", err)
+    }
+
+    return &WebCrawler{
+        BaseCrawler: crawler.NewBaseCrawler("web-"+config.Site.Name, config.Schedule),
+        storage:     storage,
+        scraper:     s,
+        config:      config,
+    }, nil
+}
+
+// Crawl scrapes the site and stores the extracted records
+func (c *WebCrawler) Crawl(ctx context.Context) error {
+    records, err := c.scraper.Scrape(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to scrape %s: %w", c.config.Site.Name, err)
+    }
+
+    now := time.Now().UTC()
+    data := models.ScrapedData{
+        LastUpdated: now,
+        Site:        c.config.Site.Name,
+        Records:     records,
+    }
+
+    // Save to storage
+    key := fmt.Sprintf("%s/latest.json", c.config.DataPath)
+    if err := c.storage.Save(ctx, key, data); err != nil {
+        return fmt.Errorf("failed to save data: %w", err)
+    }
+
+    // Pages change over time, so keep a dated snapshot as well
+    snapshotKey := fmt.Sprintf("%s/%s/%s-%s.json",
+        c.config.DataPath, now.Format("2006"), c.config.Site.Name, now.Format("2006-01-02"))
+    if err := c.storage.Save(ctx, snapshotKey, data); err != nil {
+        return fmt.Errorf("failed to save snapshot: %w", err)
+    }
+    crawler.RecordRecords(c.Name(), len(records), len(records))
+
+    c.UpdateLastRun()
+    return nil
+} 
\ No newline at end of file
@@ -0,0 +1,60 @@
+package web
+
+import (
+    "context"
+    "math"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/cassette/cassettetest"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/scraper"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestWebCrawlerReplay(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    c, err := NewWebCrawler(s, cassettetest.Client(t, "testdata/web-bitcoin-forks.json"), &Config{
+        DataPath: "web/bitcoin-forks",
+        Site: scraper.SiteConfig{
+            Name:      "bitcoin-forks",
+            StartURLs: []string{"https://en.wikipedia.org/wiki/List_of_bitcoin_forks"},
+            // The cassette holds the page only; robots.txt is tested with the scraper
+            IgnoreRobots: true,
+            Record: scraper.RecordConfig{
+                Selector: "table.wikitable tr:has(td)",
+                Fields: []scraper.FieldConfig{
+                    {Name: "name", Selector: "td:nth-child(1)", Required: true},
+                    {Name: "ticker", Selector: "td:nth-child(2)"},
+                    {Name: "height", Selector: "td:nth-child(4)", Type: scraper.TypeInt},
+                },
+            },
+        },
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := c.Crawl(ctx); err != nil {
+        t.Fatalf("Crawl: %v", err)
+    }
+
+    var data models.ScrapedData
+    if err := s.Load(ctx, "web/bitcoin-forks/latest.json", &data); err != nil {
+        t.Fatal(err)
+    }
+    if len(data.Records) == 0 {
+        t.Fatal("stored no records")
+    }
+    // Every row has a name, and a block height where the table gives one
+    for _, r := range data.Records {
+        if name, _ := r.Fields["name"].(string); name == "" {
+            t.Errorf("record without a name: %+v", r.Fields)
+        }
+        if height, ok := r.Fields["height"]; ok && height != nil {
+            if h, ok := height.(float64); !ok || h != math.Trunc(h) {
+                t.Errorf("height %v of %v is not an integer", height, r.Fields["name"])
+            }
+        }
+    }
+} 
\ No newline at end of file
@@ -5,15 +5,26 @@ import (
     "fmt"
     "time"
 
+    "go.mongodb.org/mongo-driver/bson"
     "go.mongodb.org/mongo-driver/mongo"
     "go.mongodb.org/mongo-driver/mongo/options"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
     "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
 )
 
 // Database defines the interface for database operations
 type Database interface {
     // SaveBitcoinPrices saves bitcoin price data
     SaveBitcoinPrices(ctx context.Context, data models.BitcoinDailyData) error
+    // SaveOrderBookSnapshot saves an order book snapshot
+    SaveOrderBookSnapshot(ctx context.Context, snapshot models.OrderBookSnapshot) error
+    // SaveBar saves a bar aggregated from streamed trades
+    SaveBar(ctx context.Context, bar models.Bar) error
+    // SaveDriftEvent saves an upstream response that failed validation
+    SaveDriftEvent(ctx context.Context, event models.DriftEvent) error
     // Close closes the database connection
     Close(ctx context.Context) error
 }
@@ -53,6 +64,12 @@ func NewMongoDB(cfg Config) (*MongoDB, error) {
 
 // SaveBitcoinPrices implements Database.SaveBitcoinPrices
 func (m *MongoDB) SaveBitcoinPrices(ctx context.Context, data models.BitcoinDailyData) error {
+    return observe(ctx, "SaveBitcoinPrices", "save_bitcoin_prices", "bitcoin_prices", func(ctx context.Context) error {
+        return m.saveBitcoinPrices(ctx, data)
+    })
+}
+
+func (m *MongoDB) saveBitcoinPrices(ctx context.Context, data models.BitcoinDailyData) error {
     collection := m.client.Database(m.database).Collection("bitcoin_prices")
     
     _, err := collection.InsertOne(ctx, data)
@@ -63,6 +80,85 @@ func (m *MongoDB) SaveBitcoinPrices(ctx context.Context, data models.BitcoinDail
     return nil
 }
 
+// SaveOrderBookSnapshot implements Database.SaveOrderBookSnapshot
+func (m *MongoDB) SaveOrderBookSnapshot(ctx context.Context, snapshot models.OrderBookSnapshot) error {
+    return observe(ctx, "SaveOrderBookSnapshot", "save_order_book_snapshot", "order_book_snapshots", func(ctx context.Context) error {
+        return m.saveOrderBookSnapshot(ctx, snapshot)
+    })
+}
+
+func (m *MongoDB) saveOrderBookSnapshot(ctx context.Context, snapshot models.OrderBookSnapshot) error {
+    collection := m.client.Database(m.database).Collection("order_book_snapshots")
+
+    _, err := collection.InsertOne(ctx, snapshot)
+    if err != nil {
+        return fmt.Errorf("failed to insert order book snapshot: %w", err)
+    }
+
+    return nil
+}
+
+// SaveBar implements Database.SaveBar. Bars are keyed by exchange, symbol,
+// interval and start, so a redelivered message does not create a duplicate.
+func (m *MongoDB) SaveBar(ctx context.Context, bar models.Bar) error {
+    return observe(ctx, "SaveBar", "save_bar", "bars", func(ctx context.Context) error {
+        return m.saveBar(ctx, bar)
+    })
+}
+
+func (m *MongoDB) saveBar(ctx context.Context, bar models.Bar) error {
+    collection := m.client.Database(m.database).Collection("bars")
+
+    filter := bson.M{
+        "exchange": bar.Exchange,
+        "symbol":   bar.Symbol,
+        "source":   bar.Source,
+        "interval": bar.Interval,
+        "start":    bar.Start,
+    }
+    opts := options.Replace().SetUpsert(true)
+    _, err := collection.ReplaceOne(ctx, filter, bar, opts)
+    if err != nil {
+        return fmt.Errorf("failed to save bar: %w", err)
+    }
+
+    return nil
+}
+
+// SaveDriftEvent implements Database.SaveDriftEvent
+func (m *MongoDB) SaveDriftEvent(ctx context.Context, event models.DriftEvent) error {
+    return observe(ctx, "SaveDriftEvent", "save_drift_event", "drift_events", func(ctx context.Context) error {
+        return m.saveDriftEvent(ctx, event)
+    })
+}
+
+func (m *MongoDB) saveDriftEvent(ctx context.Context, event models.DriftEvent) error {
+    collection := m.client.Database(m.database).Collection("drift_events")
+
+    _, err := collection.InsertOne(ctx, event)
+    if err != nil {
+        return fmt.Errorf("failed to insert drift event: %w", err)
+    }
+
+    return nil
+}
+
+// observe runs fn in a span named name, recording its latency as operation
+// on collection
+func observe(ctx context.Context, name, operation, collection string, fn func(context.Context) error) error {
+    ctx, span := tracing.Tracer().Start(ctx, name,
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithAttributes(
+            attribute.String("db.system", "mongodb"),
+            attribute.String("db.operation", operation),
+            attribute.String("db.mongodb.collection", collection),
+        ))
+    start := time.Now()
+    err := fn(ctx)
+    tracing.End(span, err)
+    return storage.ObserveOperation("database", operation, start, err)
+}
+
 // Close implements Database.Close
 func (m *MongoDB) Close(ctx context.Context) error {
     if err := m.client.Disconnect(ctx); err != nil {
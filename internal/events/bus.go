@@ -0,0 +1,147 @@
+package events
+
+import (
+    "log"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Bus fans out PointsEvents to subscribers within the process. Publishing
+// never blocks: a subscriber whose buffer is full misses the event. The
+// latest events are kept so that reconnecting subscribers can catch up.
+type Bus struct {
+    mu     sync.Mutex
+    subs   map[*Subscription]struct{}
+    buffer int
+    nextID uint64
+
+    // history is a ring of the last published events, oldest at start
+    history []models.PointsEvent
+    start   int
+    count   int
+}
+
+// NewBus creates a new Bus whose subscribers buffer up to buffer events and
+// which keeps the last replay events for SubscribeSince
+func NewBus(buffer, replay int) *Bus {
+    if buffer <= 0 {
+        buffer = 64
+    }
+    if replay <= 0 {
+        replay = 256
+    }
+    return &Bus{
+        subs:    make(map[*Subscription]struct{}),
+        buffer:  buffer,
+        history: make([]models.PointsEvent, replay),
+    }
+}
+
+// Subscription receives the events of one asset, or of every asset when
+// the asset is empty
+type Subscription struct {
+    C       <-chan models.PointsEvent
+    ch      chan models.PointsEvent
+    asset   string
+    bus     *Bus
+    once    sync.Once
+    dropped int64
+}
+
+// Subscribe registers a subscription; Close must be called when done
+func (b *Bus) Subscribe(asset string) *Subscription {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    return b.subscribe(asset)
+}
+
+// SubscribeSince registers a subscription and returns the kept events of
+// the asset published after the event lastID, which the subscription does
+// not receive. An id newer than every event was given out before a restart,
+// so every kept event is returned. Events older than the kept ones are lost.
+func (b *Bus) SubscribeSince(asset string, lastID uint64) (*Subscription, []models.PointsEvent) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    if lastID > b.nextID {
+        lastID = 0
+    }
+    var missed []models.PointsEvent
+    for i := 0; i < b.count; i++ {
+        event := b.history[(b.start+i)%len(b.history)]
+        if event.ID > lastID && matches(asset, event) {
+            missed = append(missed, event)
+        }
+    }
+    return b.subscribe(asset), missed
+}
+
+// subscribe registers a subscription. The caller must hold b.mu.
+func (b *Bus) subscribe(asset string) *Subscription {
+    ch := make(chan models.PointsEvent, b.buffer)
+    sub := &Subscription{
+        C:     ch,
+        ch:    ch,
+        asset: asset,
+        bus:   b,
+    }
+    b.subs[sub] = struct{}{}
+    return sub
+}
+
+// matches reports whether a subscription to asset receives event
+func matches(asset string, event models.PointsEvent) bool {
+    return asset == "" || strings.EqualFold(asset, event.Asset)
+}
+
+// Publish stamps the event with an id and the current time when unset and
+// hands it to the matching subscribers
+func (b *Bus) Publish(event models.PointsEvent) {
+    if event.StoredAt.IsZero() {
+        event.StoredAt = time.Now().UTC()
+    }
+
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    b.nextID++
+    event.ID = b.nextID
+    if b.count < len(b.history) {
+        b.history[(b.start+b.count)%len(b.history)] = event
+        b.count++
+    } else {
+        b.history[b.start] = event
+        b.start = (b.start + 1) % len(b.history)
+    }
+
+    for sub := range b.subs {
+        if !matches(sub.asset, event) {
+            continue
+        }
+        select {
+        case sub.ch <- event:
+        default:
+            if n := atomic.AddInt64(&sub.dropped, 1); n == 1 || n%100 == 0 {
+                log.Printf("Event subscriber for %q is falling behind, %d events dropped", sub.asset, n)
+            }
+        }
+    }
+}
+
+// Dropped returns how many events the subscription missed
+func (s *Subscription) Dropped() int64 {
+    return atomic.LoadInt64(&s.dropped)
+}
+
+// Close unregisters the subscription and closes its channel
+func (s *Subscription) Close() {
+    s.once.Do(func() {
+        s.bus.mu.Lock()
+        delete(s.bus.subs, s)
+        s.bus.mu.Unlock()
+        close(s.ch)
+    })
+} 
\ No newline at end of file
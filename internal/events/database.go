@@ -0,0 +1,92 @@
+package events
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// BitcoinAsset is the asset name of the prices saved by SaveBitcoinPrices
+const BitcoinAsset = "bitcoin"
+
+// Database wraps a database.Database and publishes the points it saves
+type Database struct {
+    database.Database
+    bus *Bus
+
+    mu sync.Mutex
+    // lastPrice is the newest price published so far
+    lastPrice time.Time
+}
+
+// NewDatabase creates a new Database publishing to bus
+func NewDatabase(db database.Database, bus *Bus) *Database {
+    return &Database{
+        Database: db,
+        bus:      bus,
+    }
+}
+
+// SaveBitcoinPrices implements database.Database.SaveBitcoinPrices. Every
+// batch holds the full history, so only the prices newer than those of the
+// previous batch are published; the first batch publishes its latest price.
+func (d *Database) SaveBitcoinPrices(ctx context.Context, data models.BitcoinDailyData) error {
+    if err := d.Database.SaveBitcoinPrices(ctx, data); err != nil {
+        return err
+    }
+
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    var fresh []models.BitcoinPrice
+    newest := d.lastPrice
+    for _, p := range data.Data {
+        if p.Timestamp.After(d.lastPrice) {
+            fresh = append(fresh, p)
+        }
+        if p.Timestamp.After(newest) {
+            newest = p.Timestamp
+        }
+    }
+    if d.lastPrice.IsZero() && len(fresh) > 1 {
+        latest := fresh[0]
+        for _, p := range fresh {
+            if p.Timestamp.After(latest.Timestamp) {
+                latest = p
+            }
+        }
+        fresh = []models.BitcoinPrice{latest}
+    }
+    d.lastPrice = newest
+
+    if len(fresh) > 0 {
+        d.bus.Publish(models.PointsEvent{
+            Asset:  BitcoinAsset,
+            Kind:   models.EventKindPrice,
+            Prices: fresh,
+        })
+    }
+    return nil
+}
+
+// SaveBar implements database.Database.SaveBar
+func (d *Database) SaveBar(ctx context.Context, bar models.Bar) error {
+    if err := d.Database.SaveBar(ctx, bar); err != nil {
+        return err
+    }
+    d.bus.Publish(models.PointsEvent{
+        Asset: BarAsset(bar.Exchange, bar.Symbol),
+        Kind:  models.EventKindBar,
+        Bars:  []models.Bar{bar},
+    })
+    return nil
+}
+
+// BarAsset is the asset name of the bars of a market, e.g. binance:BTCUSDT
+func BarAsset(exchange, symbol string) string {
+    return fmt.Sprintf("%s:%s", strings.ToLower(exchange), symbol)
+} 
\ No newline at end of file
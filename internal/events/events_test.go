@@ -0,0 +1,187 @@
+package events
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/gorilla/websocket"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+func TestBusFiltersByAsset(t *testing.T) {
+    bus := NewBus(1, 0)
+    btc := bus.Subscribe("bitcoin")
+    defer btc.Close()
+    all := bus.Subscribe("")
+    defer all.Close()
+
+    bus.Publish(models.PointsEvent{Asset: "Bitcoin", Kind: models.EventKindPrice})
+    bus.Publish(models.PointsEvent{Asset: "binance:BTCUSDT", Kind: models.EventKindBar})
+
+    if e := <-btc.C; e.ID != 1 || e.StoredAt.IsZero() {
+        t.Errorf("bitcoin subscriber got %+v", e)
+    }
+    if len(btc.C) != 0 {
+        t.Errorf("bitcoin subscriber got a bar event")
+    }
+    // The buffer of one holds the first event, the second is dropped
+    if e := <-all.C; e.ID != 1 || all.Dropped() != 1 {
+        t.Errorf("subscriber to every asset got %+v and dropped %d", e, all.Dropped())
+    }
+}
+
+func TestBusSubscribeSince(t *testing.T) {
+    bus := NewBus(0, 3)
+    for i := 0; i < 5; i++ {
+        bus.Publish(models.PointsEvent{Asset: "bitcoin", Kind: models.EventKindPrice})
+    }
+
+    ids := func(events []models.PointsEvent) []uint64 {
+        var ids []uint64
+        for _, e := range events {
+            ids = append(ids, e.ID)
+        }
+        return ids
+    }
+    tests := []struct {
+        asset  string
+        lastID uint64
+        want   string
+    }{
+        {"bitcoin", 3, "[4 5]"},
+        // Only the last three events are kept
+        {"bitcoin", 1, "[3 4 5]"},
+        {"bitcoin", 5, "[]"},
+        {"ethereum", 1, "[]"},
+        // An id from before a restart replays every kept event
+        {"bitcoin", 99, "[3 4 5]"},
+    }
+    for _, tt := range tests {
+        sub, missed := bus.SubscribeSince(tt.asset, tt.lastID)
+        sub.Close()
+        if got := fmt.Sprint(ids(missed)); got != tt.want {
+            t.Errorf("SubscribeSince(%q, %d) = %v, want %s", tt.asset, tt.lastID, ids(missed), tt.want)
+        }
+    }
+}
+
+// readEvents reads n server-sent events, skipping comments and the retry
+// field
+func readEvents(t *testing.T, r *bufio.Reader, n int) []map[string]string {
+    var events []map[string]string
+    event := map[string]string{}
+    for len(events) < n {
+        line, err := r.ReadString('\n')
+        if err != nil {
+            t.Fatalf("read: %v after %d events", err, len(events))
+        }
+        line = strings.TrimSuffix(line, "\n")
+        switch {
+        case line == "":
+            if len(event) > 0 {
+                events = append(events, event)
+                event = map[string]string{}
+            }
+        case strings.HasPrefix(line, ":"), strings.HasPrefix(line, "retry:"):
+        default:
+            field, value, _ := strings.Cut(line, ": ")
+            event[field] = value
+        }
+    }
+    return events
+}
+
+func TestSSEReplaysMissedEvents(t *testing.T) {
+    bus := NewBus(0, 0)
+    server := httptest.NewServer(NewPushServer(bus, Config{}).Handler())
+    defer server.Close()
+
+    bus.Publish(models.PointsEvent{Asset: "bitcoin", Kind: models.EventKindPrice})
+    bus.Publish(models.PointsEvent{Asset: "binance:BTCUSDT", Kind: models.EventKindBar})
+    bus.Publish(models.PointsEvent{Asset: "bitcoin", Kind: models.EventKindPrice})
+
+    req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/events/bitcoin", nil)
+    req.Header.Set("Last-Event-ID", "1")
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer resp.Body.Close()
+    if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+        t.Fatalf("Content-Type = %q", ct)
+    }
+    r := bufio.NewReader(resp.Body)
+
+    // The missed event comes first, then the live ones
+    replayed := readEvents(t, r, 1)
+    bus.Publish(models.PointsEvent{Asset: "bitcoin", Kind: models.EventKindPrice})
+    live := readEvents(t, r, 1)
+
+    for i, e := range append(replayed, live...) {
+        want := []string{"3", "4"}[i]
+        var data models.PointsEvent
+        json.Unmarshal([]byte(e["data"]), &data)
+        if e["id"] != want || e["event"] != models.EventKindPrice || data.Asset != "bitcoin" {
+            t.Errorf("event %d = %v, want id %s", i, e, want)
+        }
+    }
+
+    req.Header.Set("Last-Event-ID", "latest")
+    resp, err = http.DefaultClient.Do(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp.Body.Close()
+    if resp.StatusCode != http.StatusBadRequest {
+        t.Errorf("invalid Last-Event-ID: status %d", resp.StatusCode)
+    }
+}
+
+func TestWebSocketStreamsEvents(t *testing.T) {
+    bus := NewBus(0, 0)
+    server := httptest.NewServer(NewPushServer(bus, Config{Heartbeat: time.Second}).Handler())
+    defer server.Close()
+    wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/v1/ws/binance:BTCUSDT"
+
+    // Pages of other origins are refused
+    header := http.Header{"Origin": {"https://evil.example.com"}}
+    if _, resp, err := websocket.DefaultDialer.Dial(wsURL, header); err == nil || resp.StatusCode != http.StatusForbidden {
+        t.Errorf("cross-origin dial: %v", err)
+    }
+
+    conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer conn.Close()
+
+    // The subscription is registered once the handler runs
+    deadline := time.Now().Add(2 * time.Second)
+    for {
+        bus.mu.Lock()
+        n := len(bus.subs)
+        bus.mu.Unlock()
+        if n == 1 || time.Now().After(deadline) {
+            break
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    bus.Publish(models.PointsEvent{Asset: "bitcoin", Kind: models.EventKindPrice})
+    bus.Publish(models.PointsEvent{Asset: "binance:BTCUSDT", Kind: models.EventKindBar, Bars: []models.Bar{{Close: 100}}})
+
+    conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+    var event models.PointsEvent
+    if err := conn.ReadJSON(&event); err != nil {
+        t.Fatal(err)
+    }
+    if event.ID != 2 || event.Kind != models.EventKindBar || len(event.Bars) != 1 || event.Bars[0].Close != 100 {
+        t.Errorf("event = %+v", event)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,247 @@
+package events
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "net/http"
+    "net/url"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gorilla/websocket"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+const (
+    defaultPushHeartbeat = 15 * time.Second
+    writeTimeout         = 10 * time.Second
+)
+
+// Config holds configuration for the push server
+type Config struct {
+    // Addr enables the push server when set, e.g. :8081
+    Addr string `yaml:"addr"`
+    // Buffer is the number of events queued per subscriber, defaults to 64
+    Buffer int `yaml:"buffer"`
+    // Replay is the number of recent events kept for server-sent event
+    // clients reconnecting with Last-Event-ID, defaults to 256
+    Replay int `yaml:"replay"`
+    // Heartbeat is how often idle connections are pinged, defaults to 15s
+    Heartbeat time.Duration `yaml:"heartbeat"`
+    // AllowedOrigins lists the origins allowed to open WebSockets, "*" allows
+    // any; by default only same-origin pages may connect
+    AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+// PushServer streams the events of a Bus over server-sent events at
+// /v1/events/{asset} and WebSockets at /v1/ws/{asset}. Leaving out the asset
+// streams every asset.
+type PushServer struct {
+    bus      *Bus
+    config   Config
+    upgrader websocket.Upgrader
+}
+
+// NewPushServer creates a new PushServer
+func NewPushServer(bus *Bus, config Config) *PushServer {
+    if config.Heartbeat <= 0 {
+        config.Heartbeat = defaultPushHeartbeat
+    }
+    p := &PushServer{
+        bus:    bus,
+        config: config,
+    }
+    p.upgrader = websocket.Upgrader{
+        HandshakeTimeout: writeTimeout,
+        CheckOrigin:      p.checkOrigin,
+    }
+    return p
+}
+
+// Handler returns the HTTP handler serving the push endpoints
+func (p *PushServer) Handler() http.Handler {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/v1/events", p.handleSSE)
+    mux.HandleFunc("/v1/events/", p.handleSSE)
+    mux.HandleFunc("/v1/ws", p.handleWebSocket)
+    mux.HandleFunc("/v1/ws/", p.handleWebSocket)
+    return mux
+}
+
+// ListenAndServe serves the push endpoints on the configured address until
+// ctx is done
+func (p *PushServer) ListenAndServe(ctx context.Context) error {
+    srv := &http.Server{
+        Addr:              p.config.Addr,
+        Handler:           p.Handler(),
+        ReadHeaderTimeout: 10 * time.Second,
+    }
+
+    errChan := make(chan error, 1)
+    go func() {
+        errChan <- srv.ListenAndServe()
+    }()
+
+    select {
+    case err := <-errChan:
+        return fmt.Errorf("failed to serve push endpoints: %w", err)
+    case <-ctx.Done():
+    }
+
+    // Streams never finish on their own, so Shutdown would wait for the timeout
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+    if err := srv.Shutdown(shutdownCtx); err != nil {
+        srv.Close()
+    }
+    return nil
+}
+
+// asset returns the asset named after prefix in the path, empty for all
+func asset(r *http.Request, prefix string) string {
+    return strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+}
+
+func (p *PushServer) handleSSE(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodGet {
+        w.Header().Set("Allow", "GET")
+        http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+        return
+    }
+
+    // A reconnecting EventSource sends the id of the last event it got
+    var sub *Subscription
+    var missed []models.PointsEvent
+    if v := r.Header.Get("Last-Event-ID"); v != "" {
+        lastID, err := strconv.ParseUint(v, 10, 64)
+        if err != nil {
+            http.Error(w, "invalid Last-Event-ID", http.StatusBadRequest)
+            return
+        }
+        sub, missed = p.bus.SubscribeSince(asset(r, "/v1/events"), lastID)
+    } else {
+        sub = p.bus.Subscribe(asset(r, "/v1/events"))
+    }
+    defer sub.Close()
+
+    h := w.Header()
+    h.Set("Content-Type", "text/event-stream")
+    h.Set("Cache-Control", "no-cache")
+    h.Set("Connection", "keep-alive")
+    h.Set("X-Accel-Buffering", "no")
+    w.WriteHeader(http.StatusOK)
+    // Ask clients to wait a few seconds before reconnecting
+    fmt.Fprintf(w, "retry: 5000\n\n")
+    for _, event := range missed {
+        if err := writeSSE(w, event); err != nil {
+            return
+        }
+    }
+    flusher.Flush()
+
+    ticker := time.NewTicker(p.config.Heartbeat)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-r.Context().Done():
+            return
+        case <-ticker.C:
+            if _, err := fmt.Fprintf(w, ": ping\n\n"); err != nil {
+                return
+            }
+        case event := <-sub.C:
+            if err := writeSSE(w, event); err != nil {
+                return
+            }
+        }
+        flusher.Flush()
+    }
+}
+
+// writeSSE writes event as a server-sent event. An event that cannot be
+// marshalled is logged and skipped.
+func writeSSE(w http.ResponseWriter, event models.PointsEvent) error {
+    data, err := json.Marshal(event)
+    if err != nil {
+        log.Printf("Failed to marshal event: %v", err)
+        return nil
+    }
+    _, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, data)
+    return err
+}
+
+func (p *PushServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+    conn, err := p.upgrader.Upgrade(w, r, nil)
+    if err != nil {
+        // The upgrader has already replied
+        return
+    }
+    defer conn.Close()
+
+    sub := p.bus.Subscribe(asset(r, "/v1/ws"))
+    defer sub.Close()
+
+    // Clients only send control frames; reading handles pongs and notices
+    // the connection closing
+    closed := make(chan struct{})
+    deadline := 2 * p.config.Heartbeat
+    conn.SetReadDeadline(time.Now().Add(deadline))
+    conn.SetPongHandler(func(string) error {
+        return conn.SetReadDeadline(time.Now().Add(deadline))
+    })
+    go func() {
+        defer close(closed)
+        for {
+            if _, _, err := conn.ReadMessage(); err != nil {
+                return
+            }
+        }
+    }()
+
+    ticker := time.NewTicker(p.config.Heartbeat)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-closed:
+            return
+        case <-r.Context().Done():
+            conn.WriteControl(websocket.CloseMessage,
+                websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(time.Second))
+            return
+        case <-ticker.C:
+            if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+                return
+            }
+        case event := <-sub.C:
+            conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+            if err := conn.WriteJSON(event); err != nil {
+                return
+            }
+        }
+    }
+}
+
+// checkOrigin allows same-origin requests, requests without an Origin and
+// the configured origins
+func (p *PushServer) checkOrigin(r *http.Request) bool {
+    origin := r.Header.Get("Origin")
+    if origin == "" {
+        return true
+    }
+    for _, allowed := range p.config.AllowedOrigins {
+        if allowed == "*" || strings.EqualFold(allowed, origin) {
+            return true
+        }
+    }
+    u, err := url.Parse(origin)
+    return err == nil && strings.EqualFold(u.Host, r.Host)
+} 
\ No newline at end of file
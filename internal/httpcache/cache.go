@@ -0,0 +1,163 @@
+package httpcache
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "os"
+    "path/filepath"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Cache backends supported by Config.Backend
+const (
+    BackendDir     = "dir"
+    BackendStorage = "storage"
+)
+
+// Entry is a cached response together with its validators
+type Entry struct {
+    URL          string      `json:"url" bson:"url"`
+    ETag         string      `json:"etag" bson:"etag"`
+    LastModified string      `json:"last_modified" bson:"last_modified"`
+    Header       http.Header `json:"header" bson:"header"`
+    Body         []byte      `json:"body" bson:"body"`
+    StoredAt     time.Time   `json:"stored_at" bson:"stored_at"`
+}
+
+// Cache stores response entries by key. Get returns nil when nothing is cached.
+type Cache interface {
+    Get(ctx context.Context, key string) (*Entry, error)
+    Set(ctx context.Context, key string, entry *Entry) error
+}
+
+// Config holds configuration for the response cache
+type Config struct {
+    // Backend is dir or storage; empty disables caching
+    Backend string `yaml:"backend"`
+    // Dir is the cache directory of the dir backend
+    Dir string `yaml:"dir"`
+}
+
+// New creates the configured cache, or returns nil when caching is disabled.
+// The storage backend keeps entries in s.
+func New(cfg Config, s storage.Storage) (Cache, error) {
+    switch cfg.Backend {
+    case "":
+        return nil, nil
+    case BackendDir:
+        if cfg.Dir == "" {
+            return nil, fmt.Errorf("http cache: dir is required")
+        }
+        return NewDirCache(cfg.Dir)
+    case BackendStorage:
+        return NewStorageCache(s), nil
+    default:
+        return nil, fmt.Errorf("http cache: unknown backend %q", cfg.Backend)
+    }
+}
+
+// DirCache keeps entries as JSON files in a local directory
+type DirCache struct {
+    dir string
+}
+
+// NewDirCache creates a new DirCache, creating dir if needed
+func NewDirCache(dir string) (*DirCache, error) {
+    if err := os.MkdirAll(dir, 0o755); err != nil {
+        return nil, fmt.Errorf("failed to create cache directory: %w", err)
+    }
+    return &DirCache{dir: dir}, nil
+}
+
+// Get implements Cache.Get
+func (c *DirCache) Get(ctx context.Context, key string) (*Entry, error) {
+    data, err := os.ReadFile(c.path(key))
+    if err != nil {
+        if errors.Is(err, os.ErrNotExist) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to read cache entry: %w", err)
+    }
+
+    var entry Entry
+    if err := json.Unmarshal(data, &entry); err != nil {
+        return nil, fmt.Errorf("failed to decode cache entry: %w", err)
+    }
+    return &entry, nil
+}
+
+// Set implements Cache.Set. Entries are written to a temporary file first
+// so readers never see a partial entry.
+func (c *DirCache) Set(ctx context.Context, key string, entry *Entry) error {
+    data, err := json.Marshal(entry)
+    if err != nil {
+        return fmt.Errorf("failed to encode cache entry: %w", err)
+    }
+
+    tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+    if err != nil {
+        return fmt.Errorf("failed to write cache entry: %w", err)
+    }
+    defer os.Remove(tmp.Name())
+
+    if _, err := tmp.Write(data); err != nil {
+        tmp.Close()
+        return fmt.Errorf("failed to write cache entry: %w", err)
+    }
+    if err := tmp.Close(); err != nil {
+        return fmt.Errorf("failed to write cache entry: %w", err)
+    }
+    if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+        return fmt.Errorf("failed to write cache entry: %w", err)
+    }
+    return nil
+}
+
+func (c *DirCache) path(key string) string {
+    return filepath.Join(c.dir, key+".json")
+}
+
+// StorageCache keeps entries in a storage.Storage under httpcache/<key>
+type StorageCache struct {
+    storage storage.Storage
+}
+
+// NewStorageCache creates a new StorageCache
+func NewStorageCache(s storage.Storage) *StorageCache {
+    return &StorageCache{storage: s}
+}
+
+// Get implements Cache.Get
+func (c *StorageCache) Get(ctx context.Context, key string) (*Entry, error) {
+    var entry Entry
+    if err := c.storage.Load(ctx, "httpcache/"+key, &entry); err != nil {
+        if errors.Is(err, storage.ErrNotFound) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to load cache entry: %w", err)
+    }
+    return &entry, nil
+}
+
+// Set implements Cache.Set
+func (c *StorageCache) Set(ctx context.Context, key string, entry *Entry) error {
+    if err := c.storage.Save(ctx, "httpcache/"+key, entry); err != nil {
+        return fmt.Errorf("failed to save cache entry: %w", err)
+    }
+    return nil
+}
+
+// Key returns the cache key of a request URL. A URL that changes on every
+// run, e.g. one embedding the current time, never hits the cache and adds an
+// entry per run; see WithKey.
+func Key(url string) string {
+    sum := sha256.Sum256([]byte(url))
+    return hex.EncodeToString(sum[:])
+} 
\ No newline at end of file
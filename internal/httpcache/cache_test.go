@@ -0,0 +1,75 @@
+package httpcache
+
+import (
+    "context"
+    "net/http"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestCaches(t *testing.T) {
+    dir, err := NewDirCache(t.TempDir())
+    if err != nil {
+        t.Fatal(err)
+    }
+    caches := map[string]Cache{
+        BackendDir:     dir,
+        BackendStorage: NewStorageCache(storage.NewMemoryStorage()),
+    }
+
+    for name, cache := range caches {
+        t.Run(name, func(t *testing.T) {
+            ctx := context.Background()
+            key := Key("https://example.com/data?a=1")
+
+            entry, err := cache.Get(ctx, key)
+            if err != nil || entry != nil {
+                t.Fatalf("Get on an empty cache = %v, %v", entry, err)
+            }
+
+            want := &Entry{
+                URL:          "https://example.com/data?a=1",
+                ETag:         `"v1"`,
+                LastModified: "Mon, 01 Jan 2024 00:00:00 GMT",
+                Header:       http.Header{"Content-Type": {"application/json"}},
+                Body:         []byte(`{"a":1}`),
+                StoredAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+            }
+            if err := cache.Set(ctx, key, want); err != nil {
+                t.Fatalf("Set: %v", err)
+            }
+            got, err := cache.Get(ctx, key)
+            if err != nil || got == nil {
+                t.Fatalf("Get = %v, %v", got, err)
+            }
+            if got.URL != want.URL || got.ETag != want.ETag || got.LastModified != want.LastModified ||
+                string(got.Body) != string(want.Body) || got.Header.Get("Content-Type") != "application/json" ||
+                !got.StoredAt.Equal(want.StoredAt) {
+                t.Errorf("Get = %+v, want %+v", got, want)
+            }
+
+            // Set replaces the entry
+            want.ETag = `"v2"`
+            if err := cache.Set(ctx, key, want); err != nil {
+                t.Fatalf("Set: %v", err)
+            }
+            if got, _ := cache.Get(ctx, key); got == nil || got.ETag != `"v2"` {
+                t.Errorf("Get after replace = %+v", got)
+            }
+        })
+    }
+}
+
+func TestNew(t *testing.T) {
+    if c, err := New(Config{}, nil); c != nil || err != nil {
+        t.Errorf("New with no backend = %v, %v, want caching disabled", c, err)
+    }
+    if _, err := New(Config{Backend: BackendDir}, nil); err == nil {
+        t.Error("New accepted the dir backend without a dir")
+    }
+    if _, err := New(Config{Backend: "redis"}, nil); err == nil {
+        t.Error("New accepted an unknown backend")
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,185 @@
+package httpcache
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "net/http"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+// ErrNotModified is returned for conditional requests answered with 304
+var ErrNotModified = errors.New("not modified")
+
+type conditionalKey struct{}
+
+type cacheKey struct{}
+
+// Pending holds the entries of conditional requests until the crawler has
+// stored the responses. Committing them earlier would answer the next crawl
+// with 304 even when the previous one failed after fetching.
+type Pending struct {
+    mu      sync.Mutex
+    entries []pendingEntry
+}
+
+type pendingEntry struct {
+    cache Cache
+    key   string
+    entry *Entry
+}
+
+// Conditional marks requests made with the returned context to fail with
+// ErrNotModified when the upstream answers 304, instead of being served the
+// cached body. Crawlers use it to skip work when nothing has changed, and
+// call Commit on the returned Pending once the new responses are stored.
+func Conditional(ctx context.Context) (context.Context, *Pending) {
+    p := &Pending{}
+    return context.WithValue(ctx, conditionalKey{}, p), p
+}
+
+func pendingFrom(ctx context.Context) *Pending {
+    p, _ := ctx.Value(conditionalKey{}).(*Pending)
+    return p
+}
+
+func (p *Pending) add(cache Cache, key string, entry *Entry) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.entries = append(p.entries, pendingEntry{cache: cache, key: key, entry: entry})
+}
+
+// Commit caches the held entries, so the next conditional request for the
+// same URLs can be answered with 304
+func (p *Pending) Commit(ctx context.Context) error {
+    p.mu.Lock()
+    entries := p.entries
+    p.entries = nil
+    p.mu.Unlock()
+
+    var errs []error
+    for _, e := range entries {
+        if err := e.cache.Set(ctx, e.key, e.entry); err != nil {
+            errs = append(errs, err)
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// WithKey makes requests made with the returned context cache under key
+// instead of their URL. Sources whose URL embeds the time, such as a
+// {{.Now}} template, use it to keep one entry per URL template.
+func WithKey(ctx context.Context, key string) context.Context {
+    return context.WithValue(ctx, cacheKey{}, key)
+}
+
+// Middleware returns an httpclient middleware that caches GET responses
+// carrying an ETag or Last-Modified header and revalidates them with
+// If-None-Match and If-Modified-Since. Cache failures are logged and the
+// request proceeds uncached.
+func Middleware(cache Cache) httpclient.Middleware {
+    return func(next http.RoundTripper) http.RoundTripper {
+        return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+            if req.Method != http.MethodGet {
+                return next.RoundTrip(req)
+            }
+
+            ctx := req.Context()
+            key := Key(req.URL.String())
+            if k, ok := ctx.Value(cacheKey{}).(string); ok {
+                key = Key(k)
+            }
+            entry, err := cache.Get(ctx, key)
+            if err != nil {
+                log.Printf("HTTP cache: %v", err)
+                entry = nil
+            }
+            // An entry keyed by WithKey may hold the body of another URL. It
+            // still revalidates conditional requests, which never serve it.
+            if entry != nil && entry.URL != req.URL.Redacted() && pendingFrom(ctx) == nil {
+                entry = nil
+            }
+
+            if entry != nil {
+                req = req.Clone(ctx)
+                if entry.ETag != "" {
+                    req.Header.Set("If-None-Match", entry.ETag)
+                }
+                if entry.LastModified != "" {
+                    req.Header.Set("If-Modified-Since", entry.LastModified)
+                }
+            }
+
+            resp, err := next.RoundTrip(req)
+            if err != nil {
+                return nil, err
+            }
+
+            switch {
+            case resp.StatusCode == http.StatusNotModified && entry != nil:
+                io.Copy(io.Discard, resp.Body)
+                resp.Body.Close()
+                if pendingFrom(ctx) != nil {
+                    return nil, &httpclient.PermanentError{Err: ErrNotModified}
+                }
+                return entry.response(req), nil
+
+            case resp.StatusCode == http.StatusOK:
+                etag := resp.Header.Get("ETag")
+                lastModified := resp.Header.Get("Last-Modified")
+                if etag == "" && lastModified == "" {
+                    return resp, nil
+                }
+
+                body, err := io.ReadAll(resp.Body)
+                resp.Body.Close()
+                if err != nil {
+                    return nil, fmt.Errorf("failed to read response body: %w", err)
+                }
+                resp.Body = io.NopCloser(bytes.NewReader(body))
+
+                stored := &Entry{
+                    URL:          req.URL.Redacted(),
+                    ETag:         etag,
+                    LastModified: lastModified,
+                    Header:       resp.Header.Clone(),
+                    Body:         body,
+                    StoredAt:     time.Now().UTC(),
+                }
+                if p := pendingFrom(ctx); p != nil {
+                    p.add(cache, key, stored)
+                } else if err := cache.Set(ctx, key, stored); err != nil {
+                    log.Printf("HTTP cache: %v", err)
+                }
+            }
+            return resp, nil
+        })
+    }
+}
+
+// response rebuilds a 200 response from the entry
+func (e *Entry) response(req *http.Request) *http.Response {
+    return &http.Response{
+        Status:        "200 OK",
+        StatusCode:    http.StatusOK,
+        Proto:         "HTTP/1.1",
+        ProtoMajor:    1,
+        ProtoMinor:    1,
+        Header:        e.Header.Clone(),
+        Body:          io.NopCloser(bytes.NewReader(e.Body)),
+        ContentLength: int64(len(e.Body)),
+        Request:       req,
+    }
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+    return f(req)
+} 
\ No newline at end of file
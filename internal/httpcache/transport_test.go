@@ -0,0 +1,137 @@
+package httpcache
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// validatingServer answers with body and the validator header, and with 304
+// when the request carries the matching conditional header
+type validatingServer struct {
+    *httptest.Server
+    mu          sync.Mutex
+    conditional []string
+}
+
+func newValidatingServer(t *testing.T, header, value, conditionalHeader string) *validatingServer {
+    s := &validatingServer{}
+    s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        got := r.Header.Get(conditionalHeader)
+        s.mu.Lock()
+        s.conditional = append(s.conditional, got)
+        s.mu.Unlock()
+        if got == value {
+            w.WriteHeader(http.StatusNotModified)
+            return
+        }
+        w.Header().Set(header, value)
+        w.Write([]byte(`{"value":1}`))
+    }))
+    t.Cleanup(s.Close)
+    return s
+}
+
+func (s *validatingServer) last() string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.conditional[len(s.conditional)-1]
+}
+
+func newCachingClient() *httpclient.Client {
+    client := httpclient.New(httpclient.Config{MaxRetries: -1})
+    client.Use(Middleware(NewStorageCache(storage.NewMemoryStorage())))
+    return client
+}
+
+func TestMiddlewareRevalidates(t *testing.T) {
+    tests := []struct {
+        name, header, value, conditional string
+    }{
+        {"etag", "ETag", `"abc"`, "If-None-Match"},
+        {"last-modified", "Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT", "If-Modified-Since"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            server := newValidatingServer(t, tt.header, tt.value, tt.conditional)
+            client := newCachingClient()
+            ctx := context.Background()
+
+            for i := 0; i < 2; i++ {
+                var v struct{ Value int }
+                if err := client.GetJSON(ctx, server.URL, &v); err != nil {
+                    t.Fatalf("request %d: %v", i+1, err)
+                }
+                if v.Value != 1 {
+                    t.Errorf("request %d decoded %+v", i+1, v)
+                }
+            }
+            // The second request was answered 304 and served from the cache
+            if got := server.last(); got != tt.value {
+                t.Errorf("second request sent %s %q, want %q", tt.conditional, got, tt.value)
+            }
+        })
+    }
+}
+
+func TestConditionalCommit(t *testing.T) {
+    server := newValidatingServer(t, "ETag", `"abc"`, "If-None-Match")
+    client := newCachingClient()
+    var v interface{}
+
+    // A crawl that fails after the 200 does not commit, so the next one
+    // fetches the data again
+    ctx, _ := Conditional(context.Background())
+    if err := client.GetJSON(ctx, server.URL, &v); err != nil {
+        t.Fatal(err)
+    }
+    ctx, pending := Conditional(context.Background())
+    if err := client.GetJSON(ctx, server.URL, &v); err != nil {
+        t.Fatalf("uncommitted entry answered: %v", err)
+    }
+    if got := server.last(); got != "" {
+        t.Errorf("request sent If-None-Match %q before any commit", got)
+    }
+
+    // Once committed, a 304 stops the crawl
+    if err := pending.Commit(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+    ctx, _ = Conditional(context.Background())
+    err := client.GetJSON(ctx, server.URL, &v)
+    var permanent *httpclient.PermanentError
+    if !errors.Is(err, ErrNotModified) || !errors.As(err, &permanent) {
+        t.Errorf("GetJSON after commit = %v, want a permanent ErrNotModified", err)
+    }
+}
+
+func TestWithKey(t *testing.T) {
+    server := newValidatingServer(t, "ETag", `"abc"`, "If-None-Match")
+    client := newCachingClient()
+    var v interface{}
+
+    ctx := WithKey(context.Background(), server.URL+"?now={{.Now}}")
+    if err := client.GetJSON(ctx, server.URL+"?now=1", &v); err != nil {
+        t.Fatal(err)
+    }
+
+    // Another URL under the same key is not served the cached body...
+    if err := client.GetJSON(ctx, server.URL+"?now=2", &v); err != nil {
+        t.Fatal(err)
+    }
+    if got := server.last(); got != "" {
+        t.Errorf("plain request for another URL sent If-None-Match %q", got)
+    }
+
+    // ...but a conditional request revalidates against it
+    condCtx, _ := Conditional(ctx)
+    if err := client.GetJSON(condCtx, server.URL+"?now=3", &v); !errors.Is(err, ErrNotModified) {
+        t.Errorf("conditional request under the key = %v, want ErrNotModified", err)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,324 @@
+package httpclient
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "math/rand"
+    "net/http"
+    "strconv"
+    "time"
+)
+
+// DefaultRetryableStatusCodes are retried when Config.RetryableStatusCodes is empty
+var DefaultRetryableStatusCodes = []int{
+    http.StatusTooManyRequests,
+    http.StatusInternalServerError,
+    http.StatusBadGateway,
+    http.StatusServiceUnavailable,
+    http.StatusGatewayTimeout,
+}
+
+const defaultUserAgent = "investutil-gocrawler/1.0"
+
+// Config holds configuration for Client
+type Config struct {
+    // Timeout bounds a single attempt
+    Timeout time.Duration `yaml:"timeout"`
+    // MaxRetries defaults to 3; a negative value disables retries
+    MaxRetries int `yaml:"max_retries"`
+    // BaseDelay is the first backoff delay, doubled on every retry up to MaxDelay
+    BaseDelay            time.Duration `yaml:"base_delay"`
+    MaxDelay             time.Duration `yaml:"max_delay"`
+    RetryableStatusCodes []int         `yaml:"retryable_status_codes"`
+    UserAgent            string        `yaml:"user_agent"`
+}
+
+// StatusError is returned by GetJSON for a non-200 response
+type StatusError struct {
+    StatusCode int
+    URL        string
+    // RetryAfter is the wait the response asked for, if any, e.g. when it
+    // was longer than the client would wait
+    RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+    if e.RetryAfter > 0 {
+        return fmt.Sprintf("unexpected status code: %d, retry after %s", e.StatusCode, e.RetryAfter)
+    }
+    return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// PermanentError wraps a transport error that must not be retried, such as
+// a middleware refusing the request
+type PermanentError struct {
+    Err error
+}
+
+func (e *PermanentError) Error() string {
+    return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+    return e.Err
+}
+
+// Middleware wraps the transport used for every attempt
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// Client is an HTTP client shared by crawlers. It retries failed requests
+// with jittered exponential backoff, honours Retry-After and logs every attempt.
+type Client struct {
+    http *http.Client
+    // direct does not follow redirects, for Transport callers that handle them
+    direct    *http.Client
+    transport http.RoundTripper
+    config    Config
+    retryable map[int]bool
+}
+
+// New creates a new Client, filling unset configuration with defaults
+func New(cfg Config) *Client {
+    return NewWithTransport(cfg, http.DefaultTransport)
+}
+
+// NewWithTransport creates a new Client sending requests through transport,
+// e.g. a proxy pool
+func NewWithTransport(cfg Config, transport http.RoundTripper) *Client {
+    if cfg.Timeout <= 0 {
+        cfg.Timeout = 30 * time.Second
+    }
+    if cfg.MaxRetries < 0 {
+        cfg.MaxRetries = 0
+    } else if cfg.MaxRetries == 0 {
+        cfg.MaxRetries = 3
+    }
+    if cfg.BaseDelay <= 0 {
+        cfg.BaseDelay = time.Second
+    }
+    if cfg.MaxDelay <= 0 {
+        cfg.MaxDelay = 30 * time.Second
+    }
+    if len(cfg.RetryableStatusCodes) == 0 {
+        cfg.RetryableStatusCodes = DefaultRetryableStatusCodes
+    }
+    if cfg.UserAgent == "" {
+        cfg.UserAgent = defaultUserAgent
+    }
+
+    retryable := make(map[int]bool, len(cfg.RetryableStatusCodes))
+    for _, code := range cfg.RetryableStatusCodes {
+        retryable[code] = true
+    }
+
+    return &Client{
+        http: &http.Client{
+            Timeout:   cfg.Timeout,
+            Transport: transport,
+        },
+        direct: &http.Client{
+            Timeout:   cfg.Timeout,
+            Transport: transport,
+            CheckRedirect: func(*http.Request, []*http.Request) error {
+                return http.ErrUseLastResponse
+            },
+        },
+        transport: transport,
+        config:    cfg,
+        retryable: retryable,
+    }
+}
+
+// Use wraps the transport with a middleware. Middlewares added later run
+// first. Use must be called before the client is shared.
+func (c *Client) Use(m Middleware) {
+    c.transport = m(c.transport)
+    c.http.Transport = c.transport
+    c.direct.Transport = c.transport
+}
+
+// Do sends a request, retrying network errors and retryable status codes.
+// The caller must close the body of the returned response.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+    return c.do(c.http, req)
+}
+
+// do sends req through client. The caller's request is left untouched.
+func (c *Client) do(client *http.Client, req *http.Request) (*http.Response, error) {
+    ctx := req.Context()
+    if req.Header.Get("User-Agent") == "" {
+        req = req.Clone(ctx)
+        req.Header.Set("User-Agent", c.config.UserAgent)
+    }
+
+    for attempt := 0; ; attempt++ {
+        attemptReq, err := cloneRequest(req)
+        if err != nil {
+            return nil, err
+        }
+
+        attemptReq, span := startAttemptSpan(attemptReq, attempt)
+        start := time.Now()
+        resp, err := client.Do(attemptReq)
+        elapsed := time.Since(start).Round(time.Millisecond)
+        endAttemptSpan(span, resp, err)
+
+        if err != nil {
+            recordAttempt(req.URL.Host, 0, time.Since(start))
+            log.Printf("HTTP %s %s failed in %s (attempt %d): %v", req.Method, req.URL.Redacted(), elapsed, attempt+1, err)
+            if ctx.Err() != nil || !isRetryableError(err) || attempt >= c.config.MaxRetries {
+                return nil, err
+            }
+            if err := sleep(ctx, c.backoff(attempt)); err != nil {
+                return nil, err
+            }
+            continue
+        }
+
+        log.Printf("HTTP %s %s -> %d in %s (attempt %d)", req.Method, req.URL.Redacted(), resp.StatusCode, elapsed, attempt+1)
+        recordAttempt(req.URL.Host, resp.StatusCode, time.Since(start))
+        if !c.retryable[resp.StatusCode] || attempt >= c.config.MaxRetries {
+            return resp, nil
+        }
+
+        delay, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+        if !ok {
+            delay = c.backoff(attempt)
+        } else if !c.canWait(ctx, delay) {
+            // Waiting would hold the caller past MaxDelay or its deadline,
+            // so the response is returned for the caller to retry later
+            log.Printf("HTTP %s %s: Retry-After of %s is too long, not retrying", req.Method, req.URL.Redacted(), delay)
+            return resp, nil
+        }
+        // Drain the body so the connection can be reused
+        io.Copy(io.Discard, resp.Body)
+        resp.Body.Close()
+
+        if err := sleep(ctx, delay); err != nil {
+            return nil, err
+        }
+    }
+}
+
+// GetJSON fetches url and decodes a 200 JSON response into v
+func (c *Client) GetJSON(ctx context.Context, url string, v interface{}) error {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+    if err != nil {
+        return fmt.Errorf("failed to create request: %w", err)
+    }
+    req.Header.Set("Accept", "application/json")
+    return c.DoJSON(req, v)
+}
+
+// DoJSON sends req and decodes a 200 JSON response into v
+func (c *Client) DoJSON(req *http.Request, v interface{}) error {
+    resp, err := c.Do(req)
+    if err != nil {
+        return fmt.Errorf("failed to fetch data: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        delay, _ := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+        return &StatusError{StatusCode: resp.StatusCode, URL: req.URL.Redacted(), RetryAfter: delay}
+    }
+
+    if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+        return fmt.Errorf("failed to decode response: %w", err)
+    }
+    return nil
+}
+
+// Transport returns a RoundTripper backed by Do, for libraries such as
+// colly that bring their own http.Client. Redirects are returned to that
+// client rather than followed, so it keeps its own redirect handling.
+func (c *Client) Transport() http.RoundTripper {
+    return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+        return c.do(c.direct, req)
+    })
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+    return f(req)
+}
+
+// backoff returns the jittered exponential delay before retry attempt+1
+func (c *Client) backoff(attempt int) time.Duration {
+    delay := c.config.BaseDelay << uint(attempt)
+    if delay <= 0 || delay > c.config.MaxDelay {
+        delay = c.config.MaxDelay
+    }
+    // Equal jitter: a random delay between half and the whole backoff
+    half := delay / 2
+    return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// canWait reports whether the client may wait d before retrying: at most
+// MaxDelay, and not past the deadline of ctx
+func (c *Client) canWait(ctx context.Context, d time.Duration) bool {
+    if d > c.config.MaxDelay {
+        return false
+    }
+    deadline, ok := ctx.Deadline()
+    return !ok || time.Until(deadline) > d
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+    if value == "" {
+        return 0, false
+    }
+    if seconds, err := strconv.Atoi(value); err == nil {
+        if seconds < 0 {
+            return 0, false
+        }
+        return time.Duration(seconds) * time.Second, true
+    }
+    if at, err := http.ParseTime(value); err == nil {
+        if d := at.Sub(now); d > 0 {
+            return d, true
+        }
+        return 0, true
+    }
+    return 0, false
+}
+
+// isRetryableError reports whether a transport error may succeed on retry
+func isRetryableError(err error) bool {
+    var permanent *PermanentError
+    return !errors.Is(err, context.Canceled) && !errors.As(err, &permanent)
+}
+
+// cloneRequest copies req for another attempt, rewinding its body
+func cloneRequest(req *http.Request) (*http.Request, error) {
+    clone := req.Clone(req.Context())
+    if req.Body != nil && req.Body != http.NoBody {
+        if req.GetBody == nil {
+            return nil, fmt.Errorf("request body cannot be replayed for retries")
+        }
+        body, err := req.GetBody()
+        if err != nil {
+            return nil, fmt.Errorf("failed to rewind request body: %w", err)
+        }
+        clone.Body = body
+    }
+    return clone, nil
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+    timer := time.NewTimer(d)
+    defer timer.Stop()
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case <-timer.C:
+        return nil
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,112 @@
+package httpclient
+
+import (
+    "context"
+    "errors"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestRetryAfter(t *testing.T) {
+    tests := []struct {
+        name       string
+        retryAfter string
+        timeout    time.Duration
+        attempts   int32
+        wait       time.Duration
+    }{
+        {"short wait is honoured", "1", 0, 2, 0},
+        {"longer than max delay", "3600", 0, 1, time.Hour},
+        {"past the deadline", "2", time.Second, 1, 2 * time.Second},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var attempts int32
+            server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                if atomic.AddInt32(&attempts, 1) == 1 {
+                    w.Header().Set("Retry-After", tt.retryAfter)
+                    w.WriteHeader(http.StatusTooManyRequests)
+                    return
+                }
+                w.Write([]byte(`{}`))
+            }))
+            defer server.Close()
+
+            ctx := context.Background()
+            if tt.timeout > 0 {
+                var cancel context.CancelFunc
+                ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+                defer cancel()
+            }
+            client := New(Config{MaxDelay: 5 * time.Second})
+
+            start := time.Now()
+            var v struct{}
+            err := client.GetJSON(ctx, server.URL, &v)
+            elapsed := time.Since(start)
+
+            if got := atomic.LoadInt32(&attempts); got != tt.attempts {
+                t.Errorf("%d attempts, want %d", got, tt.attempts)
+            }
+            if tt.wait == 0 {
+                if err != nil {
+                    t.Errorf("GetJSON = %v", err)
+                }
+                return
+            }
+            var statusErr *StatusError
+            if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests || statusErr.RetryAfter != tt.wait {
+                t.Errorf("GetJSON = %v, want a 429 asking to wait %s", err, tt.wait)
+            }
+            if elapsed > 500*time.Millisecond {
+                t.Errorf("returned after %s, want at once", elapsed)
+            }
+        })
+    }
+}
+func TestTransport(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/moved" {
+            http.Redirect(w, r, "/target", http.StatusFound)
+            return
+        }
+        w.Write([]byte(r.Header.Get("User-Agent")))
+    }))
+    defer server.Close()
+
+    transport := New(Config{UserAgent: "test-agent"}).Transport()
+
+    req, err := http.NewRequest(http.MethodGet, server.URL+"/moved", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp, err := transport.RoundTrip(req)
+    if err != nil {
+        t.Fatalf("RoundTrip = %v", err)
+    }
+    resp.Body.Close()
+    if resp.StatusCode != http.StatusFound {
+        t.Errorf("status %d, want the redirect itself", resp.StatusCode)
+    }
+    if got := req.Header.Get("User-Agent"); got != "" {
+        t.Errorf("caller's request was modified: User-Agent %q", got)
+    }
+
+    req, err = http.NewRequest(http.MethodGet, server.URL+"/target", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp, err = transport.RoundTrip(req)
+    if err != nil {
+        t.Fatalf("RoundTrip = %v", err)
+    }
+    defer resp.Body.Close()
+    body, _ := io.ReadAll(resp.Body)
+    if string(body) != "test-agent" {
+        t.Errorf("server saw User-Agent %q, want test-agent", body)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,26 @@
+package httpclient
+
+import (
+    "strconv"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/metrics"
+)
+
+var (
+    requestsTotal = metrics.NewCounterVec("http_client_requests_total",
+        "HTTP request attempts by upstream host and status code, error for network errors", "host", "code")
+    requestDuration = metrics.NewHistogramVec("http_client_request_duration_seconds",
+        "Duration of HTTP request attempts by upstream host", nil, "host")
+)
+
+// recordAttempt records an attempt to host that returned code, 0 for a
+// network error
+func recordAttempt(host string, code int, elapsed time.Duration) {
+    label := "error"
+    if code > 0 {
+        label = strconv.Itoa(code)
+    }
+    requestsTotal.WithLabelValues(host, label).Inc()
+    requestDuration.WithLabelValues(host).Observe(elapsed.Seconds())
+} 
\ No newline at end of file
@@ -0,0 +1,43 @@
+package httpclient
+
+import (
+    "fmt"
+    "net/http"
+
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
+)
+
+// startAttemptSpan starts a client span for an attempt of req and returns
+// the request carrying it, so that the middlewares see the span
+func startAttemptSpan(req *http.Request, attempt int) (*http.Request, trace.Span) {
+    attrs := []attribute.KeyValue{
+        attribute.String("http.request.method", req.Method),
+        attribute.String("url.full", req.URL.Redacted()),
+        attribute.String("server.address", req.URL.Hostname()),
+    }
+    if attempt > 0 {
+        attrs = append(attrs, attribute.Int("http.request.resend_count", attempt))
+    }
+    ctx, span := tracing.Tracer().Start(req.Context(), "HTTP "+req.Method,
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithAttributes(attrs...))
+    return req.WithContext(ctx), span
+}
+
+// endAttemptSpan records the response, or err for a network error, and ends
+// the span. Client and server errors mark the span as failed.
+func endAttemptSpan(span trace.Span, resp *http.Response, err error) {
+    if err != nil {
+        tracing.End(span, err)
+        return
+    }
+    span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+    if resp.StatusCode >= 400 {
+        span.SetStatus(codes.Error, fmt.Sprintf("status %d", resp.StatusCode))
+    }
+    span.End()
+} 
\ No newline at end of file
@@ -0,0 +1,30 @@
+package httpclient
+
+import (
+    "sync/atomic"
+)
+
+// UserAgents rotates through a list of user agents. It is safe for
+// concurrent use.
+type UserAgents struct {
+    agents []string
+    next   atomic.Uint64
+}
+
+// NewUserAgents creates a new UserAgents, or returns nil for an empty list
+func NewUserAgents(agents []string) *UserAgents {
+    if len(agents) == 0 {
+        return nil
+    }
+    return &UserAgents{agents: agents}
+}
+
+// Next returns the next user agent. A nil UserAgents returns an empty
+// string, leaving the client default in place.
+func (u *UserAgents) Next() string {
+    if u == nil {
+        return ""
+    }
+    n := u.next.Add(1) - 1
+    return u.agents[n%uint64(len(u.agents))]
+} 
\ No newline at end of file
@@ -0,0 +1,41 @@
+package indicators
+
+import (
+    "context"
+    "log"
+
+    "github.com/yourusername/investutil-gocrawler/internal/database"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+)
+
+// Database wraps a database.Database and updates the indicators of the
+// Bitcoin prices it saves
+type Database struct {
+    database.Database
+    pipeline *Pipeline
+}
+
+// NewDatabase creates a new Database updating pipeline
+func NewDatabase(db database.Database, pipeline *Pipeline) *Database {
+    return &Database{
+        Database: db,
+        pipeline: pipeline,
+    }
+}
+
+// SaveBitcoinPrices implements database.Database.SaveBitcoinPrices. The
+// prices are saved even when the indicators fail, which is only logged so
+// that the batch is not redelivered.
+func (d *Database) SaveBitcoinPrices(ctx context.Context, data models.BitcoinDailyData) error {
+    if err := d.Database.SaveBitcoinPrices(ctx, data); err != nil {
+        return err
+    }
+    result, err := d.pipeline.Update(ctx, resample.FromBitcoin(data.Data))
+    if err != nil {
+        log.Printf("Failed to update indicators: %v", err)
+        return nil
+    }
+    log.Printf("Updated indicators for %d points (full recompute: %t)", result.Points, result.Full)
+    return nil
+} 
\ No newline at end of file
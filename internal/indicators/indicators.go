@@ -0,0 +1,399 @@
+package indicators
+
+import (
+    "fmt"
+    "math"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+)
+
+// Units of the derived series besides the price unit
+const (
+    UnitPercent = "percent"
+    UnitRatio   = "ratio"
+)
+
+// daysPerYear annualizes the volatility of daily returns; crypto trades every day
+const daysPerYear = 365
+
+// MACDConfig holds the windows of the MACD
+type MACDConfig struct {
+    // Fast is the window of the fast EMA, defaults to 12
+    Fast int `yaml:"fast"`
+    // Slow is the window of the slow EMA, defaults to 26
+    Slow int `yaml:"slow"`
+    // Signal is the window of the signal line, defaults to 9
+    Signal int `yaml:"signal"`
+}
+
+// BollingerConfig holds the settings of the Bollinger bands
+type BollingerConfig struct {
+    // Window of the middle band, defaults to 20
+    Window int `yaml:"window"`
+    // Width is the number of standard deviations of the outer bands, defaults to 2
+    Width float64 `yaml:"width"`
+}
+
+// Config selects the indicators computed from a price series. Windows are
+// counted in points, which are days for daily series.
+type Config struct {
+    // Unit labels the series on the price scale, defaults to usd
+    Unit string `yaml:"unit"`
+    // SMA lists the windows of simple moving averages
+    SMA []int `yaml:"sma"`
+    // EMA lists the windows of exponential moving averages
+    EMA []int `yaml:"ema"`
+    // RSI is the period of the relative strength index, e.g. 14
+    RSI int `yaml:"rsi"`
+    // MACD enables the MACD, its signal line and histogram
+    MACD *MACDConfig `yaml:"macd"`
+    // Bollinger enables the Bollinger bands
+    Bollinger *BollingerConfig `yaml:"bollinger"`
+    // Volatility lists the windows of the annualized realized volatility
+    Volatility []int `yaml:"volatility"`
+    // Drawdown enables the drawdown from the all-time high
+    Drawdown bool `yaml:"drawdown"`
+    // WeeklySMA lists the windows in weeks of moving averages of weekly
+    // closes, e.g. [200]
+    WeeklySMA []int `yaml:"weekly_sma"`
+}
+
+// withDefaults returns the configuration with unset values filled in
+func (c Config) withDefaults() Config {
+    if c.Unit == "" {
+        c.Unit = "usd"
+    }
+    if c.MACD != nil {
+        macd := *c.MACD
+        if macd.Fast <= 0 {
+            macd.Fast = 12
+        }
+        if macd.Slow <= 0 {
+            macd.Slow = 26
+        }
+        if macd.Signal <= 0 {
+            macd.Signal = 9
+        }
+        c.MACD = &macd
+    }
+    if c.Bollinger != nil {
+        bollinger := *c.Bollinger
+        if bollinger.Window <= 0 {
+            bollinger.Window = 20
+        }
+        if bollinger.Width <= 0 {
+            bollinger.Width = 2
+        }
+        c.Bollinger = &bollinger
+    }
+    return c
+}
+
+// Validate checks that at least one indicator is enabled and that the
+// windows make sense
+func (c Config) Validate() error {
+    c = c.withDefaults()
+    if len(c.calculators()) == 0 {
+        return fmt.Errorf("indicators: at least one indicator is required")
+    }
+    for _, windows := range [][]int{c.SMA, c.EMA, c.WeeklySMA} {
+        for _, w := range windows {
+            if w <= 0 {
+                return fmt.Errorf("indicators: moving average windows must be positive")
+            }
+        }
+    }
+    for _, w := range c.Volatility {
+        if w < 2 {
+            return fmt.Errorf("indicators: volatility windows must be at least 2")
+        }
+    }
+    if c.RSI < 0 {
+        return fmt.Errorf("indicators: rsi period must be positive")
+    }
+    if c.MACD != nil && c.MACD.Fast >= c.MACD.Slow {
+        return fmt.Errorf("indicators: macd fast window must be shorter than the slow one")
+    }
+    return nil
+}
+
+// calculator computes an indicator point by point. Its exported fields hold
+// all of its state so that it can be stored and resumed.
+type calculator interface {
+    // update consumes the next close and returns the outputs ready at t
+    update(t time.Time, close float64) []output
+}
+
+type output struct {
+    name  string
+    value float64
+}
+
+// namedCalculator is a configured calculator and the series it outputs
+type namedCalculator struct {
+    id     string
+    calc   calculator
+    series map[string]string // output name to unit
+}
+
+// calculators builds the calculators of the configured indicators
+func (c Config) calculators() []namedCalculator {
+    var calcs []namedCalculator
+    single := func(id, unit string, calc calculator) {
+        calcs = append(calcs, namedCalculator{id, calc, map[string]string{id: unit}})
+    }
+    for _, w := range c.SMA {
+        single(fmt.Sprintf("sma-%d", w), c.Unit, &smaCalculator{Window: w})
+    }
+    for _, w := range c.EMA {
+        single(fmt.Sprintf("ema-%d", w), c.Unit, &emaCalculator{EMA: ema{Window: w}})
+    }
+    if c.RSI > 0 {
+        single(fmt.Sprintf("rsi-%d", c.RSI), UnitPercent, &rsiCalculator{Period: c.RSI})
+    }
+    if c.MACD != nil {
+        calcs = append(calcs, namedCalculator{
+            id: "macd",
+            calc: &macdCalculator{
+                Fast:   ema{Window: c.MACD.Fast},
+                Slow:   ema{Window: c.MACD.Slow},
+                Signal: ema{Window: c.MACD.Signal},
+            },
+            series: map[string]string{"macd": c.Unit, "macd-signal": c.Unit, "macd-histogram": c.Unit},
+        })
+    }
+    if c.Bollinger != nil {
+        calcs = append(calcs, namedCalculator{
+            id:     "bollinger",
+            calc:   &bollingerCalculator{Window: c.Bollinger.Window, Width: c.Bollinger.Width},
+            series: map[string]string{"bollinger-middle": c.Unit, "bollinger-upper": c.Unit, "bollinger-lower": c.Unit},
+        })
+    }
+    for _, w := range c.Volatility {
+        single(fmt.Sprintf("volatility-%d", w), UnitRatio, &volatilityCalculator{Window: w})
+    }
+    if c.Drawdown {
+        single("drawdown", UnitRatio, &drawdownCalculator{})
+    }
+    for _, w := range c.WeeklySMA {
+        single(fmt.Sprintf("sma-%dw", w), c.Unit, &weeklySMACalculator{Window: w})
+    }
+    return calcs
+}
+
+// push appends v to a window of at most n values
+func push(values []float64, v float64, n int) []float64 {
+    values = append(values, v)
+    if len(values) > n {
+        values = append(values[:0], values[len(values)-n:]...)
+    }
+    return values
+}
+
+// meanStddev returns the mean and the standard deviation of values, dividing
+// by len(values)-ddof
+func meanStddev(values []float64, ddof int) (float64, float64) {
+    var sum float64
+    for _, v := range values {
+        sum += v
+    }
+    mean := sum / float64(len(values))
+    var sq float64
+    for _, v := range values {
+        sq += (v - mean) * (v - mean)
+    }
+    return mean, math.Sqrt(sq / float64(len(values)-ddof))
+}
+
+// smaCalculator is a simple moving average
+type smaCalculator struct {
+    Window int       `json:"window"`
+    Values []float64 `json:"values"`
+}
+
+func (s *smaCalculator) update(t time.Time, close float64) []output {
+    s.Values = push(s.Values, close, s.Window)
+    if len(s.Values) < s.Window {
+        return nil
+    }
+    mean, _ := meanStddev(s.Values, 0)
+    return []output{{fmt.Sprintf("sma-%d", s.Window), mean}}
+}
+
+// ema is an exponential moving average seeded with the mean of its first
+// window
+type ema struct {
+    Window int     `json:"window"`
+    Count  int     `json:"count"`
+    Value  float64 `json:"value"`
+}
+
+// add consumes v and reports whether the average is ready
+func (e *ema) add(v float64) bool {
+    if e.Count < e.Window {
+        e.Count++
+        e.Value += (v - e.Value) / float64(e.Count)
+        return e.Count == e.Window
+    }
+    e.Value += 2 / float64(e.Window+1) * (v - e.Value)
+    return true
+}
+
+// emaCalculator is an exponential moving average
+type emaCalculator struct {
+    EMA ema `json:"ema"`
+}
+
+func (e *emaCalculator) update(t time.Time, close float64) []output {
+    if !e.EMA.add(close) {
+        return nil
+    }
+    return []output{{fmt.Sprintf("ema-%d", e.EMA.Window), e.EMA.Value}}
+}
+
+// rsiCalculator is Wilder's relative strength index
+type rsiCalculator struct {
+    Period  int     `json:"period"`
+    Count   int     `json:"count"`
+    Prev    float64 `json:"prev"`
+    AvgGain float64 `json:"avg_gain"`
+    AvgLoss float64 `json:"avg_loss"`
+}
+
+func (r *rsiCalculator) update(t time.Time, close float64) []output {
+    r.Count++
+    prev := r.Prev
+    r.Prev = close
+    if r.Count == 1 {
+        return nil
+    }
+
+    gain, loss := math.Max(close-prev, 0), math.Max(prev-close, 0)
+    changes := r.Count - 1
+    if changes <= r.Period {
+        // The first averages are plain means of the changes
+        r.AvgGain += (gain - r.AvgGain) / float64(changes)
+        r.AvgLoss += (loss - r.AvgLoss) / float64(changes)
+        if changes < r.Period {
+            return nil
+        }
+    } else {
+        n := float64(r.Period)
+        r.AvgGain = (r.AvgGain*(n-1) + gain) / n
+        r.AvgLoss = (r.AvgLoss*(n-1) + loss) / n
+    }
+
+    rsi := 100.0
+    if r.AvgLoss > 0 {
+        rsi = 100 - 100/(1+r.AvgGain/r.AvgLoss)
+    } else if r.AvgGain == 0 {
+        rsi = 50
+    }
+    return []output{{fmt.Sprintf("rsi-%d", r.Period), rsi}}
+}
+
+// macdCalculator is the moving average convergence divergence with its
+// signal line and histogram
+type macdCalculator struct {
+    Fast   ema `json:"fast"`
+    Slow   ema `json:"slow"`
+    Signal ema `json:"signal"`
+}
+
+func (m *macdCalculator) update(t time.Time, close float64) []output {
+    fastReady := m.Fast.add(close)
+    if !m.Slow.add(close) || !fastReady {
+        return nil
+    }
+    macd := m.Fast.Value - m.Slow.Value
+    outputs := []output{{"macd", macd}}
+    if m.Signal.add(macd) {
+        outputs = append(outputs,
+            output{"macd-signal", m.Signal.Value},
+            output{"macd-histogram", macd - m.Signal.Value})
+    }
+    return outputs
+}
+
+// bollingerCalculator is a moving average with bands a number of standard
+// deviations above and below it
+type bollingerCalculator struct {
+    Window int       `json:"window"`
+    Width  float64   `json:"width"`
+    Values []float64 `json:"values"`
+}
+
+func (b *bollingerCalculator) update(t time.Time, close float64) []output {
+    b.Values = push(b.Values, close, b.Window)
+    if len(b.Values) < b.Window {
+        return nil
+    }
+    mean, stddev := meanStddev(b.Values, 0)
+    return []output{
+        {"bollinger-middle", mean},
+        {"bollinger-upper", mean + b.Width*stddev},
+        {"bollinger-lower", mean - b.Width*stddev},
+    }
+}
+
+// volatilityCalculator is the annualized standard deviation of the daily
+// log returns over a window
+type volatilityCalculator struct {
+    Window  int       `json:"window"`
+    Prev    float64   `json:"prev"`
+    Returns []float64 `json:"returns"`
+}
+
+func (v *volatilityCalculator) update(t time.Time, close float64) []output {
+    prev := v.Prev
+    v.Prev = close
+    if prev <= 0 || close <= 0 {
+        return nil
+    }
+    v.Returns = push(v.Returns, math.Log(close/prev), v.Window)
+    if len(v.Returns) < v.Window {
+        return nil
+    }
+    _, stddev := meanStddev(v.Returns, 1)
+    return []output{{fmt.Sprintf("volatility-%d", v.Window), stddev * math.Sqrt(daysPerYear)}}
+}
+
+// drawdownCalculator is the fall from the all-time high, from 0 at a new
+// high down to -1
+type drawdownCalculator struct {
+    High float64 `json:"high"`
+}
+
+func (d *drawdownCalculator) update(t time.Time, close float64) []output {
+    if close > d.High {
+        d.High = close
+    }
+    if d.High <= 0 {
+        return nil
+    }
+    return []output{{"drawdown", close/d.High - 1}}
+}
+
+// weeklySMACalculator is a moving average of weekly closes. Every point gets
+// a value, taking the close of the current week so far as its last close.
+type weeklySMACalculator struct {
+    Window int       `json:"window"`
+    Week   time.Time `json:"week"`
+    Close  float64   `json:"close"`
+    Closes []float64 `json:"closes"`
+}
+
+func (w *weeklySMACalculator) update(t time.Time, close float64) []output {
+    week := resample.PeriodStart(t, resample.PeriodWeek, time.UTC)
+    if !w.Week.IsZero() && week.After(w.Week) {
+        w.Closes = push(w.Closes, w.Close, w.Window-1)
+    }
+    w.Week = week
+    w.Close = close
+    if len(w.Closes) < w.Window-1 {
+        return nil
+    }
+    mean, _ := meanStddev(append(append([]float64{}, w.Closes...), close), 0)
+    return []output{{fmt.Sprintf("sma-%dw", w.Window), mean}}
+} 
\ No newline at end of file
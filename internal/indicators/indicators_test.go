@@ -0,0 +1,103 @@
+package indicators
+
+import (
+    "math"
+    "testing"
+    "time"
+)
+
+// run feeds daily closes from 1 January 2024, a Monday, to calc and returns
+// the value of output name after each close, NaN while it is not ready
+func run(calc calculator, name string, closes []float64) []float64 {
+    values := make([]float64, len(closes))
+    for i, c := range closes {
+        values[i] = math.NaN()
+        t := time.Date(2024, 1, 1+i, 0, 0, 0, 0, time.UTC)
+        for _, out := range calc.update(t, c) {
+            if out.name == name {
+                values[i] = out.value
+            }
+        }
+    }
+    return values
+}
+
+func assertValues(t *testing.T, name string, got, want []float64) {
+    t.Helper()
+    if len(got) != len(want) {
+        t.Fatalf("%s: %d values, want %d", name, len(got), len(want))
+    }
+    for i := range want {
+        if math.IsNaN(want[i]) != math.IsNaN(got[i]) || (!math.IsNaN(want[i]) && math.Abs(got[i]-want[i]) > 1e-9) {
+            t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+        }
+    }
+}
+
+var nan = math.NaN()
+
+func TestSMA(t *testing.T) {
+    got := run(&smaCalculator{Window: 3}, "sma-3", []float64{1, 2, 3, 4, 8})
+    assertValues(t, "sma-3", got, []float64{nan, nan, 2, 3, 5})
+}
+
+func TestEMASeededWithMean(t *testing.T) {
+    // Seeded with the mean of the first window, then smoothed by 2/(3+1)
+    got := run(&emaCalculator{EMA: ema{Window: 3}}, "ema-3", []float64{2, 4, 6, 10, 4})
+    assertValues(t, "ema-3", got, []float64{nan, nan, 4, 7, 5.5})
+}
+
+func TestWilderRSI(t *testing.T) {
+    // Changes +1 +1 -1 +1: the first averages are means, later ones are
+    // smoothed as (previous*(n-1) + change)/n, giving 0.75/0.25 at the end
+    got := run(&rsiCalculator{Period: 2}, "rsi-2", []float64{1, 2, 3, 2, 3})
+    assertValues(t, "rsi-2", got, []float64{nan, nan, 100, 50, 75})
+
+    flat := run(&rsiCalculator{Period: 2}, "rsi-2", []float64{5, 5, 5})
+    assertValues(t, "flat rsi-2", flat, []float64{nan, nan, 50})
+}
+
+func TestMACD(t *testing.T) {
+    closes := []float64{2, 4, 6, 10, 4, 8}
+    newCalc := func() calculator {
+        return &macdCalculator{Fast: ema{Window: 2}, Slow: ema{Window: 3}, Signal: ema{Window: 2}}
+    }
+    // fast: 3, 5, 8.33, 5.44, 7.15; slow: 4, 7, 5.5, 6.75
+    macd := []float64{nan, nan, 1, 4.0 / 3, -1.0 / 18, 43.0 / 108}
+    signal := []float64{nan, nan, nan, 7.0 / 6, 19.0 / 54, 31.0 / 81}
+    assertValues(t, "macd", run(newCalc(), "macd", closes), macd)
+    assertValues(t, "macd-signal", run(newCalc(), "macd-signal", closes), signal)
+    histogram := []float64{nan, nan, nan, macd[3] - signal[3], macd[4] - signal[4], macd[5] - signal[5]}
+    assertValues(t, "macd-histogram", run(newCalc(), "macd-histogram", closes), histogram)
+}
+
+func TestBollinger(t *testing.T) {
+    closes := []float64{1, 2, 3}
+    // Population standard deviation of 1, 2, 3
+    stddev := math.Sqrt(2.0 / 3)
+    newCalc := func() calculator { return &bollingerCalculator{Window: 3, Width: 2} }
+    assertValues(t, "middle", run(newCalc(), "bollinger-middle", closes), []float64{nan, nan, 2})
+    assertValues(t, "upper", run(newCalc(), "bollinger-upper", closes), []float64{nan, nan, 2 + 2*stddev})
+    assertValues(t, "lower", run(newCalc(), "bollinger-lower", closes), []float64{nan, nan, 2 - 2*stddev})
+}
+
+func TestVolatility(t *testing.T) {
+    r1, r2 := math.Log(1.1), math.Log(0.9)
+    // Sample standard deviation of two returns, annualized over 365 days
+    want := math.Abs(r1-r2) / math.Sqrt(2) * math.Sqrt(365)
+    got := run(&volatilityCalculator{Window: 2}, "volatility-2", []float64{100, 110, 99})
+    assertValues(t, "volatility-2", got, []float64{nan, nan, want})
+}
+
+func TestDrawdown(t *testing.T) {
+    got := run(&drawdownCalculator{}, "drawdown", []float64{100, 120, 90, 130})
+    assertValues(t, "drawdown", got, []float64{0, 0, -0.25, 0})
+}
+
+func TestWeeklySMA(t *testing.T) {
+    // Two weeks of closes: the first week closes at 7 on Sunday, and each
+    // day of the second averages that with its own close so far
+    closes := []float64{1, 2, 3, 4, 5, 6, 7, 9, 11}
+    got := run(&weeklySMACalculator{Window: 2}, "sma-2w", closes)
+    assertValues(t, "sma-2w", got, []float64{nan, nan, nan, nan, nan, nan, nan, 8, 9})
+} 
\ No newline at end of file
@@ -0,0 +1,237 @@
+package indicators
+
+import (
+    "context"
+    "encoding/binary"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "hash/fnv"
+    "math"
+    "sort"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Key returns the storage prefix of the named indicator series of the
+// series under prefix
+func Key(prefix, name string) string {
+    return fmt.Sprintf("%s/indicators/%s", prefix, name)
+}
+
+func stateKey(prefix string) string {
+    return fmt.Sprintf("%s/indicators/state.json", prefix)
+}
+
+// state is the checkpoint a run resumes from: the calculators after every
+// point up to Checkpoint, which is the second to last point of the previous
+// run. The last point is left out as it is often a provisional close that
+// the next crawl revises. Digest fingerprints the points up to Checkpoint.
+type state struct {
+    Checkpoint time.Time `json:"checkpoint" bson:"checkpoint"`
+    Count      int       `json:"count" bson:"count"`
+    Digest     string    `json:"digest" bson:"digest"`
+    // Calculators holds the JSON encoded state of each calculator
+    Calculators map[string]string `json:"calculators" bson:"calculators"`
+}
+
+// digest fingerprints the timestamps and closes of points
+func digest(points []resample.Point) string {
+    h := fnv.New64a()
+    var buf [16]byte
+    for _, p := range points {
+        binary.BigEndian.PutUint64(buf[:8], uint64(p.Timestamp.UnixNano()))
+        binary.BigEndian.PutUint64(buf[8:], math.Float64bits(p.Close))
+        h.Write(buf[:])
+    }
+    return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// Result summarizes a pipeline run
+type Result struct {
+    // Points is the number of points the indicators were computed for
+    Points int
+    // Full is set when the history changed or no checkpoint was stored and
+    // the indicators were recomputed from the start
+    Full bool
+    // Latest holds the value of each indicator at the last point
+    Latest map[string]float64
+}
+
+// Pipeline computes the indicators of a price series and stores them under
+// <prefix>/indicators/<name>/ as metric series. Each run resumes from the
+// checkpoint of the previous one and only recomputes the tail.
+type Pipeline struct {
+    storage storage.Storage
+    prefix  string
+    config  Config
+
+    mu sync.Mutex
+}
+
+// NewPipeline creates a new Pipeline for the series under prefix
+func NewPipeline(s storage.Storage, prefix string, config Config) *Pipeline {
+    return &Pipeline{
+        storage: s,
+        prefix:  prefix,
+        config:  config.withDefaults(),
+    }
+}
+
+// Update computes the indicators of points, the full history of the series,
+// and stores the new values
+func (p *Pipeline) Update(ctx context.Context, points []resample.Point) (Result, error) {
+    if err := p.config.Validate(); err != nil {
+        return Result{}, err
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    points = append([]resample.Point(nil), points...)
+    sort.SliceStable(points, func(i, j int) bool {
+        return points[i].Timestamp.Before(points[j].Timestamp)
+    })
+    if len(points) == 0 {
+        return Result{}, nil
+    }
+
+    calcs := p.config.calculators()
+    next, ok, err := p.resume(ctx, calcs, points)
+    if err != nil {
+        return Result{}, err
+    }
+    start, checkpoint := next.Count, next.Checkpoint
+    if !ok {
+        start, checkpoint = 0, time.Time{}
+    }
+    result := Result{
+        Points: len(points) - start,
+        Full:   !ok,
+        Latest: make(map[string]float64),
+    }
+
+    // The state before the last point becomes the next checkpoint. When the
+    // points end at the checkpoint it stays as it is.
+    computed := make(map[string][]models.MetricPoint)
+    for i := start; i < len(points); i++ {
+        if i == len(points)-1 {
+            next = state{
+                Count:       i,
+                Digest:      digest(points[:i]),
+                Calculators: make(map[string]string, len(calcs)),
+            }
+            if i > 0 {
+                next.Checkpoint = points[i-1].Timestamp
+            }
+            for _, c := range calcs {
+                raw, err := json.Marshal(c.calc)
+                if err != nil {
+                    return Result{}, fmt.Errorf("failed to marshal %s state: %w", c.id, err)
+                }
+                next.Calculators[c.id] = string(raw)
+            }
+        }
+        pt := points[i]
+        for _, c := range calcs {
+            for _, out := range c.calc.update(pt.Timestamp, pt.Close) {
+                computed[out.name] = append(computed[out.name], models.MetricPoint{Timestamp: pt.Timestamp, Value: out.value})
+                if i == len(points)-1 {
+                    result.Latest[out.name] = out.value
+                }
+            }
+        }
+    }
+
+    for _, c := range calcs {
+        for name, unit := range c.series {
+            if err := p.store(ctx, name, unit, checkpoint, result.Full, computed[name]); err != nil {
+                return Result{}, err
+            }
+        }
+    }
+    if err := p.storage.Save(ctx, stateKey(p.prefix), next); err != nil {
+        return Result{}, fmt.Errorf("failed to save indicator state: %w", err)
+    }
+    return result, nil
+}
+
+// resume loads the stored checkpoint and restores the calculators from it.
+// It reports false when the checkpoint is missing, was made with other
+// indicators or the points up to it have changed, in which case everything
+// is recomputed from the first point.
+func (p *Pipeline) resume(ctx context.Context, calcs []namedCalculator, points []resample.Point) (state, bool, error) {
+    var st state
+    if err := p.storage.Load(ctx, stateKey(p.prefix), &st); err != nil {
+        if errors.Is(err, storage.ErrNotFound) {
+            return st, false, nil
+        }
+        return st, false, fmt.Errorf("failed to load indicator state: %w", err)
+    }
+
+    if len(st.Calculators) != len(calcs) || st.Count > len(points) || digest(points[:st.Count]) != st.Digest {
+        return st, false, nil
+    }
+    if st.Count > 0 && !points[st.Count-1].Timestamp.Equal(st.Checkpoint) {
+        return st, false, nil
+    }
+    for _, c := range calcs {
+        if _, ok := st.Calculators[c.id]; !ok {
+            return st, false, nil
+        }
+    }
+    for _, c := range calcs {
+        if err := json.Unmarshal([]byte(st.Calculators[c.id]), c.calc); err != nil {
+            return st, false, fmt.Errorf("failed to restore %s state: %w", c.id, err)
+        }
+    }
+    return st, true, nil
+}
+
+// store replaces the values after the checkpoint of the named series with
+// the computed ones, or the whole series after a full run
+func (p *Pipeline) store(ctx context.Context, name, unit string, checkpoint time.Time, full bool, computed []models.MetricPoint) error {
+    key := Key(p.prefix, name)
+    series, err := crawler.LoadSeries(ctx, p.storage, key)
+    if err != nil {
+        return fmt.Errorf("indicator %s: %w", name, err)
+    }
+    series.Metric = name
+    series.Unit = unit
+
+    var kept, dropped []models.MetricPoint
+    for _, pt := range series.Data {
+        if !full && !pt.Timestamp.After(checkpoint) {
+            kept = append(kept, pt)
+        } else {
+            dropped = append(dropped, pt)
+        }
+    }
+    series.Data = kept
+
+    n, err := crawler.AppendSeries(ctx, p.storage, key, series, computed)
+    if err != nil {
+        return fmt.Errorf("indicator %s: %w", name, err)
+    }
+    if len(dropped) == 0 {
+        return nil
+    }
+
+    // Rewrite the files that may still hold dropped values: the whole series
+    // when nothing was appended, and the yearly files of the dropped values
+    series.LastUpdated = time.Now().UTC()
+    series.Data = append(kept, crawler.FreshPoints(series, computed)...)
+    if n == 0 {
+        if err := p.storage.Save(ctx, key+"/latest.json", series); err != nil {
+            return fmt.Errorf("indicator %s: failed to save data: %w", name, err)
+        }
+    }
+    if err := crawler.SaveYearly(ctx, p.storage, key, series, dropped); err != nil {
+        return fmt.Errorf("indicator %s: %w", name, err)
+    }
+    return nil
+} 
\ No newline at end of file
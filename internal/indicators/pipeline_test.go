@@ -0,0 +1,165 @@
+package indicators
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/resample"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+var testConfig = Config{
+    SMA:        []int{5},
+    EMA:        []int{5},
+    RSI:        14,
+    MACD:       &MACDConfig{Fast: 3, Slow: 6, Signal: 3},
+    Bollinger:  &BollingerConfig{Window: 5},
+    Volatility: []int{5},
+    Drawdown:   true,
+    WeeklySMA:  []int{2},
+}
+
+// prices returns n daily points from 1 December 2023, crossing a year
+func prices(n int) []resample.Point {
+    points := make([]resample.Point, n)
+    for i := range points {
+        points[i] = resample.Point{
+            Timestamp: time.Date(2023, 12, 1+i, 0, 0, 0, 0, time.UTC),
+            Close:     100 + 10*math.Sin(float64(i)/3) + float64(i%7),
+        }
+    }
+    return points
+}
+
+// assertSameSeries compares every stored indicator series, including the
+// yearly files, with those of a full recompute
+func assertSameSeries(t *testing.T, got, want storage.Storage, step string) {
+    t.Helper()
+    ctx := context.Background()
+    for _, c := range testConfig.withDefaults().calculators() {
+        for name := range c.series {
+            prefixes := []string{Key("btc", name)}
+            for _, year := range []int{2023, 2024} {
+                prefixes = append(prefixes, fmt.Sprintf("%s/%d/%s-%d.json", Key("btc", name), year, name, year))
+            }
+            for i, prefix := range prefixes {
+                var g, w models.MetricSeries
+                var err error
+                if i == 0 {
+                    g, err = crawler.LoadSeries(ctx, got, prefix)
+                    if err == nil {
+                        w, err = crawler.LoadSeries(ctx, want, prefix)
+                    }
+                } else {
+                    err = got.Load(ctx, prefix, &g)
+                    if err == nil {
+                        err = want.Load(ctx, prefix, &w)
+                    }
+                }
+                if err != nil {
+                    t.Fatalf("%s: %s: %v", step, prefix, err)
+                }
+                if len(g.Data) != len(w.Data) {
+                    t.Errorf("%s: %s has %d points, want %d", step, prefix, len(g.Data), len(w.Data))
+                    continue
+                }
+                for k := range w.Data {
+                    if !g.Data[k].Timestamp.Equal(w.Data[k].Timestamp) || math.Abs(g.Data[k].Value-w.Data[k].Value) > 1e-9 {
+                        t.Errorf("%s: %s[%d] = %+v, want %+v", step, prefix, k, g.Data[k], w.Data[k])
+                        break
+                    }
+                }
+            }
+        }
+    }
+}
+
+// recompute runs the pipeline once over points in fresh storage
+func recompute(t *testing.T, points []resample.Point) storage.Storage {
+    t.Helper()
+    s := storage.NewMemoryStorage()
+    result, err := NewPipeline(s, "btc", testConfig).Update(context.Background(), points)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !result.Full {
+        t.Fatal("the first run must be full")
+    }
+    return s
+}
+
+func TestPipelineIncrementalMatchesFullRecompute(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    p := NewPipeline(s, "btc", testConfig)
+    all := prices(60)
+
+    if _, err := p.Update(ctx, all[:40]); err != nil {
+        t.Fatal(err)
+    }
+
+    // The next crawl revises the provisional last close and adds points
+    points := append([]resample.Point(nil), all[:50]...)
+    points[39].Close += 25
+    result, err := p.Update(ctx, points)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if result.Full || result.Points != 11 {
+        t.Errorf("second run = %+v, want 11 points resumed from the checkpoint", result)
+    }
+    assertSameSeries(t, s, recompute(t, points), "after a revised close")
+
+    // A crawl without new points recomputes the last one only
+    if result, err = p.Update(ctx, points); err != nil {
+        t.Fatal(err)
+    }
+    if result.Full || result.Points != 1 {
+        t.Errorf("unchanged run = %+v, want the last point only", result)
+    }
+    assertSameSeries(t, s, recompute(t, points), "after an unchanged run")
+
+    // Dropping the last point trims the values stored after the checkpoint
+    if _, err := p.Update(ctx, points[:49]); err != nil {
+        t.Fatal(err)
+    }
+    assertSameSeries(t, s, recompute(t, points[:49]), "after the last point was dropped")
+}
+
+func TestPipelineRecomputesChangedHistory(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    p := NewPipeline(s, "btc", testConfig)
+    points := prices(40)
+
+    if _, err := p.Update(ctx, points); err != nil {
+        t.Fatal(err)
+    }
+
+    // A backfilled older value changes the digest of the checkpointed points
+    points[10].Close *= 2
+    result, err := p.Update(ctx, points)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !result.Full || result.Points != len(points) {
+        t.Errorf("run after a history change = %+v, want a full recompute", result)
+    }
+    assertSameSeries(t, s, recompute(t, points), "after a history change")
+
+    // Other indicator settings do not resume from the stored state
+    other := testConfig
+    other.SMA = []int{5, 10}
+    result, err = NewPipeline(s, "btc", other).Update(ctx, points)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !result.Full {
+        t.Error("a configuration change resumed from the old checkpoint")
+    }
+} 
\ No newline at end of file
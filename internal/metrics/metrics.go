@@ -0,0 +1,37 @@
+package metrics
+
+import (
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/collectors"
+    "github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Default is the registry the packages register their metrics with and
+// Handler serves. Besides the module's metrics it exports the Go runtime and
+// process metrics.
+var Default = newRegistry()
+
+func newRegistry() *prometheus.Registry {
+    r := prometheus.NewRegistry()
+    r.MustRegister(
+        collectors.NewGoCollector(),
+        collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+    )
+    return r
+}
+
+// NewCounterVec registers a counter with the Default registry
+func NewCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+    return promauto.With(Default).NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+}
+
+// NewGaugeVec registers a gauge with the Default registry
+func NewGaugeVec(name, help string, labels ...string) *prometheus.GaugeVec {
+    return promauto.With(Default).NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+}
+
+// NewHistogramVec registers a histogram with the Default registry; nil
+// buckets are prometheus.DefBuckets, from 5ms to 10s
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+    return promauto.With(Default).NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+} 
\ No newline at end of file
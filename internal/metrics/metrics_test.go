@@ -0,0 +1,53 @@
+package metrics
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+func TestHandlerExposition(t *testing.T) {
+    runs := NewCounterVec("test_runs_total", "Test runs by outcome", "crawler", "outcome")
+    last := NewGaugeVec("test_last_run_timestamp_seconds", "Unix time of the last test run", "crawler")
+    duration := NewHistogramVec("test_run_duration_seconds", "Duration of test runs", []float64{1, 5}, "crawler")
+
+    runs.WithLabelValues("onchain", "success").Inc()
+    runs.WithLabelValues("onchain", "success").Inc()
+    runs.WithLabelValues("sentiment \"fng\"", "failure").Inc()
+    last.WithLabelValues("onchain").Set(1.7e9)
+    duration.WithLabelValues("onchain").Observe(0.5)
+    duration.WithLabelValues("onchain").Observe(3)
+    duration.WithLabelValues("onchain").Observe(7)
+
+    rec := httptest.NewRecorder()
+    Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+    if rec.Code != http.StatusOK {
+        t.Fatalf("status = %d", rec.Code)
+    }
+    if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+        t.Errorf("Content-Type = %q", ct)
+    }
+
+    body := rec.Body.String()
+    for _, line := range []string{
+        "# HELP test_runs_total Test runs by outcome",
+        "# TYPE test_runs_total counter",
+        `test_runs_total{crawler="onchain",outcome="success"} 2`,
+        `test_runs_total{crawler="sentiment \"fng\"",outcome="failure"} 1`,
+        "# TYPE test_last_run_timestamp_seconds gauge",
+        `test_last_run_timestamp_seconds{crawler="onchain"} 1.7e+09`,
+        "# TYPE test_run_duration_seconds histogram",
+        `test_run_duration_seconds_bucket{crawler="onchain",le="1"} 1`,
+        `test_run_duration_seconds_bucket{crawler="onchain",le="5"} 2`,
+        `test_run_duration_seconds_bucket{crawler="onchain",le="+Inf"} 3`,
+        `test_run_duration_seconds_sum{crawler="onchain"} 10.5`,
+        `test_run_duration_seconds_count{crawler="onchain"} 3`,
+        "# TYPE go_goroutines gauge",
+        "# TYPE process_cpu_seconds_total counter",
+    } {
+        if !strings.Contains(body, line+"\n") {
+            t.Errorf("missing %q", line)
+        }
+    }
+} 
\ No newline at end of file
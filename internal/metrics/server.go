@@ -0,0 +1,54 @@
+package metrics
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Config holds configuration for the metrics endpoint
+type Config struct {
+    // Addr enables the metrics endpoint when set, e.g. :9100
+    Addr string `yaml:"addr"`
+    // Path defaults to /metrics
+    Path string `yaml:"path"`
+}
+
+// Handler serves the Default registry in the exposition format the scraper
+// negotiates
+func Handler() http.Handler {
+    return promhttp.HandlerFor(Default, promhttp.HandlerOpts{})
+}
+
+// ListenAndServe serves the metrics endpoint on the configured address until
+// ctx is done
+func ListenAndServe(ctx context.Context, config Config) error {
+    if config.Path == "" {
+        config.Path = "/metrics"
+    }
+    mux := http.NewServeMux()
+    mux.Handle(config.Path, Handler())
+    srv := &http.Server{
+        Addr:              config.Addr,
+        Handler:           mux,
+        ReadHeaderTimeout: 10 * time.Second,
+    }
+
+    errChan := make(chan error, 1)
+    go func() {
+        errChan <- srv.ListenAndServe()
+    }()
+
+    select {
+    case err := <-errChan:
+        return fmt.Errorf("failed to serve metrics: %w", err)
+    case <-ctx.Done():
+    }
+
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    return srv.Shutdown(shutdownCtx)
+} 
\ No newline at end of file
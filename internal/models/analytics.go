@@ -0,0 +1,26 @@
+package models
+
+import (
+    "time"
+)
+
+// CorrelationMatrix holds the rolling correlations and betas of the returns
+// of a set of assets at one date. Entries are null for pairs that are not
+// configured or lack a full window of common returns.
+type CorrelationMatrix struct {
+    Date time.Time `json:"date" bson:"date"`
+    // Correlations[i][j] correlates the returns of the i-th and j-th asset
+    Correlations [][]*float64 `json:"correlations" bson:"correlations"`
+    // Betas[i][j] is the beta of the i-th asset against the j-th as benchmark
+    Betas [][]*float64 `json:"betas" bson:"betas"`
+}
+
+// CorrelationSeries holds the correlation matrices of one return frequency
+// and rolling window
+type CorrelationSeries struct {
+    LastUpdated time.Time           `json:"last_updated" bson:"last_updated"`
+    Frequency   string              `json:"frequency" bson:"frequency"`
+    Window      int                 `json:"window" bson:"window"`
+    Assets      []string            `json:"assets" bson:"assets"`
+    Matrices    []CorrelationMatrix `json:"matrices" bson:"matrices"`
+} 
\ No newline at end of file
@@ -0,0 +1,25 @@
+package models
+
+import (
+    "time"
+)
+
+// Bar represents an OHLCV bar aggregated from streamed trades
+type Bar struct {
+    Exchange string    `json:"exchange" bson:"exchange"`
+    Symbol   string    `json:"symbol" bson:"symbol"`
+    Start    time.Time `json:"start" bson:"start"`
+    Interval string    `json:"interval" bson:"interval"`
+    Open     float64   `json:"open" bson:"open"`
+    High     float64   `json:"high" bson:"high"`
+    Low      float64   `json:"low" bson:"low"`
+    Close    float64   `json:"close" bson:"close"`
+    Volume   float64   `json:"volume" bson:"volume"`
+    Trades   int       `json:"trades" bson:"trades"`
+    // Source is the channel the bar was built from: trades, or ticker for
+    // bars of ticker prices, which have no volume
+    Source string `json:"source" bson:"source"`
+    // MissedTrades counts trades detected as lost through sequence gaps; the
+    // bar's prices and volume are incomplete when it is not zero
+    MissedTrades int64 `json:"missed_trades" bson:"missed_trades"`
+} 
\ No newline at end of file
@@ -0,0 +1,13 @@
+package models
+
+import (
+    "time"
+)
+
+// BudgetUsage represents the requests made to a host during a calendar month
+type BudgetUsage struct {
+    Host      string    `json:"host" bson:"host"`
+    Month     string    `json:"month" bson:"month"`
+    Requests  int       `json:"requests" bson:"requests"`
+    UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+} 
\ No newline at end of file
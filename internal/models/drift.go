@@ -0,0 +1,21 @@
+package models
+
+import (
+    "time"
+)
+
+// Violation describes a validation rule an upstream response broke
+type Violation struct {
+    Field   string `json:"field" bson:"field"`
+    Rule    string `json:"rule" bson:"rule"`
+    Message string `json:"message" bson:"message"`
+    // Count is how many items broke the rule; Message describes the first
+    Count int `json:"count" bson:"count"`
+}
+
+// DriftEvent records an upstream response that failed validation
+type DriftEvent struct {
+    Source     string      `json:"source" bson:"source"`
+    DetectedAt time.Time   `json:"detected_at" bson:"detected_at"`
+    Violations []Violation `json:"violations" bson:"violations"`
+} 
\ No newline at end of file
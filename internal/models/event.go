@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Kinds of PointsEvent
+const (
+    EventKindPrice = "price"
+    EventKindBar   = "bar"
+)
+
+// PointsEvent announces points newly stored for an asset. Prices are set
+// for price events and Bars for bar events.
+type PointsEvent struct {
+    ID       uint64         `json:"id"`
+    Asset    string         `json:"asset"`
+    Kind     string         `json:"kind"`
+    StoredAt time.Time      `json:"stored_at"`
+    Prices   []BitcoinPrice `json:"prices,omitempty"`
+    Bars     []Bar          `json:"bars,omitempty"`
+} 
\ No newline at end of file
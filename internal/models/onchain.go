@@ -0,0 +1,31 @@
+package models
+
+import (
+    "time"
+)
+
+// MetricPoint represents a single value of a time series metric
+type MetricPoint struct {
+    Timestamp time.Time `json:"timestamp" bson:"timestamp"`
+    Value     float64   `json:"value" bson:"value"`
+}
+
+// MetricSeries represents a stored time series of a single metric
+type MetricSeries struct {
+    LastUpdated time.Time     `json:"last_updated" bson:"last_updated"`
+    Metric      string        `json:"metric" bson:"metric"`
+    Unit        string        `json:"unit" bson:"unit"`
+    Data        []MetricPoint `json:"data" bson:"data"`
+}
+
+// BlockchainChartResponse represents the response from the blockchain.info charts API
+type BlockchainChartResponse struct {
+    Status string `json:"status"`
+    Name   string `json:"name"`
+    Unit   string `json:"unit"`
+    Period string `json:"period"`
+    Values []struct {
+        X float64 `json:"x"`
+        Y float64 `json:"y"`
+    } `json:"values"`
+} 
\ No newline at end of file
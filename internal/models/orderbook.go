@@ -0,0 +1,45 @@
+package models
+
+import (
+    "time"
+)
+
+// OrderBookLevel represents a single price level of an order book side
+type OrderBookLevel struct {
+    Price    float64 `json:"price" bson:"price"`
+    Quantity float64 `json:"quantity" bson:"quantity"`
+}
+
+// OrderBookSnapshot represents the top of an exchange order book at a point in time.
+// Bids are sorted by descending price and asks by ascending price.
+type OrderBookSnapshot struct {
+    Exchange  string           `json:"exchange" bson:"exchange"`
+    Symbol    string           `json:"symbol" bson:"symbol"`
+    Timestamp time.Time        `json:"timestamp" bson:"timestamp"`
+    Sequence  int64            `json:"sequence,omitempty" bson:"sequence,omitempty"`
+    Bids      []OrderBookLevel `json:"bids" bson:"bids"`
+    Asks      []OrderBookLevel `json:"asks" bson:"asks"`
+}
+
+// BinanceDepthResponse represents the response from the Binance depth endpoint
+type BinanceDepthResponse struct {
+    LastUpdateID int64           `json:"lastUpdateId"`
+    Bids         [][]interface{} `json:"bids"`
+    Asks         [][]interface{} `json:"asks"`
+}
+
+// CoinbaseBookResponse represents the response from the Coinbase product book endpoint
+type CoinbaseBookResponse struct {
+    Sequence int64           `json:"sequence"`
+    Bids     [][]interface{} `json:"bids"`
+    Asks     [][]interface{} `json:"asks"`
+}
+
+// KrakenDepthResponse represents the response from the Kraken depth endpoint
+type KrakenDepthResponse struct {
+    Error  []string `json:"error"`
+    Result map[string]struct {
+        Bids [][]interface{} `json:"bids"`
+        Asks [][]interface{} `json:"asks"`
+    } `json:"result"`
+} 
\ No newline at end of file
@@ -0,0 +1,30 @@
+package models
+
+import (
+    "time"
+)
+
+// QualityIssue is a single problem found in a stored series
+type QualityIssue struct {
+    Check     string    `json:"check" bson:"check"`
+    Timestamp time.Time `json:"timestamp" bson:"timestamp"`
+    Value     float64   `json:"value" bson:"value"`
+    Message   string    `json:"message" bson:"message"`
+    // New is set for issues in the batch being stored
+    New bool `json:"new" bson:"new"`
+}
+
+// QualityReport summarizes the data quality of an asset's series
+type QualityReport struct {
+    Asset     string    `json:"asset" bson:"asset"`
+    CheckedAt time.Time `json:"checked_at" bson:"checked_at"`
+    Points    int       `json:"points" bson:"points"`
+    From      time.Time `json:"from" bson:"from"`
+    To        time.Time `json:"to" bson:"to"`
+    // Counts holds the number of issues per check
+    Counts    map[string]int `json:"counts" bson:"counts"`
+    NewIssues int            `json:"new_issues" bson:"new_issues"`
+    // Issues lists the first issues found, new ones first
+    Issues []QualityIssue `json:"issues" bson:"issues"`
+    Action string         `json:"action" bson:"action"`
+} 
\ No newline at end of file
@@ -0,0 +1,34 @@
+package models
+
+import (
+    "time"
+)
+
+// ReconciledPoint compares the sources of an asset at one timestamp
+type ReconciledPoint struct {
+    Timestamp time.Time `json:"timestamp" bson:"timestamp"`
+    Consensus float64   `json:"consensus" bson:"consensus"`
+    // Values and Deviations are keyed by source; deviations are relative to the consensus
+    Values     map[string]float64 `json:"values" bson:"values"`
+    Deviations map[string]float64 `json:"deviations" bson:"deviations"`
+    // Flagged lists the sources deviating beyond the threshold
+    Flagged []string `json:"flagged,omitempty" bson:"flagged,omitempty"`
+}
+
+// SourceDeviation summarizes how far a source strays from the consensus
+type SourceDeviation struct {
+    Points           int     `json:"points" bson:"points"`
+    Flagged          int     `json:"flagged" bson:"flagged"`
+    MeanAbsDeviation float64 `json:"mean_abs_deviation" bson:"mean_abs_deviation"`
+    MaxAbsDeviation  float64 `json:"max_abs_deviation" bson:"max_abs_deviation"`
+}
+
+// ReconciliationReport holds the per-timestamp comparison of an asset's sources
+type ReconciliationReport struct {
+    LastUpdated time.Time                  `json:"last_updated" bson:"last_updated"`
+    Method      string                     `json:"method" bson:"method"`
+    Threshold   float64                    `json:"threshold" bson:"threshold"`
+    Sources     []string                   `json:"sources" bson:"sources"`
+    Stats       map[string]SourceDeviation `json:"stats" bson:"stats"`
+    Points      []ReconciledPoint          `json:"points" bson:"points"`
+} 
\ No newline at end of file
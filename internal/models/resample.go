@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// AggregateBar summarizes one calendar period of a series: OHLC from the
+// closes, the summed volume and the last market cap
+type AggregateBar struct {
+    Start     time.Time `json:"start" bson:"start"`
+    End       time.Time `json:"end" bson:"end"`
+    Open      float64   `json:"open" bson:"open"`
+    High      float64   `json:"high" bson:"high"`
+    Low       float64   `json:"low" bson:"low"`
+    Close     float64   `json:"close" bson:"close"`
+    Volume    float64   `json:"volume,omitempty" bson:"volume,omitempty"`
+    MarketCap float64   `json:"market_cap,omitempty" bson:"market_cap,omitempty"`
+    // Count is the number of points in the period
+    Count int `json:"count" bson:"count"`
+}
+
+// AggregateSeries is a series resampled to one period
+type AggregateSeries struct {
+    LastUpdated time.Time      `json:"last_updated" bson:"last_updated"`
+    Metric      string         `json:"metric" bson:"metric"`
+    Period      string         `json:"period" bson:"period"`
+    Timezone    string         `json:"timezone" bson:"timezone"`
+    Bars        []AggregateBar `json:"bars" bson:"bars"`
+} 
\ No newline at end of file
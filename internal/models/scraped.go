@@ -0,0 +1,19 @@
+package models
+
+import (
+    "time"
+)
+
+// Record represents a single typed record extracted from a web page
+type Record struct {
+    URL       string                 `json:"url" bson:"url"`
+    ScrapedAt time.Time              `json:"scraped_at" bson:"scraped_at"`
+    Fields    map[string]interface{} `json:"fields" bson:"fields"`
+}
+
+// ScrapedData represents the records extracted from a site in one crawl
+type ScrapedData struct {
+    LastUpdated time.Time `json:"last_updated" bson:"last_updated"`
+    Site        string    `json:"site" bson:"site"`
+    Records     []Record  `json:"records" bson:"records"`
+} 
\ No newline at end of file
@@ -0,0 +1,32 @@
+package models
+
+import (
+    "time"
+)
+
+// SentimentValue represents a single daily reading of a sentiment index
+type SentimentValue struct {
+    Timestamp      time.Time `json:"timestamp" bson:"timestamp"`
+    Value          int       `json:"value" bson:"value"`
+    Classification string    `json:"classification" bson:"classification"`
+}
+
+// SentimentData represents a collection of sentiment index readings
+type SentimentData struct {
+    LastUpdated time.Time        `json:"last_updated" bson:"last_updated"`
+    Index       string           `json:"index" bson:"index"`
+    Data        []SentimentValue `json:"data" bson:"data"`
+}
+
+// FearGreedResponse represents the response from the Alternative.me Fear & Greed API
+type FearGreedResponse struct {
+    Name string `json:"name"`
+    Data []struct {
+        Value               string `json:"value"`
+        ValueClassification string `json:"value_classification"`
+        Timestamp           string `json:"timestamp"`
+    } `json:"data"`
+    Metadata struct {
+        Error *string `json:"error"`
+    } `json:"metadata"`
+} 
\ No newline at end of file
@@ -0,0 +1,109 @@
+package notify
+
+import (
+    "bytes"
+    "context"
+    "crypto/tls"
+    "fmt"
+    "mime"
+    "net"
+    "net/smtp"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// SMTPConfig holds the mail server settings of an email sink
+type SMTPConfig struct {
+    Host string `yaml:"host"`
+    // Port defaults to 587
+    Port int `yaml:"port"`
+    // Username and Password enable PLAIN authentication, which needs TLS
+    // unless the server is on localhost
+    Username string   `yaml:"username"`
+    Password string   `yaml:"password"`
+    From     string   `yaml:"from"`
+    To       []string `yaml:"to"`
+    // InsecureSkipVerify accepts any certificate, e.g. for a local relay
+    InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+}
+
+func (c SMTPConfig) validate() error {
+    if c.Host == "" || c.From == "" || len(c.To) == 0 {
+        return fmt.Errorf("smtp host, from and to are required")
+    }
+    return nil
+}
+
+// sendMail sends msg as a plain text email, upgrading the connection with
+// STARTTLS when the server offers it
+func sendMail(ctx context.Context, cfg SMTPConfig, msg Message) error {
+    port := cfg.Port
+    if port == 0 {
+        port = 587
+    }
+    addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+
+    var dialer net.Dialer
+    conn, err := dialer.DialContext(ctx, "tcp", addr)
+    if err != nil {
+        return fmt.Errorf("failed to connect to %s: %w", addr, err)
+    }
+    if deadline, ok := ctx.Deadline(); ok {
+        conn.SetDeadline(deadline)
+    }
+    client, err := smtp.NewClient(conn, cfg.Host)
+    if err != nil {
+        conn.Close()
+        return fmt.Errorf("failed to start smtp session: %w", err)
+    }
+    defer client.Close()
+
+    if ok, _ := client.Extension("STARTTLS"); ok {
+        tlsConfig := &tls.Config{ServerName: cfg.Host, InsecureSkipVerify: cfg.InsecureSkipVerify}
+        if err := client.StartTLS(tlsConfig); err != nil {
+            return fmt.Errorf("failed to start tls: %w", err)
+        }
+    }
+    if cfg.Username != "" {
+        if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+            return fmt.Errorf("failed to authenticate: %w", err)
+        }
+    }
+
+    if err := client.Mail(cfg.From); err != nil {
+        return fmt.Errorf("failed to set sender: %w", err)
+    }
+    for _, to := range cfg.To {
+        if err := client.Rcpt(to); err != nil {
+            return fmt.Errorf("failed to add recipient %s: %w", to, err)
+        }
+    }
+    w, err := client.Data()
+    if err != nil {
+        return fmt.Errorf("failed to start message: %w", err)
+    }
+    if _, err := w.Write(mailBody(cfg, msg)); err != nil {
+        return fmt.Errorf("failed to write message: %w", err)
+    }
+    if err := w.Close(); err != nil {
+        return fmt.Errorf("failed to send message: %w", err)
+    }
+    return client.Quit()
+}
+
+// mailBody formats the headers and the text of msg
+func mailBody(cfg SMTPConfig, msg Message) []byte {
+    var b bytes.Buffer
+    fmt.Fprintf(&b, "From: %s\r\n", cfg.From)
+    fmt.Fprintf(&b, "To: %s\r\n", strings.Join(cfg.To, ", "))
+    fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+    fmt.Fprintf(&b, "Date: %s\r\n", msg.Time.Format(time.RFC1123Z))
+    b.WriteString("MIME-Version: 1.0\r\n")
+    b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+    b.WriteString("\r\n")
+    // Bare line feeds are not allowed in SMTP
+    b.WriteString(strings.ReplaceAll(msg.body(), "\n", "\r\n"))
+    b.WriteString("\r\n")
+    return b.Bytes()
+} 
\ No newline at end of file
@@ -0,0 +1,200 @@
+package notify
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+// Sink types supported by SinkConfig.Type
+const (
+    SinkWebhook = "webhook"
+    SinkSlack   = "slack"
+    SinkEmail   = "email"
+)
+
+// Message is a notification sent to the sinks
+type Message struct {
+    // Subject is a one-line summary
+    Subject string `json:"subject"`
+    Text    string `json:"text"`
+    // Fields holds structured details, e.g. the asset and the value
+    Fields map[string]interface{} `json:"fields,omitempty"`
+    Time   time.Time              `json:"time"`
+}
+
+// body returns the text followed by the fields, one per line
+func (m Message) body() string {
+    var b strings.Builder
+    b.WriteString(m.Text)
+    keys := make([]string, 0, len(m.Fields))
+    for k := range m.Fields {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    if len(keys) > 0 {
+        b.WriteString("\n")
+    }
+    for _, k := range keys {
+        fmt.Fprintf(&b, "\n%s: %v", k, m.Fields[k])
+    }
+    return b.String()
+}
+
+// SinkConfig declares a destination for notifications
+type SinkConfig struct {
+    // Name is used by rules to pick their sinks
+    Name string `yaml:"name"`
+    // Type is webhook (a JSON POST of the message), slack (a Slack
+    // compatible incoming webhook) or email
+    Type string `yaml:"type"`
+    // URL of the webhook
+    URL string `yaml:"url"`
+    // Headers are added to webhook requests, e.g. an Authorization header
+    Headers map[string]string `yaml:"headers"`
+    // Channel and Username override the defaults of a Slack webhook
+    Channel  string `yaml:"channel"`
+    Username string `yaml:"username"`
+    // SMTP holds the mail server settings of an email sink
+    SMTP SMTPConfig `yaml:"smtp"`
+}
+
+// Config holds the notification sinks
+type Config struct {
+    Sinks []SinkConfig `yaml:"sinks"`
+    // Timeout bounds the delivery to each sink, defaults to 10s
+    Timeout time.Duration `yaml:"timeout"`
+}
+
+// Validate checks the sinks for missing or unknown settings
+func (c Config) Validate() error {
+    seen := make(map[string]bool, len(c.Sinks))
+    for _, s := range c.Sinks {
+        if s.Name == "" {
+            return fmt.Errorf("notify: sink name is required")
+        }
+        if seen[s.Name] {
+            return fmt.Errorf("notify: sink %s is declared twice", s.Name)
+        }
+        seen[s.Name] = true
+        switch s.Type {
+        case SinkWebhook, SinkSlack:
+            if s.URL == "" {
+                return fmt.Errorf("notify: sink %s: url is required", s.Name)
+            }
+        case SinkEmail:
+            if err := s.SMTP.validate(); err != nil {
+                return fmt.Errorf("notify: sink %s: %w", s.Name, err)
+            }
+        default:
+            return fmt.Errorf("notify: sink %s has unknown type %q", s.Name, s.Type)
+        }
+    }
+    return nil
+}
+
+// Has reports whether a sink is named name
+func (c Config) Has(name string) bool {
+    for _, s := range c.Sinks {
+        if s.Name == name {
+            return true
+        }
+    }
+    return false
+}
+
+// sender delivers a message to one sink
+type sender func(ctx context.Context, msg Message) error
+
+// Notifier delivers messages to the configured sinks
+type Notifier struct {
+    config  Config
+    senders map[string]sender
+}
+
+// New creates a new Notifier. Webhooks are sent with a client of their own,
+// so they do not go through the proxies and rate limits of the crawlers.
+// They are not retried either, since a failed POST may still have been
+// delivered; callers try the failed sinks again at their next delivery.
+func New(config Config) (*Notifier, error) {
+    if err := config.Validate(); err != nil {
+        return nil, err
+    }
+    if config.Timeout <= 0 {
+        config.Timeout = 10 * time.Second
+    }
+    client := httpclient.New(httpclient.Config{Timeout: config.Timeout, MaxRetries: -1})
+    n := &Notifier{
+        config:  config,
+        senders: make(map[string]sender, len(config.Sinks)),
+    }
+    for _, s := range config.Sinks {
+        s := s
+        switch s.Type {
+        case SinkWebhook:
+            n.senders[s.Name] = func(ctx context.Context, msg Message) error {
+                return postJSON(ctx, client, s.URL, s.Headers, msg)
+            }
+        case SinkSlack:
+            n.senders[s.Name] = func(ctx context.Context, msg Message) error {
+                return postJSON(ctx, client, s.URL, s.Headers, slackMessage(s, msg))
+            }
+        case SinkEmail:
+            n.senders[s.Name] = func(ctx context.Context, msg Message) error {
+                return sendMail(ctx, s.SMTP, msg)
+            }
+        }
+    }
+    return n, nil
+}
+
+// Notify delivers msg to the named sinks, or to every sink when no name is
+// given. A failing sink does not keep the message from the others.
+func (n *Notifier) Notify(ctx context.Context, msg Message, sinks ...string) error {
+    _, err := n.NotifyEach(ctx, msg, sinks...)
+    return err
+}
+
+// NotifyEach delivers msg like Notify and returns the sinks it was
+// delivered to, so that a retry can skip them
+func (n *Notifier) NotifyEach(ctx context.Context, msg Message, sinks ...string) ([]string, error) {
+    if msg.Time.IsZero() {
+        msg.Time = time.Now().UTC()
+    }
+    if len(sinks) == 0 {
+        sinks = n.Sinks()
+    }
+
+    var delivered []string
+    var errs []error
+    for _, name := range sinks {
+        send, ok := n.senders[name]
+        if !ok {
+            errs = append(errs, fmt.Errorf("unknown sink %s", name))
+            continue
+        }
+        sendCtx, cancel := context.WithTimeout(ctx, n.config.Timeout)
+        err := send(sendCtx, msg)
+        cancel()
+        if err != nil {
+            errs = append(errs, fmt.Errorf("sink %s: %w", name, err))
+            continue
+        }
+        delivered = append(delivered, name)
+    }
+    return delivered, errors.Join(errs...)
+}
+
+// Sinks returns the names of every sink
+func (n *Notifier) Sinks() []string {
+    names := make([]string, 0, len(n.config.Sinks))
+    for _, s := range n.config.Sinks {
+        names = append(names, s.Name)
+    }
+    return names
+} 
\ No newline at end of file
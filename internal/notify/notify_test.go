@@ -0,0 +1,178 @@
+package notify
+
+import (
+    "bufio"
+    "context"
+    "encoding/json"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "strconv"
+    "strings"
+    "sync"
+    "testing"
+)
+
+// smtpServer is a minimal SMTP stand-in that records the messages it accepts
+type smtpServer struct {
+    addr string
+
+    mu       sync.Mutex
+    messages []string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+    l, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { l.Close() })
+    s := &smtpServer{addr: l.Addr().String()}
+    go func() {
+        for {
+            conn, err := l.Accept()
+            if err != nil {
+                return
+            }
+            go s.serve(conn)
+        }
+    }()
+    return s
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+    defer conn.Close()
+    r := bufio.NewReader(conn)
+    reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+    reply("220 localhost ESMTP")
+    for {
+        line, err := r.ReadString('\n')
+        if err != nil {
+            return
+        }
+        cmd := strings.ToUpper(strings.TrimSpace(line))
+        switch {
+        case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+            reply("250 localhost")
+        case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+            reply("250 OK")
+        case cmd == "DATA":
+            reply("354 End data with <CR><LF>.<CR><LF>")
+            var data strings.Builder
+            for {
+                line, err := r.ReadString('\n')
+                if err != nil {
+                    return
+                }
+                if line == ".\r\n" {
+                    break
+                }
+                data.WriteString(line)
+            }
+            s.mu.Lock()
+            s.messages = append(s.messages, data.String())
+            s.mu.Unlock()
+            reply("250 OK")
+        case cmd == "QUIT":
+            reply("221 Bye")
+            return
+        default:
+            reply("502 Command not implemented")
+        }
+    }
+}
+
+func (s *smtpServer) received() []string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return append([]string(nil), s.messages...)
+}
+
+func TestNotifyDeliversToEverySink(t *testing.T) {
+    var mu sync.Mutex
+    bodies := make(map[string]map[string]interface{})
+    handler := func(name string) http.HandlerFunc {
+        return func(w http.ResponseWriter, r *http.Request) {
+            var body map[string]interface{}
+            if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+                t.Errorf("%s: %v", name, err)
+            }
+            if r.Header.Get("Authorization") != "Bearer secret" && name == "webhook" {
+                t.Errorf("webhook: missing Authorization header")
+            }
+            mu.Lock()
+            bodies[name] = body
+            mu.Unlock()
+        }
+    }
+    webhook := httptest.NewServer(handler("webhook"))
+    defer webhook.Close()
+    slack := httptest.NewServer(handler("slack"))
+    defer slack.Close()
+    smtpSrv := newSMTPServer(t)
+    host, port, _ := net.SplitHostPort(smtpSrv.addr)
+    portNum, _ := strconv.Atoi(port)
+
+    n, err := New(Config{Sinks: []SinkConfig{
+        {Name: "hook", Type: SinkWebhook, URL: webhook.URL, Headers: map[string]string{"Authorization": "Bearer secret"}},
+        {Name: "chat", Type: SinkSlack, URL: slack.URL, Channel: "#alerts"},
+        {Name: "mail", Type: SinkEmail, SMTP: SMTPConfig{Host: host, Port: portNum, From: "crawler@localhost", To: []string{"ops@localhost"}}},
+    }})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    msg := Message{Subject: "bitcoin above 100000", Text: "crossed", Fields: map[string]interface{}{"value": 100500}}
+    delivered, err := n.NotifyEach(context.Background(), msg)
+    if err != nil {
+        t.Fatalf("NotifyEach: %v", err)
+    }
+    if strings.Join(delivered, ",") != "hook,chat,mail" {
+        t.Errorf("delivered to %v", delivered)
+    }
+
+    if got := bodies["webhook"]["subject"]; got != msg.Subject {
+        t.Errorf("webhook subject = %v", got)
+    }
+    if got := bodies["slack"]["channel"]; got != "#alerts" {
+        t.Errorf("slack channel = %v", got)
+    }
+    if got, _ := bodies["slack"]["text"].(string); !strings.HasPrefix(got, "*bitcoin above 100000*") {
+        t.Errorf("slack text = %q", got)
+    }
+    mails := smtpSrv.received()
+    if len(mails) != 1 || !strings.Contains(mails[0], "Subject: bitcoin above 100000") || !strings.Contains(mails[0], "value: 100500") {
+        t.Errorf("mails = %q", mails)
+    }
+}
+
+func TestNotifyDoesNotRetryFailedWebhook(t *testing.T) {
+    var calls int
+    failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        calls++
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }))
+    defer failing.Close()
+    ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+    defer ok.Close()
+
+    n, err := New(Config{Sinks: []SinkConfig{
+        {Name: "down", Type: SinkWebhook, URL: failing.URL},
+        {Name: "up", Type: SinkWebhook, URL: ok.URL},
+    }})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    delivered, err := n.NotifyEach(context.Background(), Message{Subject: "test"})
+    if err == nil || !strings.Contains(err.Error(), "sink down") {
+        t.Errorf("err = %v, want the failure of sink down", err)
+    }
+    if len(delivered) != 1 || delivered[0] != "up" {
+        t.Errorf("delivered to %v, want [up]", delivered)
+    }
+    if calls != 1 {
+        t.Errorf("failed webhook was posted %d times, want 1", calls)
+    }
+} 
\ No newline at end of file
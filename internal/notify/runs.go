@@ -0,0 +1,195 @@
+package notify
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/ratelimit"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// RunConfig selects the crawler and collector run outcomes that are notified
+type RunConfig struct {
+    Config `yaml:",inline"`
+    // OnFailure notifies every failed run
+    OnFailure bool `yaml:"on_failure"`
+    // AfterFailures notifies once when a run fails this many times in a row,
+    // 0 disables it
+    AfterFailures int `yaml:"after_failures"`
+    // OnRecovery notifies the first successful run after a notified failure
+    OnRecovery bool `yaml:"on_recovery"`
+}
+
+// Validate checks the sinks and the thresholds
+func (c RunConfig) Validate() error {
+    if err := c.Config.Validate(); err != nil {
+        return err
+    }
+    if c.AfterFailures < 0 {
+        return fmt.Errorf("notify: after_failures must not be negative")
+    }
+    return nil
+}
+
+// RunState is what is remembered of a crawler's runs between invocations
+type RunState struct {
+    // Failures counts the failed runs since the last successful one
+    Failures    int       `json:"failures" bson:"failures"`
+    LastError   string    `json:"last_error" bson:"last_error"`
+    LastFailure time.Time `json:"last_failure" bson:"last_failure"`
+    LastSuccess time.Time `json:"last_success" bson:"last_success"`
+    // Notified reports whether a failure of the current streak was notified
+    Notified bool `json:"notified" bson:"notified"`
+}
+
+// RunNotifier notifies the sinks of failed and recovered runs. The failure
+// streak of each run is kept in storage, so that the binaries running a
+// single crawler from cron count the failures of earlier invocations.
+type RunNotifier struct {
+    storage  storage.Storage
+    config   RunConfig
+    notifier *Notifier
+
+    mu sync.Mutex
+}
+
+// NewRunNotifier creates a new RunNotifier keeping its state in s. It returns
+// nil when no sink is configured; Record on a nil RunNotifier does nothing.
+func NewRunNotifier(s storage.Storage, config RunConfig) (*RunNotifier, error) {
+    if err := config.Validate(); err != nil {
+        return nil, err
+    }
+    if len(config.Sinks) == 0 {
+        return nil, nil
+    }
+    notifier, err := New(config.Config)
+    if err != nil {
+        return nil, err
+    }
+    return &RunNotifier{
+        storage:  s,
+        config:   config,
+        notifier: notifier,
+    }, nil
+}
+
+func runKey(name string) string {
+    return fmt.Sprintf("runs/%s", name)
+}
+
+// Record records the outcome of a run of name that took duration, runErr
+// being nil for a successful run, and sends the notifications it calls for.
+// A run stopped by an exhausted request budget neither fails nor ends the
+// streak.
+func (r *RunNotifier) Record(ctx context.Context, name string, duration time.Duration, runErr error) error {
+    if r == nil || errors.Is(runErr, ratelimit.ErrBudgetExhausted) {
+        return nil
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    var st RunState
+    if err := r.storage.Load(ctx, runKey(name), &st); err != nil && !errors.Is(err, storage.ErrNotFound) {
+        return fmt.Errorf("failed to load run state: %w", err)
+    }
+
+    now := time.Now().UTC()
+    var msg *Message
+    if runErr == nil {
+        if st.Notified && r.config.OnRecovery {
+            msg = recoveryMessage(name, duration, st)
+        }
+        st = RunState{LastSuccess: now}
+    } else {
+        st.Failures++
+        st.LastError = runErr.Error()
+        st.LastFailure = now
+        // A streak is notified once, or again on the next run when sending
+        // failed
+        threshold := r.config.AfterFailures > 0 && st.Failures >= r.config.AfterFailures && !st.Notified
+        if r.config.OnFailure || threshold {
+            msg = failureMessage(name, duration, runErr, st)
+        }
+    }
+
+    var errs []error
+    if msg != nil {
+        if err := r.notifier.Notify(ctx, *msg); err != nil {
+            errs = append(errs, err)
+        } else {
+            log.Printf("Sent run notification: %s", msg.Subject)
+            if runErr != nil {
+                st.Notified = true
+            }
+        }
+    }
+    if err := r.storage.Save(ctx, runKey(name), st); err != nil {
+        errs = append(errs, fmt.Errorf("failed to save run state: %w", err))
+    }
+    return errors.Join(errs...)
+}
+
+// failureMessage describes a failed run
+func failureMessage(name string, duration time.Duration, err error, st RunState) *Message {
+    subject := fmt.Sprintf("%s failed", name)
+    if st.Failures > 1 {
+        subject = fmt.Sprintf("%s failed %d times in a row", name, st.Failures)
+    }
+    fields := map[string]interface{}{
+        "crawler":              name,
+        "duration":             duration.Round(time.Millisecond).String(),
+        "consecutive_failures": st.Failures,
+        "error_chain":          errorChain(err),
+    }
+    if !st.LastSuccess.IsZero() {
+        fields["last_success"] = st.LastSuccess.Format(time.RFC3339)
+    }
+    return &Message{
+        Subject: subject,
+        Text:    err.Error(),
+        Fields:  fields,
+    }
+}
+
+// recoveryMessage describes the successful run ending a failure streak
+func recoveryMessage(name string, duration time.Duration, st RunState) *Message {
+    return &Message{
+        Subject: fmt.Sprintf("%s recovered after %d failed runs", name, st.Failures),
+        Text:    fmt.Sprintf("%s completed successfully. The last failure was: %s", name, st.LastError),
+        Fields: map[string]interface{}{
+            "crawler":      name,
+            "duration":     duration.Round(time.Millisecond).String(),
+            "failures":     st.Failures,
+            "last_failure": st.LastFailure.Format(time.RFC3339),
+        },
+    }
+}
+
+// errorChain lists the messages of err and of the errors it wraps,
+// outermost first. Joined errors are listed depth-first.
+func errorChain(err error) []string {
+    var chain []string
+    var walk func(error)
+    walk = func(err error) {
+        for err != nil {
+            chain = append(chain, fmt.Sprintf("%s (%T)", err.Error(), err))
+            switch u := err.(type) {
+            case interface{ Unwrap() []error }:
+                for _, e := range u.Unwrap() {
+                    walk(e)
+                }
+                return
+            case interface{ Unwrap() error }:
+                err = u.Unwrap()
+            default:
+                return
+            }
+        }
+    }
+    walk(err)
+    return chain
+} 
\ No newline at end of file
@@ -0,0 +1,84 @@
+package notify
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/ratelimit"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+func TestRunNotifierThreshold(t *testing.T) {
+    var mu sync.Mutex
+    var subjects []string
+    down := true
+    webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        mu.Lock()
+        defer mu.Unlock()
+        if down {
+            w.WriteHeader(http.StatusServiceUnavailable)
+            return
+        }
+        var msg Message
+        json.NewDecoder(r.Body).Decode(&msg)
+        subjects = append(subjects, msg.Subject)
+    }))
+    defer webhook.Close()
+
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    runs, err := NewRunNotifier(s, RunConfig{
+        Config:        Config{Sinks: []SinkConfig{{Name: "oncall", Type: SinkWebhook, URL: webhook.URL}}},
+        AfterFailures: 2,
+        OnRecovery:    true,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    failed := errors.New("upstream returned 500")
+    paused := &ratelimit.BudgetError{Host: "api.example.com", Limit: 10, ResetAt: time.Now().Add(time.Hour)}
+    steps := []struct {
+        err      error
+        down     bool
+        failures int
+        sent     int
+    }{
+        {failed, false, 1, 0},
+        // The threshold is reached but the sink is down
+        {failed, true, 2, 0},
+        // The next failure sends the missed notification
+        {failed, false, 3, 1},
+        {failed, false, 4, 1},
+        // A budget pause leaves the streak alone
+        {paused, false, 4, 1},
+        {nil, false, 0, 2},
+    }
+    for i, step := range steps {
+        mu.Lock()
+        down = step.down
+        mu.Unlock()
+        err := runs.Record(ctx, "onchain", time.Second, step.err)
+        if step.down != (err != nil) {
+            t.Errorf("step %d: Record = %v", i, err)
+        }
+
+        var st RunState
+        s.Load(ctx, runKey("onchain"), &st)
+        mu.Lock()
+        sent := len(subjects)
+        mu.Unlock()
+        if st.Failures != step.failures || sent != step.sent {
+            t.Errorf("step %d: %d failures and %d notifications, want %d and %d", i, st.Failures, sent, step.failures, step.sent)
+        }
+    }
+    if len(subjects) == 2 && (subjects[0] != "onchain failed 3 times in a row" || subjects[1] != "onchain recovered after 4 failed runs") {
+        t.Errorf("subjects = %q", subjects)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,57 @@
+package notify
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+// slackPayload is the body of a Slack incoming webhook
+type slackPayload struct {
+    Text     string `json:"text"`
+    Channel  string `json:"channel,omitempty"`
+    Username string `json:"username,omitempty"`
+}
+
+// slackMessage formats msg for a Slack compatible webhook, with the subject
+// in bold
+func slackMessage(s SinkConfig, msg Message) slackPayload {
+    return slackPayload{
+        Text:     fmt.Sprintf("*%s*\n%s", msg.Subject, msg.body()),
+        Channel:  s.Channel,
+        Username: s.Username,
+    }
+}
+
+// postJSON posts v as JSON to url and expects a 2xx response
+func postJSON(ctx context.Context, client *httpclient.Client, url string, headers map[string]string, v interface{}) error {
+    body, err := json.Marshal(v)
+    if err != nil {
+        return fmt.Errorf("failed to marshal message: %w", err)
+    }
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+    if err != nil {
+        return fmt.Errorf("failed to create request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+    for k, v := range headers {
+        req.Header.Set(k, v)
+    }
+
+    resp, err := client.Do(req)
+    if err != nil {
+        return fmt.Errorf("failed to post message: %w", err)
+    }
+    defer resp.Body.Close()
+    io.Copy(io.Discard, resp.Body)
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return &httpclient.StatusError{StatusCode: resp.StatusCode, URL: req.URL.Redacted()}
+    }
+    return nil
+} 
\ No newline at end of file
@@ -0,0 +1,197 @@
+package proxy
+
+import (
+    "context"
+    "errors"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+// fakeProxy answers every proxied request itself with its name, or with
+// status when set
+type fakeProxy struct {
+    *httptest.Server
+    name   string
+    status atomic.Int32
+    hits   atomic.Int32
+}
+
+func newFakeProxy(t *testing.T, name string) *fakeProxy {
+    p := &fakeProxy{name: name}
+    p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        p.hits.Add(1)
+        if code := int(p.status.Load()); code != 0 {
+            w.WriteHeader(code)
+            return
+        }
+        w.Write([]byte(p.name))
+    }))
+    t.Cleanup(p.Close)
+    return p
+}
+
+func newTestPool(t *testing.T, cfg Config, proxies ...*fakeProxy) *Pool {
+    t.Helper()
+    for _, p := range proxies {
+        cfg.URLs = append(cfg.URLs, p.URL)
+    }
+    pool, err := NewPool(cfg)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return pool
+}
+
+// get sends a request through the pool and returns the proxy that answered
+func get(t *testing.T, pool *Pool, ctx context.Context) (string, int) {
+    t.Helper()
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://upstream.test/data", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp, err := pool.RoundTrip(req)
+    if err != nil {
+        t.Fatalf("RoundTrip: %v", err)
+    }
+    defer resp.Body.Close()
+    body, _ := io.ReadAll(resp.Body)
+    return string(body), resp.StatusCode
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+    a, b, c := newFakeProxy(t, "a"), newFakeProxy(t, "b"), newFakeProxy(t, "c")
+    pool := newTestPool(t, Config{}, a, b, c)
+
+    var got string
+    for i := 0; i < 6; i++ {
+        name, _ := get(t, pool, context.Background())
+        got += name
+    }
+    if got != "abcabc" {
+        t.Errorf("proxies used %q, want abcabc", got)
+    }
+}
+
+func TestPoolFailsOverAndEvicts(t *testing.T) {
+    a, b, c := newFakeProxy(t, "a"), newFakeProxy(t, "b"), newFakeProxy(t, "c")
+    a.status.Store(http.StatusForbidden)
+    pool := newTestPool(t, Config{MaxFailures: 2}, a, b, c)
+
+    // a is banned, so the same request is answered by the next proxy
+    if name, code := get(t, pool, context.Background()); name != "b" || code != http.StatusOK {
+        t.Errorf("first request answered by %q with %d, want b", name, code)
+    }
+    get(t, pool, context.Background())
+    if name, _ := get(t, pool, context.Background()); name != "b" {
+        t.Errorf("third request answered by %q, want b after a fails again", name)
+    }
+    if hits := a.hits.Load(); hits != 2 {
+        t.Fatalf("a was tried %d times, want 2", hits)
+    }
+
+    // a is now evicted and no longer tried
+    for i := 0; i < 4; i++ {
+        get(t, pool, context.Background())
+    }
+    if hits := a.hits.Load(); hits != 2 {
+        t.Errorf("a was tried %d times after eviction", hits)
+    }
+}
+
+func TestPoolProxyAuthFailover(t *testing.T) {
+    a, b := newFakeProxy(t, "a"), newFakeProxy(t, "b")
+    a.status.Store(http.StatusProxyAuthRequired)
+    pool := newTestPool(t, Config{}, a, b)
+
+    if name, _ := get(t, pool, context.Background()); name != "b" {
+        t.Errorf("answered by %q, want b after a 407", name)
+    }
+}
+
+func TestPoolAllBanned(t *testing.T) {
+    a, b := newFakeProxy(t, "a"), newFakeProxy(t, "b")
+    a.status.Store(http.StatusForbidden)
+    b.status.Store(http.StatusForbidden)
+    pool := newTestPool(t, Config{MaxFailures: 1}, a, b)
+
+    // The last banned response is returned once every proxy was tried
+    if _, code := get(t, pool, context.Background()); code != http.StatusForbidden {
+        t.Errorf("status %d, want 403", code)
+    }
+
+    req, _ := http.NewRequest(http.MethodGet, "http://upstream.test/data", nil)
+    _, err := pool.RoundTrip(req)
+    var permanent *httpclient.PermanentError
+    if !errors.Is(err, ErrNoProxy) || !errors.As(err, &permanent) {
+        t.Errorf("RoundTrip with every proxy evicted = %v, want a permanent ErrNoProxy", err)
+    }
+}
+
+func TestPoolStickySession(t *testing.T) {
+    a, b, c := newFakeProxy(t, "a"), newFakeProxy(t, "b"), newFakeProxy(t, "c")
+    pool := newTestPool(t, Config{Sticky: true, MaxFailures: 2}, a, b, c)
+    session := WithSession(context.Background(), "s")
+
+    if name, _ := get(t, pool, session); name != "a" {
+        t.Fatalf("session bound to %q, want a", name)
+    }
+    get(t, pool, context.Background())
+    if name, _ := get(t, pool, session); name != "a" {
+        t.Errorf("session moved to %q", name)
+    }
+
+    // A failover answers from another proxy but keeps the session on a
+    a.status.Store(http.StatusForbidden)
+    if name, _ := get(t, pool, session); name == "a" || name == "" {
+        t.Errorf("failover answered by %q", name)
+    }
+    a.status.Store(0)
+    if name, _ := get(t, pool, session); name != "a" {
+        t.Errorf("session moved to %q after a failover", name)
+    }
+
+    // Once a is evicted the session moves for good
+    a.status.Store(http.StatusForbidden)
+    get(t, pool, session)
+    get(t, pool, session)
+    a.status.Store(0)
+    moved, _ := get(t, pool, session)
+    if moved == "a" {
+        t.Fatal("session stayed on the evicted proxy")
+    }
+    if name, _ := get(t, pool, session); name != moved {
+        t.Errorf("session answered by %q, then %q", moved, name)
+    }
+}
+
+func TestPoolReinstatesAfterHealthCheck(t *testing.T) {
+    a, b := newFakeProxy(t, "a"), newFakeProxy(t, "b")
+    a.status.Store(http.StatusForbidden)
+    pool := newTestPool(t, Config{MaxFailures: 1, HealthCheckURL: "http://upstream.test/health"}, a, b)
+
+    get(t, pool, context.Background())
+
+    // Still banned: the health check fails and a stays evicted
+    pool.checkEvicted(context.Background())
+    for i := 0; i < 2; i++ {
+        if name, _ := get(t, pool, context.Background()); name != "b" {
+            t.Errorf("answered by %q while a is evicted", name)
+        }
+    }
+
+    a.status.Store(0)
+    pool.checkEvicted(context.Background())
+    var got string
+    for i := 0; i < 2; i++ {
+        name, _ := get(t, pool, context.Background())
+        got += name
+    }
+    if got != "ab" && got != "ba" {
+        t.Errorf("proxies used %q after reinstatement, want a and b", got)
+    }
+} 
\ No newline at end of file
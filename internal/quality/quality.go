@@ -0,0 +1,180 @@
+package quality
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Checks reported in QualityIssue.Check
+const (
+    CheckGap         = "gap"
+    CheckDuplicate   = "duplicate"
+    CheckNonPositive = "non_positive"
+    CheckOutlier     = "outlier"
+)
+
+// Actions taken when a batch has new issues
+const (
+    ActionReport     = "report"
+    ActionFail       = "fail"
+    ActionQuarantine = "quarantine"
+)
+
+// maxIssues bounds the issues kept in a report; Counts stay complete
+const maxIssues = 100
+
+// Config holds configuration for the quality checks
+type Config struct {
+    // Interval is the expected spacing of points, defaults to a day
+    Interval time.Duration `yaml:"interval"`
+    // Sigma is how many standard deviations of the recent log returns a
+    // jump must exceed to be an outlier, defaults to 5
+    Sigma float64 `yaml:"sigma"`
+    // Window is how many preceding returns the deviation is measured over, defaults to 30
+    Window int `yaml:"window"`
+    // Action is report, fail or quarantine, defaults to report
+    Action string `yaml:"action"`
+}
+
+// withDefaults fills unset configuration
+func (c Config) withDefaults() Config {
+    if c.Interval <= 0 {
+        c.Interval = 24 * time.Hour
+    }
+    if c.Sigma <= 0 {
+        c.Sigma = 5
+    }
+    if c.Window < 2 {
+        c.Window = 30
+    }
+    if c.Action == "" {
+        c.Action = ActionReport
+    }
+    return c
+}
+
+// Validate checks the configuration for unknown settings
+func (c Config) Validate() error {
+    switch c.Action {
+    case "", ActionReport, ActionFail, ActionQuarantine:
+        return nil
+    default:
+        return fmt.Errorf("quality: unknown action %q", c.Action)
+    }
+}
+
+// Error is returned when a batch has new issues and the action is fail or quarantine
+type Error struct {
+    Report      *models.QualityReport
+    Quarantined bool
+}
+
+func (e *Error) Error() string {
+    checks := make([]string, 0, len(e.Report.Counts))
+    for check, n := range e.Report.Counts {
+        checks = append(checks, fmt.Sprintf("%s=%d", check, n))
+    }
+    sort.Strings(checks)
+
+    verb := "rejected"
+    if e.Quarantined {
+        verb = "quarantined"
+    }
+    return fmt.Sprintf("quality: %s batch %s with %d new issues (series totals: %s)",
+        e.Report.Asset, verb, e.Report.NewIssues, strings.Join(checks, ", "))
+}
+
+// Check runs every check over a series sorted by time. Issues after since
+// belong to the batch being stored and are marked new.
+func Check(asset string, series []models.MetricPoint, since time.Time, cfg Config) *models.QualityReport {
+    cfg = cfg.withDefaults()
+    report := &models.QualityReport{
+        Asset:     asset,
+        CheckedAt: time.Now().UTC(),
+        Points:    len(series),
+        Counts:    make(map[string]int),
+        Action:    cfg.Action,
+    }
+    if len(series) == 0 {
+        return report
+    }
+    report.From = series[0].Timestamp
+    report.To = series[len(series)-1].Timestamp
+
+    var issues []models.QualityIssue
+    add := func(check string, p models.MetricPoint, format string, args ...interface{}) {
+        issue := models.QualityIssue{
+            Check:     check,
+            Timestamp: p.Timestamp,
+            Value:     p.Value,
+            Message:   fmt.Sprintf(format, args...),
+            New:       p.Timestamp.After(since),
+        }
+        report.Counts[check]++
+        if issue.New {
+            report.NewIssues++
+        }
+        issues = append(issues, issue)
+    }
+
+    var returns []float64
+    for i, p := range series {
+        if p.Value <= 0 {
+            add(CheckNonPositive, p, "value %v is not positive", p.Value)
+        }
+        if i == 0 {
+            continue
+        }
+        prev := series[i-1]
+
+        switch diff := p.Timestamp.Sub(prev.Timestamp); {
+        case diff == 0:
+            add(CheckDuplicate, p, "timestamp repeated")
+        case diff > cfg.Interval*3/2:
+            missing := int(math.Round(float64(diff)/float64(cfg.Interval))) - 1
+            add(CheckGap, p, "%d missing points since %s", missing, prev.Timestamp.Format(time.RFC3339))
+        }
+
+        if p.Value <= 0 || prev.Value <= 0 || p.Timestamp.Equal(prev.Timestamp) {
+            continue
+        }
+        r := math.Log(p.Value / prev.Value)
+        if len(returns) >= cfg.Window {
+            mean, std := meanStd(returns[len(returns)-cfg.Window:])
+            if std > 0 && math.Abs(r-mean) > cfg.Sigma*std {
+                add(CheckOutlier, p, "jump of %.1f%% from %v is %.1f sigma",
+                    (math.Exp(r)-1)*100, prev.Value, math.Abs(r-mean)/std)
+            }
+        }
+        returns = append(returns, r)
+    }
+
+    // Keep new issues first so the batch's problems are never cut off
+    sort.SliceStable(issues, func(i, j int) bool {
+        return issues[i].New && !issues[j].New
+    })
+    if len(issues) > maxIssues {
+        issues = issues[:maxIssues]
+    }
+    report.Issues = issues
+    return report
+}
+
+func meanStd(values []float64) (float64, float64) {
+    var sum float64
+    for _, v := range values {
+        sum += v
+    }
+    mean := sum / float64(len(values))
+
+    var sq float64
+    for _, v := range values {
+        sq += (v - mean) * (v - mean)
+    }
+    return mean, math.Sqrt(sq / float64(len(values)))
+} 
\ No newline at end of file
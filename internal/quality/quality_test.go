@@ -0,0 +1,65 @@
+package quality
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// series returns daily points from start with a jump on the last day
+func series(start time.Time, days int, jump float64) []models.MetricPoint {
+    points := make([]models.MetricPoint, days)
+    for i := range points {
+        v := 100 + float64(i%3)
+        if i == days-1 {
+            v *= jump
+        }
+        points[i] = models.MetricPoint{Timestamp: start.AddDate(0, 0, i), Value: v}
+    }
+    return points
+}
+
+func TestQuarantinedBatchIsRejectedOnce(t *testing.T) {
+    ctx := context.Background()
+    s := storage.NewMemoryStorage()
+    cfg := Config{Action: ActionQuarantine, Window: 5}
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    points := series(start, 20, 3)
+    since := points[len(points)-2].Timestamp
+
+    report := Check("btc", points, since, cfg)
+    if report.NewIssues != 1 {
+        t.Fatalf("new issues = %d, want the jump", report.NewIssues)
+    }
+    var qerr *Error
+    if err := Apply(ctx, s, cfg, report, points); !errors.As(err, &qerr) || !qerr.Quarantined {
+        t.Fatalf("Apply = %v, want the batch quarantined", err)
+    }
+
+    // The next run sees the same batch: its issue was reported already
+    report = Check("btc", points, since, cfg)
+    if err := Apply(ctx, s, cfg, report, points); err != nil {
+        t.Fatalf("second Apply = %v, want the batch accepted", err)
+    }
+    if report.NewIssues != 0 || report.Counts[CheckOutlier] != 1 {
+        t.Errorf("second report has %d new issues and counts %v", report.NewIssues, report.Counts)
+    }
+    keys, err := s.List(ctx, "quarantine/btc/")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(keys) != 1 {
+        t.Errorf("quarantined %d batches, want 1: %v", len(keys), keys)
+    }
+
+    // A new issue in a later batch is still rejected
+    points = append(points, models.MetricPoint{Timestamp: start.AddDate(0, 0, 20), Value: 0})
+    report = Check("btc", points, points[len(points)-2].Timestamp, cfg)
+    if err := Apply(ctx, s, cfg, report, points); !errors.As(err, &qerr) {
+        t.Errorf("Apply of a new issue = %v, want it quarantined", err)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,102 @@
+package quality
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// rejected lists the issues of batches that failed or were quarantined
+type rejected struct {
+    Issues []rejectedIssue `json:"issues" bson:"issues"`
+}
+
+type rejectedIssue struct {
+    Check     string    `json:"check" bson:"check"`
+    Timestamp time.Time `json:"timestamp" bson:"timestamp"`
+}
+
+// Apply saves the report under quality/<asset>/ and carries out the
+// configured action. With new issues, fail returns an *Error and quarantine
+// also saves batch under quarantine/<asset>/; the caller must then not
+// store the batch. The issues of a rejected batch are recorded as seen, so
+// they are reported once and do not reject the batch again at the next run.
+func Apply(ctx context.Context, s storage.Storage, cfg Config, report *models.QualityReport, batch interface{}) error {
+    cfg = cfg.withDefaults()
+
+    seenKey := fmt.Sprintf("quality/%s/rejected.json", report.Asset)
+    var seen rejected
+    if cfg.Action != ActionReport {
+        if err := s.Load(ctx, seenKey, &seen); err != nil && !errors.Is(err, storage.ErrNotFound) {
+            return fmt.Errorf("failed to load rejected issues: %w", err)
+        }
+        markSeen(report, seen)
+    }
+
+    stamp := report.CheckedAt.Format("20060102T150405Z")
+    if err := s.Save(ctx, fmt.Sprintf("quality/%s/latest.json", report.Asset), report); err != nil {
+        return fmt.Errorf("failed to save quality report: %w", err)
+    }
+    if err := s.Save(ctx, fmt.Sprintf("quality/%s/%s.json", report.Asset, stamp), report); err != nil {
+        return fmt.Errorf("failed to save quality report: %w", err)
+    }
+
+    if report.NewIssues == 0 {
+        return nil
+    }
+    log.Printf("Quality %s: %d new issues, %v", report.Asset, report.NewIssues, report.Counts)
+
+    var qerr *Error
+    switch cfg.Action {
+    case ActionFail:
+        qerr = &Error{Report: report}
+    case ActionQuarantine:
+        key := fmt.Sprintf("quarantine/%s/%s.json", report.Asset, stamp)
+        if err := s.Save(ctx, key, batch); err != nil {
+            return fmt.Errorf("failed to quarantine batch: %w", err)
+        }
+        qerr = &Error{Report: report, Quarantined: true}
+    default:
+        return nil
+    }
+
+    // Forget the issues that are no longer in the checked range
+    kept := seen.Issues[:0]
+    for _, issue := range seen.Issues {
+        if !issue.Timestamp.Before(report.From) {
+            kept = append(kept, issue)
+        }
+    }
+    seen.Issues = kept
+    for _, issue := range report.Issues {
+        if issue.New {
+            seen.Issues = append(seen.Issues, rejectedIssue{Check: issue.Check, Timestamp: issue.Timestamp})
+        }
+    }
+    if err := s.Save(ctx, seenKey, seen); err != nil {
+        return fmt.Errorf("failed to save rejected issues: %w", err)
+    }
+    return qerr
+}
+
+// markSeen clears the New flag of the issues of an earlier rejected batch
+func markSeen(report *models.QualityReport, seen rejected) {
+    if len(seen.Issues) == 0 {
+        return
+    }
+    keys := make(map[rejectedIssue]bool, len(seen.Issues))
+    for _, issue := range seen.Issues {
+        keys[rejectedIssue{Check: issue.Check, Timestamp: issue.Timestamp.UTC()}] = true
+    }
+    for i, issue := range report.Issues {
+        if issue.New && keys[rejectedIssue{Check: issue.Check, Timestamp: issue.Timestamp.UTC()}] {
+            report.Issues[i].New = false
+            report.NewIssues--
+        }
+    }
+} 
\ No newline at end of file
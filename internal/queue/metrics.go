@@ -0,0 +1,16 @@
+package queue
+
+import "github.com/yourusername/investutil-gocrawler/internal/metrics"
+
+var (
+    published = metrics.NewCounterVec("queue_messages_published_total",
+        "Messages published", "queue")
+    publishErrors = metrics.NewCounterVec("queue_publish_errors_total",
+        "Messages that failed to publish", "queue")
+    consumed = metrics.NewCounterVec("queue_messages_consumed_total",
+        "Messages delivered to the consumer", "queue")
+    acked = metrics.NewCounterVec("queue_messages_acked_total",
+        "Messages acknowledged after they were handled", "queue")
+    rejected = metrics.NewCounterVec("queue_messages_rejected_total",
+        "Messages rejected and requeued after every handler attempt failed", "queue")
+) 
\ No newline at end of file
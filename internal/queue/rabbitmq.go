@@ -6,12 +6,25 @@ import (
     "time"
 
     "github.com/streadway/amqp"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
 )
 
 // RabbitMQ represents a RabbitMQ connection
 type RabbitMQ struct {
     conn    *amqp.Connection
-    channel *amqp.Channel
+    channel amqpChannel
+    queue   string
+}
+
+// amqpChannel is the part of *amqp.Channel used once the queue is declared
+type amqpChannel interface {
+    Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+    Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+    Close() error
 }
 
 // Config holds RabbitMQ configuration
@@ -84,29 +97,50 @@ func NewRabbitMQ(cfg Config) (*RabbitMQ, error) {
     return &RabbitMQ{
         conn:    conn,
         channel: ch,
+        queue:   cfg.Queue,
     }, nil
 }
 
-// Publish publishes a message to the queue
+// Publish publishes a message to the queue, carrying the trace context of
+// ctx in its headers
 func (r *RabbitMQ) Publish(ctx context.Context, body []byte) error {
-    return r.channel.Publish(
+    ctx, span := tracing.Tracer().Start(ctx, r.queue+" publish",
+        trace.WithSpanKind(trace.SpanKindProducer),
+        trace.WithAttributes(
+            attribute.String("messaging.system", "rabbitmq"),
+            attribute.String("messaging.destination.name", r.queue),
+            attribute.Int("messaging.message.body.size", len(body)),
+        ))
+    headers := amqp.Table{}
+    otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+
+    err := r.channel.Publish(
         "",           // exchange
-        "crypto_data", // routing key
+        r.queue,      // routing key
         false,        // mandatory
         false,        // immediate
         amqp.Publishing{
+            Headers:      headers,
             DeliveryMode: amqp.Persistent,
             ContentType:  "application/json",
             Body:        body,
             Timestamp:   time.Now(),
         },
     )
+    tracing.End(span, err)
+    if err != nil {
+        publishErrors.WithLabelValues(r.queue).Inc()
+        return err
+    }
+    published.WithLabelValues(r.queue).Inc()
+    return nil
 }
 
-// Consume starts consuming messages from the queue
-func (r *RabbitMQ) Consume(ctx context.Context, handler func([]byte) error) error {
+// Consume starts consuming messages from the queue. The handler's context
+// carries the trace of the message's publisher.
+func (r *RabbitMQ) Consume(ctx context.Context, handler func(context.Context, []byte) error) error {
     msgs, err := r.channel.Consume(
-        "crypto_data", // queue
+        r.queue,      // queue
         "",           // consumer
         false,        // auto-ack
         false,        // exclusive
@@ -123,21 +157,44 @@ func (r *RabbitMQ) Consume(ctx context.Context, handler func([]byte) error) erro
         case <-ctx.Done():
             return nil
         case msg := <-msgs:
-            // 处理消息，包含重试机制
-            for retries := 0; retries < 3; retries++ {
-                err := handler(msg.Body)
-                if err == nil {
-                    msg.Ack(false) // 确认消息
-                    break
-                }
-                if retries == 2 {
-                    // 最后一次重试失败，拒绝消息并重新入队
-                    msg.Reject(true)
-                }
-                time.Sleep(time.Second * time.Duration(retries+1))
-            }
+            consumed.WithLabelValues(r.queue).Inc()
+            r.handle(ctx, msg, handler)
+        }
+    }
+}
+
+// handle runs the handler on a delivered message in a consumer span
+func (r *RabbitMQ) handle(ctx context.Context, msg amqp.Delivery, handler func(context.Context, []byte) error) {
+    ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Headers))
+    ctx, span := tracing.Tracer().Start(ctx, r.queue+" process",
+        trace.WithSpanKind(trace.SpanKindConsumer),
+        trace.WithAttributes(
+            attribute.String("messaging.system", "rabbitmq"),
+            attribute.String("messaging.destination.name", r.queue),
+            attribute.Int("messaging.message.body.size", len(msg.Body)),
+        ))
+
+    // 处理消息，包含重试机制
+    var err error
+    for retries := 0; retries < 3; retries++ {
+        err = handler(ctx, msg.Body)
+        if err == nil {
+            msg.Ack(false) // 确认消息
+            acked.WithLabelValues(r.queue).Inc()
+            break
+        }
+        span.AddEvent("handler failed", trace.WithAttributes(
+            attribute.Int("attempt", retries+1),
+            attribute.String("error", err.Error()),
+        ))
+        if retries == 2 {
+            // 最后一次重试失败，拒绝消息并重新入队
+            msg.Reject(true)
+            rejected.WithLabelValues(r.queue).Inc()
         }
+        time.Sleep(time.Second * time.Duration(retries+1))
     }
+    tracing.End(span, err)
 }
 
 // Close closes the RabbitMQ connection
@@ -0,0 +1,28 @@
+package queue
+
+import (
+    "github.com/streadway/amqp"
+)
+
+// headerCarrier carries the trace context in the headers of a message
+type headerCarrier amqp.Table
+
+// Get implements propagation.TextMapCarrier.Get
+func (c headerCarrier) Get(key string) string {
+    v, _ := c[key].(string)
+    return v
+}
+
+// Set implements propagation.TextMapCarrier.Set
+func (c headerCarrier) Set(key, value string) {
+    c[key] = value
+}
+
+// Keys implements propagation.TextMapCarrier.Keys
+func (c headerCarrier) Keys() []string {
+    keys := make([]string, 0, len(c))
+    for k := range c {
+        keys = append(keys, k)
+    }
+    return keys
+} 
\ No newline at end of file
@@ -0,0 +1,84 @@
+package queue
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/streadway/amqp"
+    "go.opentelemetry.io/otel/sdk/trace/tracetest"
+    "go.opentelemetry.io/otel/trace"
+    "go.opentelemetry.io/otel/trace/noop"
+
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
+)
+
+// memChannel delivers every published message to its consumer
+type memChannel struct {
+    deliveries chan amqp.Delivery
+}
+
+func (c *memChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+    c.deliveries <- amqp.Delivery{Headers: msg.Headers, Body: msg.Body}
+    return nil
+}
+
+func (c *memChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+    return c.deliveries, nil
+}
+
+func (c *memChannel) Close() error {
+    return nil
+}
+
+func TestTraceCrossesQueue(t *testing.T) {
+    exporter := tracetest.NewInMemoryExporter()
+    provider := tracing.NewTracerProvider("test", exporter, 1)
+    tracing.Use(provider)
+    t.Cleanup(func() { tracing.Use(noop.NewTracerProvider()) })
+
+    q := &RabbitMQ{channel: &memChannel{deliveries: make(chan amqp.Delivery, 1)}, queue: "prices"}
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    // Collect publishes under its own span, as the collectors do
+    collectCtx, collect := tracing.Tracer().Start(ctx, "collect bitcoin-price")
+    if err := q.Publish(collectCtx, []byte(`{}`)); err != nil {
+        t.Fatal(err)
+    }
+    collect.End()
+
+    var handled trace.SpanContext
+    err := q.Consume(ctx, func(ctx context.Context, body []byte) error {
+        handled = trace.SpanContextFromContext(ctx)
+        cancel()
+        return nil
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := provider.ForceFlush(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+
+    spans := make(map[string]tracetest.SpanStub)
+    for _, s := range exporter.GetSpans() {
+        spans[s.Name] = s
+    }
+    c, p, r := spans["collect bitcoin-price"], spans["prices publish"], spans["prices process"]
+    if !c.SpanContext.IsValid() || !p.SpanContext.IsValid() || !r.SpanContext.IsValid() {
+        t.Fatalf("exported spans = %v", spans)
+    }
+    if p.Parent.SpanID() != c.SpanContext.SpanID() {
+        t.Errorf("publish parent = %s, want the collect span %s", p.Parent.SpanID(), c.SpanContext.SpanID())
+    }
+    if r.Parent.SpanID() != p.SpanContext.SpanID() || !r.Parent.IsRemote() {
+        t.Errorf("process parent = %s (remote %t), want the publish span %s from the headers", r.Parent.SpanID(), r.Parent.IsRemote(), p.SpanContext.SpanID())
+    }
+    if r.SpanContext.TraceID() != c.SpanContext.TraceID() {
+        t.Errorf("process trace = %s, want %s", r.SpanContext.TraceID(), c.SpanContext.TraceID())
+    }
+    if handled.SpanID() != r.SpanContext.SpanID() {
+        t.Errorf("handler ran in span %s, want the process span %s", handled.SpanID(), r.SpanContext.SpanID())
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,233 @@
+package ratelimit
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// ErrBudgetExhausted is matched by errors.Is for every *BudgetError
+var ErrBudgetExhausted = errors.New("monthly request budget exhausted")
+
+// BudgetError is returned when a host has used up its monthly budget
+type BudgetError struct {
+    Host    string
+    Limit   int
+    ResetAt time.Time
+}
+
+func (e *BudgetError) Error() string {
+    return fmt.Sprintf("%s: %s (%d requests), resets at %s",
+        e.Host, ErrBudgetExhausted, e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrBudgetExhausted
+func (e *BudgetError) Is(target error) bool {
+    return target == ErrBudgetExhausted
+}
+
+// saveTimeout bounds a background save of a host's usage
+const saveTimeout = 10 * time.Second
+
+// Budget counts requests per host and calendar month (UTC). Usage is saved
+// under budgets/<host>/<yyyy-mm> so it survives restarts. Hosts are counted
+// under their own lock and saved in the background: requests taken while a
+// save is running are written together by the next one.
+type Budget struct {
+    storage storage.Storage
+
+    mu    sync.Mutex
+    hosts map[string]*hostUsage
+    saves sync.WaitGroup
+}
+
+// hostUsage is the usage of a host in the current month
+type hostUsage struct {
+    mu    sync.Mutex
+    usage *models.BudgetUsage
+    // previous is the usage of the previous month while its last requests
+    // are not saved yet
+    previous *models.BudgetUsage
+    // dirty is set when usage has requests not saved yet, saving while a
+    // background save runs. Only one save of a host runs at a time.
+    dirty  bool
+    saving bool
+}
+
+// NewBudget creates a new Budget
+func NewBudget(s storage.Storage) *Budget {
+    return &Budget{
+        storage: s,
+        hosts:   make(map[string]*hostUsage),
+    }
+}
+
+// Take charges one request to host, failing with a *BudgetError when limit
+// requests have already been made this month
+func (b *Budget) Take(ctx context.Context, host string, limit int, now time.Time) error {
+    now = now.UTC()
+    month := now.Format("2006-01")
+
+    h := b.host(host)
+    h.mu.Lock()
+    defer h.mu.Unlock()
+
+    if h.usage == nil || h.usage.Month != month {
+        usage, err := b.load(ctx, host, month)
+        if err != nil {
+            return err
+        }
+        // The last requests of the previous month are saved first
+        if h.dirty {
+            h.previous, h.dirty = h.usage, false
+        }
+        h.usage = usage
+    }
+
+    if h.usage.Requests >= limit {
+        return &BudgetError{
+            Host:    host,
+            Limit:   limit,
+            ResetAt: time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC),
+        }
+    }
+
+    h.usage.Requests++
+    h.usage.UpdatedAt = now
+    h.dirty = true
+    if !h.saving {
+        h.saving = true
+        b.saves.Add(1)
+        go b.save(host, h)
+    }
+    return nil
+}
+
+// next returns the usage to save next, previous month first, and marks it
+// saved. The caller must hold h.mu.
+func (h *hostUsage) next() (models.BudgetUsage, bool) {
+    if h.previous != nil {
+        usage := *h.previous
+        h.previous = nil
+        return usage, true
+    }
+    if h.dirty {
+        h.dirty = false
+        return *h.usage, true
+    }
+    return models.BudgetUsage{}, false
+}
+
+// unsaved marks usage, which failed to save, as not saved yet. The caller
+// must hold h.mu.
+func (h *hostUsage) unsaved(usage models.BudgetUsage) {
+    switch {
+    case usage.Month == h.usage.Month:
+        h.dirty = true
+    case h.previous == nil:
+        h.previous = &usage
+    }
+}
+
+// Flush waits for the background saves and saves the usage they failed to
+// write. It is called on shutdown.
+func (b *Budget) Flush(ctx context.Context) error {
+    done := make(chan struct{})
+    go func() {
+        b.saves.Wait()
+        close(done)
+    }()
+    select {
+    case <-done:
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+
+    b.mu.Lock()
+    hosts := make(map[string]*hostUsage, len(b.hosts))
+    for host, h := range b.hosts {
+        hosts[host] = h
+    }
+    b.mu.Unlock()
+
+    var errs []error
+    for host, h := range hosts {
+        h.mu.Lock()
+        // A save started since is left to finish on its own
+        for !h.saving {
+            usage, ok := h.next()
+            if !ok {
+                break
+            }
+            if err := b.storage.Save(ctx, budgetKey(host, usage.Month), usage); err != nil {
+                h.unsaved(usage)
+                errs = append(errs, fmt.Errorf("failed to save request budget of %s: %w", host, err))
+                break
+            }
+        }
+        h.mu.Unlock()
+    }
+    return errors.Join(errs...)
+}
+
+// host returns the usage of host, creating it on first use
+func (b *Budget) host(host string) *hostUsage {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    h, ok := b.hosts[host]
+    if !ok {
+        h = &hostUsage{}
+        b.hosts[host] = h
+    }
+    return h
+}
+
+// save writes the usage of host until no request is left unsaved. A failed
+// save is logged and retried by the next request or by Flush.
+func (b *Budget) save(host string, h *hostUsage) {
+    defer b.saves.Done()
+    for {
+        h.mu.Lock()
+        usage, ok := h.next()
+        if !ok {
+            h.saving = false
+            h.mu.Unlock()
+            return
+        }
+        h.mu.Unlock()
+
+        ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+        err := b.storage.Save(ctx, budgetKey(host, usage.Month), usage)
+        cancel()
+        if err != nil {
+            log.Printf("Failed to save request budget of %s: %v", host, err)
+            h.mu.Lock()
+            h.unsaved(usage)
+            h.saving = false
+            h.mu.Unlock()
+            return
+        }
+    }
+}
+
+// load reads the stored usage of a month, starting from zero when none exists
+func (b *Budget) load(ctx context.Context, host, month string) (*models.BudgetUsage, error) {
+    var usage models.BudgetUsage
+    if err := b.storage.Load(ctx, budgetKey(host, month), &usage); err != nil {
+        if !errors.Is(err, storage.ErrNotFound) {
+            return nil, fmt.Errorf("failed to load request budget: %w", err)
+        }
+        usage = models.BudgetUsage{Host: host, Month: month}
+    }
+    return &usage, nil
+}
+
+func budgetKey(host, month string) string {
+    return fmt.Sprintf("budgets/%s/%s", host, month)
+} 
\ No newline at end of file
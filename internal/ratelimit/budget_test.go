@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// slowStorage holds the saves of the keys under blocked until release is
+// closed
+type slowStorage struct {
+    storage.Storage
+    blocked string
+    release chan struct{}
+
+    mu    sync.Mutex
+    saves int
+}
+
+func (s *slowStorage) Save(ctx context.Context, key string, data interface{}) error {
+    if strings.HasPrefix(key, s.blocked) {
+        <-s.release
+    }
+    s.mu.Lock()
+    s.saves++
+    s.mu.Unlock()
+    return s.Storage.Save(ctx, key, data)
+}
+
+func TestBudgetDoesNotWaitForStorage(t *testing.T) {
+    ctx := context.Background()
+    s := &slowStorage{Storage: storage.NewMemoryStorage(), blocked: "budgets/slow.example.com/", release: make(chan struct{})}
+    b := NewBudget(s)
+    now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+    done := make(chan error, 1)
+    go func() {
+        for i := 0; i < 5; i++ {
+            if err := b.Take(ctx, "slow.example.com", 10, now); err != nil {
+                done <- err
+                return
+            }
+        }
+        // Another host is not held up by the slow one
+        done <- b.Take(ctx, "fast.example.com", 10, now)
+    }()
+    select {
+    case err := <-done:
+        if err != nil {
+            t.Fatal(err)
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatal("Take waited for a save")
+    }
+
+    // The budget is enforced from memory while the save is pending
+    for i := 0; i < 5; i++ {
+        b.Take(ctx, "slow.example.com", 10, now)
+    }
+    var budgetErr *BudgetError
+    if err := b.Take(ctx, "slow.example.com", 10, now); !errors.As(err, &budgetErr) {
+        t.Errorf("Take over the limit = %v", err)
+    }
+
+    close(s.release)
+    if err := b.Flush(ctx); err != nil {
+        t.Fatal(err)
+    }
+    var usage models.BudgetUsage
+    if err := s.Load(ctx, "budgets/slow.example.com/2024-03", &usage); err != nil {
+        t.Fatal(err)
+    }
+    if usage.Requests != 10 {
+        t.Errorf("saved %d requests, want 10", usage.Requests)
+    }
+    // The requests taken during the first save are written in one batch
+    if s.saves > 3 {
+        t.Errorf("%d saves for 11 requests", s.saves)
+    }
+}
+
+func TestBudgetSavesPreviousMonth(t *testing.T) {
+    ctx := context.Background()
+    s := &slowStorage{Storage: storage.NewMemoryStorage(), blocked: "budgets/", release: make(chan struct{})}
+    b := NewBudget(s)
+    march := time.Date(2024, 3, 31, 23, 59, 0, 0, time.UTC)
+
+    for i := 0; i < 3; i++ {
+        if err := b.Take(ctx, "api.example.com", 10, march); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if err := b.Take(ctx, "api.example.com", 10, march.Add(2*time.Minute)); err != nil {
+        t.Fatal(err)
+    }
+    close(s.release)
+    if err := b.Flush(ctx); err != nil {
+        t.Fatal(err)
+    }
+
+    for month, want := range map[string]int{"2024-03": 3, "2024-04": 1} {
+        var usage models.BudgetUsage
+        if err := s.Load(ctx, "budgets/api.example.com/"+month, &usage); err != nil {
+            t.Fatal(err)
+        }
+        if usage.Requests != want {
+            t.Errorf("%s: saved %d requests, want %d", month, usage.Requests, want)
+        }
+    }
+} 
\ No newline at end of file
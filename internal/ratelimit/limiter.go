@@ -0,0 +1,194 @@
+package ratelimit
+
+import (
+    "context"
+    "errors"
+    "log"
+    "net/http"
+    "sync"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// HostConfig holds the limits for a single host
+type HostConfig struct {
+    // RequestsPerMinute is the sustained rate; zero disables rate limiting
+    RequestsPerMinute float64 `yaml:"requests_per_minute"`
+    // Burst is how many requests may be sent at once, defaults to 1
+    Burst int `yaml:"burst"`
+    // MonthlyBudget caps the requests per calendar month (UTC); zero is unlimited
+    MonthlyBudget int `yaml:"monthly_budget"`
+}
+
+// Config holds configuration for Limiter
+type Config struct {
+    // Default applies to hosts without an entry in Hosts
+    Default HostConfig            `yaml:"default"`
+    Hosts   map[string]HostConfig `yaml:"hosts"`
+}
+
+// Limiter enforces per-host token bucket rate limits and monthly request
+// budgets. A single Limiter is shared by every crawler in a process.
+type Limiter struct {
+    budget *Budget
+
+    mu      sync.Mutex
+    config  Config
+    buckets map[string]*bucket
+}
+
+// NewLimiter creates a new Limiter. Budgets are persisted in s; a nil
+// storage disables budget accounting.
+func NewLimiter(cfg Config, s storage.Storage) *Limiter {
+    hosts := make(map[string]HostConfig, len(cfg.Hosts))
+    for host, hc := range cfg.Hosts {
+        hosts[host] = hc
+    }
+    cfg.Hosts = hosts
+
+    l := &Limiter{
+        config:  cfg,
+        buckets: make(map[string]*bucket),
+    }
+    if s != nil {
+        l.budget = NewBudget(s)
+    }
+    return l
+}
+
+// SetHost overrides the limits of a host, e.g. from a source configuration.
+// It must be called before the host is first requested.
+func (l *Limiter) SetHost(host string, hc HostConfig) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    if _, ok := l.config.Hosts[host]; ok {
+        log.Printf("Rate limit for %s configured more than once, using the last one", host)
+    }
+    l.config.Hosts[host] = hc
+    delete(l.buckets, host)
+}
+
+// Wait blocks until a request to host is allowed by its rate limit and
+// charges it to the host's monthly budget. It returns a *BudgetError once
+// the budget is exhausted.
+func (l *Limiter) Wait(ctx context.Context, host string) error {
+    hc, b := l.host(host)
+    if b != nil {
+        if err := b.wait(ctx); err != nil {
+            return err
+        }
+    }
+    if l.budget != nil && hc.MonthlyBudget > 0 {
+        return l.budget.Take(ctx, host, hc.MonthlyBudget, time.Now())
+    }
+    return nil
+}
+
+// Flush saves the request budgets counted so far. It is called on shutdown.
+func (l *Limiter) Flush(ctx context.Context) error {
+    if l.budget == nil {
+        return nil
+    }
+    return l.budget.Flush(ctx)
+}
+
+// Middleware returns an httpclient middleware applying the limiter to every
+// attempt, retries included
+func (l *Limiter) Middleware() httpclient.Middleware {
+    return func(next http.RoundTripper) http.RoundTripper {
+        return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+            if err := l.Wait(req.Context(), req.URL.Hostname()); err != nil {
+                if errors.Is(err, ErrBudgetExhausted) {
+                    return nil, &httpclient.PermanentError{Err: err}
+                }
+                return nil, err
+            }
+            return next.RoundTrip(req)
+        })
+    }
+}
+
+// host returns the limits and bucket for host, creating the bucket on first use
+func (l *Limiter) host(host string) (HostConfig, *bucket) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    hc, ok := l.config.Hosts[host]
+    if !ok {
+        hc = l.config.Default
+    }
+    if hc.RequestsPerMinute <= 0 {
+        return hc, nil
+    }
+
+    b, ok := l.buckets[host]
+    if !ok {
+        b = newBucket(hc.RequestsPerMinute/60, hc.Burst)
+        l.buckets[host] = b
+    }
+    return hc, b
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+    return f(req)
+}
+
+// bucket is a token bucket refilled at rate tokens per second. Callers
+// reserve a token up front, so the balance may go negative while they wait.
+type bucket struct {
+    mu     sync.Mutex
+    rate   float64
+    burst  float64
+    tokens float64
+    last   time.Time
+}
+
+func newBucket(rate float64, burst int) *bucket {
+    if burst < 1 {
+        burst = 1
+    }
+    return &bucket{
+        rate:   rate,
+        burst:  float64(burst),
+        tokens: float64(burst),
+        last:   time.Now(),
+    }
+}
+
+// wait reserves a token and sleeps until it is available
+func (b *bucket) wait(ctx context.Context) error {
+    b.mu.Lock()
+    now := time.Now()
+    b.tokens += now.Sub(b.last).Seconds() * b.rate
+    if b.tokens > b.burst {
+        b.tokens = b.burst
+    }
+    b.last = now
+    b.tokens--
+    var delay time.Duration
+    if b.tokens < 0 {
+        delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+    }
+    b.mu.Unlock()
+
+    if delay == 0 {
+        return nil
+    }
+
+    timer := time.NewTimer(delay)
+    defer timer.Stop()
+    select {
+    case <-ctx.Done():
+        // Give the reserved token back
+        b.mu.Lock()
+        b.tokens++
+        b.mu.Unlock()
+        return ctx.Err()
+    case <-timer.C:
+        return nil
+    }
+} 
\ No newline at end of file
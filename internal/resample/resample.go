@@ -0,0 +1,188 @@
+package resample
+
+import (
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Periods supported by Resample
+const (
+    PeriodDay     = "day"
+    PeriodWeek    = "week"
+    PeriodMonth   = "month"
+    PeriodQuarter = "quarter"
+    PeriodYear    = "year"
+)
+
+// Point is a single observation to resample. Volume and MarketCap are zero
+// for series that do not carry them. Volume is a rolling 24h total, such as
+// CoinGecko's total_volumes, so only the last point of each day counts.
+type Point struct {
+    Timestamp time.Time
+    Close     float64
+    Volume    float64
+    MarketCap float64
+}
+
+// FromBitcoin converts the Bitcoin price history to points
+func FromBitcoin(prices []models.BitcoinPrice) []Point {
+    points := make([]Point, 0, len(prices))
+    for _, p := range prices {
+        points = append(points, Point{
+            Timestamp: p.Timestamp,
+            Close:     p.Price,
+            Volume:    p.Volume24h,
+            MarketCap: p.MarketCap,
+        })
+    }
+    return points
+}
+
+// FromSeries converts a metric series to points
+func FromSeries(series []models.MetricPoint) []Point {
+    points := make([]Point, 0, len(series))
+    for _, p := range series {
+        points = append(points, Point{Timestamp: p.Timestamp, Close: p.Value})
+    }
+    return points
+}
+
+// ValidatePeriod checks that period is supported
+func ValidatePeriod(period string) error {
+    switch period {
+    case PeriodDay, PeriodWeek, PeriodMonth, PeriodQuarter, PeriodYear:
+        return nil
+    default:
+        return fmt.Errorf("unknown period %q, use day, week, month, quarter or year", period)
+    }
+}
+
+// LoadLocation resolves a timezone name, an empty name being UTC
+func LoadLocation(name string) (*time.Location, error) {
+    if name == "" {
+        return time.UTC, nil
+    }
+    loc, err := time.LoadLocation(name)
+    if err != nil {
+        return nil, fmt.Errorf("unknown timezone %q: %w", name, err)
+    }
+    return loc, nil
+}
+
+// PeriodStart returns the start of the period holding t, on the calendar
+// of loc. Weeks start on Monday.
+func PeriodStart(t time.Time, period string, loc *time.Location) time.Time {
+    t = t.In(loc)
+    day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+    switch period {
+    case PeriodWeek:
+        return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+    case PeriodMonth:
+        return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+    case PeriodQuarter:
+        month := (int(t.Month())-1)/3*3 + 1
+        return time.Date(t.Year(), time.Month(month), 1, 0, 0, 0, 0, loc)
+    case PeriodYear:
+        return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, loc)
+    default:
+        return day
+    }
+}
+
+// PeriodEnd returns the start of the period after the one starting at start
+func PeriodEnd(start time.Time, period string) time.Time {
+    switch period {
+    case PeriodWeek:
+        return start.AddDate(0, 0, 7)
+    case PeriodMonth:
+        return start.AddDate(0, 1, 0)
+    case PeriodQuarter:
+        return start.AddDate(0, 3, 0)
+    case PeriodYear:
+        return start.AddDate(1, 0, 0)
+    default:
+        return start.AddDate(0, 0, 1)
+    }
+}
+
+// Volume sums the volume of the last point of each day on the calendar of
+// loc, which is what a period's volume is made of. The points must be sorted.
+func Volume(points []Point, loc *time.Location) float64 {
+    var sum, dayVolume float64
+    var day time.Time
+    for _, p := range points {
+        if d := PeriodStart(p.Timestamp, PeriodDay, loc); d.Equal(day) {
+            sum -= dayVolume
+        } else {
+            day = d
+        }
+        sum += p.Volume
+        dayVolume = p.Volume
+    }
+    return sum
+}
+
+// Resample groups points into bars of the period on the calendar of loc.
+// The points need not be sorted; bars come out oldest first, and periods
+// without points are left out. A bar's volume sums the volume of the last
+// point of each of its days, so an intraday point replaces the volume of
+// the earlier points of its day instead of adding to it.
+func Resample(points []Point, period string, loc *time.Location) ([]models.AggregateBar, error) {
+    if err := ValidatePeriod(period); err != nil {
+        return nil, err
+    }
+    if loc == nil {
+        loc = time.UTC
+    }
+
+    sorted := make([]Point, len(points))
+    copy(sorted, points)
+    sort.SliceStable(sorted, func(i, j int) bool {
+        return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+    })
+
+    var bars []models.AggregateBar
+    // day and dayVolume are the day of the last point and the volume it
+    // added to the current bar
+    var day time.Time
+    var dayVolume float64
+    for _, p := range sorted {
+        start := PeriodStart(p.Timestamp, period, loc)
+        n := len(bars)
+        if n == 0 || !bars[n-1].Start.Equal(start) {
+            bars = append(bars, models.AggregateBar{
+                Start: start,
+                End:   PeriodEnd(start, period),
+                Open:  p.Close,
+                High:  p.Close,
+                Low:   p.Close,
+            })
+            n++
+        }
+        bar := &bars[n-1]
+        if p.Close > bar.High {
+            bar.High = p.Close
+        }
+        if p.Close < bar.Low {
+            bar.Low = p.Close
+        }
+        bar.Close = p.Close
+        // Periods are days or longer, so the day of the previous point is
+        // in the same bar
+        if d := PeriodStart(p.Timestamp, PeriodDay, loc); d.Equal(day) {
+            bar.Volume -= dayVolume
+        } else {
+            day = d
+        }
+        bar.Volume += p.Volume
+        dayVolume = p.Volume
+        if p.MarketCap != 0 {
+            bar.MarketCap = p.MarketCap
+        }
+        bar.Count++
+    }
+    return bars, nil
+} 
\ No newline at end of file
@@ -0,0 +1,185 @@
+package resample
+
+import (
+    "testing"
+    "time"
+    _ "time/tzdata"
+)
+
+// history is three daily points and an intraday "now" point on the third
+// day, each with a rolling 24h volume
+func history() []Point {
+    day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    return []Point{
+        {Timestamp: day, Close: 100, Volume: 10, MarketCap: 1000},
+        {Timestamp: day.AddDate(0, 0, 1), Close: 110, Volume: 20, MarketCap: 1100},
+        {Timestamp: day.AddDate(0, 0, 2), Close: 105, Volume: 30, MarketCap: 1050},
+        {Timestamp: day.AddDate(0, 0, 2).Add(15 * time.Hour), Close: 120, Volume: 35, MarketCap: 1200},
+    }
+}
+
+func TestResampleCountsOneVolumePerDay(t *testing.T) {
+    days, err := Resample(history(), PeriodDay, time.UTC)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(days) != 3 {
+        t.Fatalf("%d daily bars, want 3", len(days))
+    }
+    last := days[2]
+    if last.Volume != 35 || last.Open != 105 || last.Close != 120 || last.Count != 2 {
+        t.Errorf("last day = %+v, want the volume of the intraday point only", last)
+    }
+
+    weeks, err := Resample(history(), PeriodWeek, time.UTC)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(weeks) != 1 || weeks[0].Volume != 10+20+35 || weeks[0].High != 120 || weeks[0].MarketCap != 1200 {
+        t.Errorf("weeks = %+v", weeks)
+    }
+
+    if v := Volume(history(), time.UTC); v != weeks[0].Volume {
+        t.Errorf("Volume = %v, want %v", v, weeks[0].Volume)
+    }
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+    t.Helper()
+    loc, err := LoadLocation(name)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return loc
+}
+
+func TestPeriodStart(t *testing.T) {
+    tokyo := mustLoad(t, "Asia/Tokyo")
+    newYork := mustLoad(t, "America/New_York")
+
+    tests := []struct {
+        name   string
+        t      time.Time
+        period string
+        loc    *time.Location
+        start  time.Time
+        end    time.Time
+    }{
+        {"month", time.Date(2024, 2, 29, 23, 59, 0, 0, time.UTC), PeriodMonth, time.UTC,
+            time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+        {"quarter", time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC), PeriodQuarter, time.UTC,
+            time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+        {"first day of a quarter", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), PeriodQuarter, time.UTC,
+            time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+        {"year", time.Date(2023, 12, 31, 23, 59, 0, 0, time.UTC), PeriodYear, time.UTC,
+            time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+        {"week across a year", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1), PeriodWeek, time.UTC,
+            time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+        // 23:30 UTC is already the next day, and the next year, in Tokyo
+        {"tokyo day", time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC), PeriodDay, tokyo,
+            time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo), time.Date(2024, 1, 2, 0, 0, 0, 0, tokyo)},
+        {"tokyo year", time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC), PeriodYear, tokyo,
+            time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo), time.Date(2025, 1, 1, 0, 0, 0, 0, tokyo)},
+        // Clocks spring forward on Sunday 10 March 2024, so the week is 167 hours
+        {"new york dst week", time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), PeriodWeek, newYork,
+            time.Date(2024, 3, 4, 0, 0, 0, 0, newYork), time.Date(2024, 3, 11, 0, 0, 0, 0, newYork)},
+        // 03:00 UTC on 4 March is still Sunday 3 March in New York
+        {"new york day before the week", time.Date(2024, 3, 4, 3, 0, 0, 0, time.UTC), PeriodWeek, newYork,
+            time.Date(2024, 2, 26, 0, 0, 0, 0, newYork), time.Date(2024, 3, 4, 0, 0, 0, 0, newYork)},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            start := PeriodStart(tt.t, tt.period, tt.loc)
+            if !start.Equal(tt.start) {
+                t.Errorf("PeriodStart = %s, want %s", start, tt.start)
+            }
+            if end := PeriodEnd(start, tt.period); !end.Equal(tt.end) {
+                t.Errorf("PeriodEnd = %s, want %s", end, tt.end)
+            }
+        })
+    }
+
+    start := PeriodStart(time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), PeriodWeek, newYork)
+    if d := PeriodEnd(start, PeriodWeek).Sub(start); d != 167*time.Hour {
+        t.Errorf("DST week lasts %s, want 167h", d)
+    }
+}
+
+func TestResampleCalendarPeriods(t *testing.T) {
+    var points []Point
+    for day := time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC); day.Before(time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)); day = day.AddDate(0, 0, 1) {
+        points = append(points, Point{Timestamp: day, Close: float64(day.YearDay()), Volume: 1})
+    }
+
+    tests := []struct {
+        period string
+        starts []time.Time
+        counts []int
+    }{
+        {PeriodMonth, []time.Time{
+            time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+        }, []int{1, 31, 31, 29, 31, 1}},
+        {PeriodQuarter, []time.Time{
+            time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+        }, []int{32, 91, 1}},
+        {PeriodYear, []time.Time{
+            time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+        }, []int{32, 92}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.period, func(t *testing.T) {
+            bars, err := Resample(points, tt.period, time.UTC)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if len(bars) != len(tt.starts) {
+                t.Fatalf("%d bars, want %d", len(bars), len(tt.starts))
+            }
+            for i, bar := range bars {
+                if !bar.Start.Equal(tt.starts[i]) || bar.Count != tt.counts[i] || bar.Volume != float64(tt.counts[i]) {
+                    t.Errorf("bar %d = %+v, want start %s with %d points", i, bar, tt.starts[i], tt.counts[i])
+                }
+            }
+        })
+    }
+}
+
+func TestResampleInLocation(t *testing.T) {
+    tokyo := mustLoad(t, "Asia/Tokyo")
+
+    // 23:00 Tokyo on 31 December, then 00:30 Tokyo on 1 January
+    points := []Point{
+        {Timestamp: time.Date(2023, 12, 31, 14, 0, 0, 0, time.UTC), Close: 100, Volume: 10},
+        {Timestamp: time.Date(2023, 12, 31, 15, 30, 0, 0, time.UTC), Close: 110, Volume: 20},
+    }
+
+    utc, err := Resample(points, PeriodDay, time.UTC)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(utc) != 1 || utc[0].Volume != 20 {
+        t.Errorf("UTC days = %+v, want one day with the last volume", utc)
+    }
+
+    years, err := Resample(points, PeriodYear, tokyo)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(years) != 2 {
+        t.Fatalf("%d Tokyo years, want 2", len(years))
+    }
+    if !years[1].Start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo)) || years[1].Open != 110 || years[1].Volume != 20 {
+        t.Errorf("Tokyo 2024 = %+v", years[1])
+    }
+    if v := Volume(points, tokyo); v != 30 {
+        t.Errorf("Tokyo volume = %v, want both days counted", v)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,80 @@
+package resample
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// Config selects the aggregates materialized after each crawl
+type Config struct {
+    // Periods lists the periods to materialize, e.g. [week, month]
+    Periods []string `yaml:"periods"`
+    // Timezone sets the calendar of the period boundaries, defaults to UTC
+    Timezone string `yaml:"timezone"`
+}
+
+// Validate checks the configuration for unknown periods and timezones
+func (c Config) Validate() error {
+    if len(c.Periods) == 0 {
+        return fmt.Errorf("resample: at least one period is required")
+    }
+    for _, period := range c.Periods {
+        if err := ValidatePeriod(period); err != nil {
+            return fmt.Errorf("resample: %w", err)
+        }
+    }
+    if _, err := LoadLocation(c.Timezone); err != nil {
+        return fmt.Errorf("resample: %w", err)
+    }
+    return nil
+}
+
+// Key returns the storage key of the aggregates of the series under prefix
+func Key(prefix, period string) string {
+    return fmt.Sprintf("%s/bars/%s/latest.json", prefix, period)
+}
+
+// Materialize resamples the points of the series under prefix to every
+// configured period and stores the bars under <prefix>/bars/<period>/latest.json
+func Materialize(ctx context.Context, s storage.Storage, prefix, metric string, points []Point, cfg Config) error {
+    if err := cfg.Validate(); err != nil {
+        return err
+    }
+    loc, _ := LoadLocation(cfg.Timezone)
+    now := time.Now().UTC()
+    for _, period := range cfg.Periods {
+        bars, err := Resample(points, period, loc)
+        if err != nil {
+            return err
+        }
+        series := models.AggregateSeries{
+            LastUpdated: now,
+            Metric:      metric,
+            Period:      period,
+            Timezone:    loc.String(),
+            Bars:        bars,
+        }
+        if err := s.Save(ctx, Key(prefix, period), series); err != nil {
+            return fmt.Errorf("failed to save %s bars: %w", period, err)
+        }
+    }
+    return nil
+}
+
+// Load loads the materialized aggregates of the series under prefix. The
+// returned bool is false when none are stored.
+func Load(ctx context.Context, s storage.Storage, prefix, period string) (models.AggregateSeries, bool, error) {
+    var series models.AggregateSeries
+    if err := s.Load(ctx, Key(prefix, period), &series); err != nil {
+        if errors.Is(err, storage.ErrNotFound) {
+            return series, false, nil
+        }
+        return series, false, fmt.Errorf("failed to load %s bars: %w", period, err)
+    }
+    return series, true, nil
+} 
\ No newline at end of file
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "sync"
+    "time"
+
+    "github.com/robfig/cron/v3"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/ratelimit"
+    "github.com/yourusername/investutil-gocrawler/internal/tracing"
+)
+
+// RunHook is called with the outcome of every crawler run; err is nil for a
+// successful run or one without change. Runs paused by an exhausted request
+// budget are not passed to the hooks.
+type RunHook func(ctx context.Context, name string, duration time.Duration, err error)
+
+// Scheduler runs registered crawlers on their cron schedules
+type Scheduler struct {
+    registry *crawler.Registry
+    cron     *cron.Cron
+    timeout  time.Duration
+    hooks    []RunHook
+
+    mu      sync.Mutex
+    running map[string]bool
+    // paused holds crawlers whose request budget is exhausted, until it resets
+    paused map[string]time.Time
+}
+
+// NewScheduler creates a new Scheduler; timeout bounds a single crawler run
+func NewScheduler(registry *crawler.Registry, timeout time.Duration) *Scheduler {
+    return &Scheduler{
+        registry: registry,
+        cron:     cron.New(),
+        timeout:  timeout,
+        running:  make(map[string]bool),
+        paused:   make(map[string]time.Time),
+    }
+}
+
+// OnRun adds a hook called after every run. Skipped runs and runs
+// interrupted by the cancellation of the scheduler's context are not reported.
+// Hooks must be added before Start.
+func (s *Scheduler) OnRun(hook RunHook) {
+    s.hooks = append(s.hooks, hook)
+}
+
+// Start schedules every registered crawler that has a schedule and starts the cron loop
+func (s *Scheduler) Start(ctx context.Context) error {
+    for _, c := range s.registry.All() {
+        if c.Schedule() == "" {
+            log.Printf("Crawler %s has no schedule, skipping", c.Name())
+            continue
+        }
+        c := c
+        if _, err := s.cron.AddFunc(c.Schedule(), func() {
+            if err := s.Run(ctx, c); err != nil {
+                log.Printf("Crawler %s failed: %v", c.Name(), err)
+            }
+        }); err != nil {
+            return fmt.Errorf("invalid schedule %q for crawler %s: %w", c.Schedule(), c.Name(), err)
+        }
+        log.Printf("Scheduled crawler %s: %s", c.Name(), c.Schedule())
+    }
+
+    s.cron.Start()
+    return nil
+}
+
+// Stop stops scheduling new runs and waits for running ones to finish
+func (s *Scheduler) Stop() {
+    <-s.cron.Stop().Done()
+}
+
+// Run runs a crawler once, skipping it when a previous run is still in
+// progress or its request budget is exhausted
+func (s *Scheduler) Run(ctx context.Context, c crawler.Crawler) error {
+    s.mu.Lock()
+    if until, ok := s.paused[c.Name()]; ok {
+        if time.Now().Before(until) {
+            s.mu.Unlock()
+            log.Printf("Crawler %s is paused until %s, skipping", c.Name(), until.Format(time.RFC3339))
+            return nil
+        }
+        delete(s.paused, c.Name())
+    }
+    if s.running[c.Name()] {
+        s.mu.Unlock()
+        log.Printf("Crawler %s is still running, skipping", c.Name())
+        return nil
+    }
+    s.running[c.Name()] = true
+    s.mu.Unlock()
+
+    defer func() {
+        s.mu.Lock()
+        delete(s.running, c.Name())
+        s.mu.Unlock()
+    }()
+
+    parent := ctx
+    if s.timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, s.timeout)
+        defer cancel()
+    }
+
+    ctx, span := tracing.Tracer().Start(ctx, "crawl "+c.Name())
+    start := time.Now()
+    log.Printf("Starting crawler: %s", c.Name())
+    err := c.Crawl(ctx)
+    duration := time.Since(start)
+    if errors.Is(err, crawler.ErrNoChange) {
+        tracing.End(span, nil)
+    } else {
+        tracing.End(span, err)
+    }
+    var budgetErr *ratelimit.BudgetError
+    if errors.As(err, &budgetErr) {
+        // A pause is neither a success nor a failure
+        crawler.RecordPause(c.Name(), duration)
+        s.mu.Lock()
+        s.paused[c.Name()] = budgetErr.ResetAt
+        s.mu.Unlock()
+        log.Printf("Crawler %s paused until %s: request budget of %s exhausted",
+            c.Name(), budgetErr.ResetAt.Format(time.RFC3339), budgetErr.Host)
+        return err
+    }
+    crawler.RecordRun(c.Name(), duration, err)
+    if errors.Is(err, crawler.ErrNoChange) {
+        log.Printf("Crawler %s completed in %s: no change", c.Name(), duration.Round(time.Millisecond))
+        err = nil
+    } else if err == nil {
+        log.Printf("Crawler %s completed successfully in %s", c.Name(), duration.Round(time.Millisecond))
+    }
+    if err == nil || parent.Err() == nil {
+        for _, hook := range s.hooks {
+            hook(parent, c.Name(), duration, err)
+        }
+    }
+    return err
+} 
\ No newline at end of file
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/crawler"
+    "github.com/yourusername/investutil-gocrawler/internal/ratelimit"
+)
+
+// stubCrawler returns the next of its errors on every crawl
+type stubCrawler struct {
+    errs  []error
+    calls int
+}
+
+func (c *stubCrawler) Name() string       { return "stub" }
+func (c *stubCrawler) Schedule() string   { return "@daily" }
+func (c *stubCrawler) LastRun() time.Time { return time.Time{} }
+func (c *stubCrawler) Crawl(context.Context) error {
+    err := c.errs[c.calls]
+    c.calls++
+    return err
+}
+
+func TestBudgetPauseIsNotAFailure(t *testing.T) {
+    budgetErr := &ratelimit.BudgetError{Host: "api.example.com", Limit: 10, ResetAt: time.Now().Add(time.Hour)}
+    c := &stubCrawler{errs: []error{errors.New("boom"), budgetErr, nil}}
+    s := NewScheduler(crawler.NewRegistry(), 0)
+
+    var outcomes []error
+    s.OnRun(func(ctx context.Context, name string, duration time.Duration, err error) {
+        outcomes = append(outcomes, err)
+    })
+
+    ctx := context.Background()
+    s.Run(ctx, c)
+    if err := s.Run(ctx, c); !errors.Is(err, ratelimit.ErrBudgetExhausted) {
+        t.Fatalf("Run = %v, want the budget error", err)
+    }
+    // Paused until the budget resets
+    if err := s.Run(ctx, c); err != nil || c.calls != 2 {
+        t.Errorf("Run while paused = %v after %d crawls, want it skipped", err, c.calls)
+    }
+
+    if len(outcomes) != 1 || outcomes[0] == nil || errors.Is(outcomes[0], ratelimit.ErrBudgetExhausted) {
+        t.Errorf("hooks saw %v, want only the failure", outcomes)
+    }
+} 
\ No newline at end of file
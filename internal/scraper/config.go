@@ -0,0 +1,92 @@
+package scraper
+
+import (
+    "fmt"
+    "net/url"
+    "time"
+)
+
+// Field types supported by FieldConfig.Type
+const (
+    TypeString = "string"
+    TypeInt    = "int"
+    TypeFloat  = "float"
+    TypeBool   = "bool"
+    TypeTime   = "time"
+)
+
+// SiteConfig declares how a site is crawled and what is extracted from it
+type SiteConfig struct {
+    Name      string   `yaml:"name"`
+    StartURLs []string `yaml:"start_urls"`
+    // AllowedDomains defaults to the hosts of the start URLs
+    AllowedDomains []string `yaml:"allowed_domains"`
+    // MaxDepth bounds how deep links are followed, the start pages being
+    // depth 1. It defaults to 2; a negative value removes the limit.
+    MaxDepth int `yaml:"max_depth"`
+    // IgnoreRobots skips the robots.txt check, which is on by default
+    IgnoreRobots bool          `yaml:"ignore_robots"`
+    UserAgent    string        `yaml:"user_agent"`
+    UserAgents   []string      `yaml:"user_agents"`
+    Parallelism  int           `yaml:"parallelism"`
+    Delay        time.Duration `yaml:"delay"`
+    Timeout      time.Duration `yaml:"timeout"`
+    // Follow is a selector for links that lead to more pages of the site
+    Follow string       `yaml:"follow"`
+    Record RecordConfig `yaml:"record"`
+}
+
+// RecordConfig declares how records are found on a page
+type RecordConfig struct {
+    // Selector matches one element per record, e.g. a table row
+    Selector string        `yaml:"selector"`
+    Fields   []FieldConfig `yaml:"fields"`
+}
+
+// FieldConfig declares how a single field is extracted from a record element
+type FieldConfig struct {
+    Name string `yaml:"name"`
+    // Selector is relative to the record element; empty means the element itself
+    Selector string `yaml:"selector"`
+    // Attr reads an attribute instead of the element text
+    Attr     string `yaml:"attr"`
+    Type     string `yaml:"type"`
+    Layout   string `yaml:"layout"`
+    Required bool   `yaml:"required"`
+}
+
+// Validate checks the site configuration for missing or unknown settings
+func (s *SiteConfig) Validate() error {
+    if s.Name == "" {
+        return fmt.Errorf("site name is required")
+    }
+    if len(s.StartURLs) == 0 {
+        return fmt.Errorf("site %s: at least one start url is required", s.Name)
+    }
+    for _, raw := range s.StartURLs {
+        if u, err := url.Parse(raw); err != nil || u.Hostname() == "" {
+            return fmt.Errorf("site %s: invalid start url %q", s.Name, raw)
+        }
+    }
+    if s.Record.Selector == "" {
+        return fmt.Errorf("site %s: record selector is required", s.Name)
+    }
+    if len(s.Record.Fields) == 0 {
+        return fmt.Errorf("site %s: at least one field is required", s.Name)
+    }
+    for _, f := range s.Record.Fields {
+        if f.Name == "" {
+            return fmt.Errorf("site %s: field name is required", s.Name)
+        }
+        switch f.Type {
+        case "", TypeString, TypeInt, TypeFloat, TypeBool:
+        case TypeTime:
+            if f.Layout == "" {
+                return fmt.Errorf("site %s: field %s: time layout is required", s.Name, f.Name)
+            }
+        default:
+            return fmt.Errorf("site %s: field %s: unknown type %q", s.Name, f.Name, f.Type)
+        }
+    }
+    return nil
+} 
\ No newline at end of file
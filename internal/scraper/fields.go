@@ -0,0 +1,47 @@
+package scraper
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// numberReplacer strips thousands separators, currency signs and units from numbers
+var numberReplacer = strings.NewReplacer(",", "", "$", "", "€", "", "£", "", "%", "", " ", "", "\u00a0", "")
+
+// parseField converts raw extracted text into the field's declared type
+func parseField(f FieldConfig, raw string) (interface{}, error) {
+    raw = strings.TrimSpace(raw)
+
+    switch f.Type {
+    case "", TypeString:
+        return raw, nil
+    case TypeInt:
+        v, err := strconv.ParseInt(numberReplacer.Replace(raw), 10, 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid int %q: %w", raw, err)
+        }
+        return v, nil
+    case TypeFloat:
+        v, err := strconv.ParseFloat(numberReplacer.Replace(raw), 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid float %q: %w", raw, err)
+        }
+        return v, nil
+    case TypeBool:
+        v, err := strconv.ParseBool(strings.ToLower(raw))
+        if err != nil {
+            return nil, fmt.Errorf("invalid bool %q: %w", raw, err)
+        }
+        return v, nil
+    case TypeTime:
+        v, err := time.Parse(f.Layout, raw)
+        if err != nil {
+            return nil, fmt.Errorf("invalid time %q: %w", raw, err)
+        }
+        return v.UTC(), nil
+    default:
+        return nil, fmt.Errorf("unknown type %q", f.Type)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,217 @@
+package scraper
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "net/url"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/gocolly/colly/v2"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/proxy"
+)
+
+const (
+    defaultUserAgent = "investutil-gocrawler/1.0"
+    defaultMaxDepth  = 2
+)
+
+// Scraper extracts typed records from a site described by a SiteConfig
+type Scraper struct {
+    client *httpclient.Client
+    config *SiteConfig
+}
+
+// NewScraper creates a new Scraper after validating the site configuration
+func NewScraper(client *httpclient.Client, config *SiteConfig) (*Scraper, error) {
+    if err := config.Validate(); err != nil {
+        return nil, err
+    }
+    return &Scraper{client: client, config: config}, nil
+}
+
+// Scrape visits the start URLs, follows links within the configured limits
+// and returns the records extracted from every visited page
+func (s *Scraper) Scrape(ctx context.Context) ([]models.Record, error) {
+    c, err := s.newCollector()
+    if err != nil {
+        return nil, err
+    }
+
+    var (
+        mu      sync.Mutex
+        records []models.Record
+        errs    []error
+    )
+
+    // Stop issuing requests once the context is done
+    userAgents := httpclient.NewUserAgents(s.config.UserAgents)
+    c.OnRequest(func(r *colly.Request) {
+        if ctx.Err() != nil {
+            r.Abort()
+            return
+        }
+        if ua := userAgents.Next(); ua != "" {
+            r.Headers.Set("User-Agent", ua)
+        }
+    })
+
+    c.OnHTML(s.config.Record.Selector, func(e *colly.HTMLElement) {
+        record, err := s.extract(e)
+        mu.Lock()
+        defer mu.Unlock()
+        if err != nil {
+            errs = append(errs, err)
+            return
+        }
+        records = append(records, record)
+    })
+
+    if s.config.Follow != "" {
+        c.OnHTML(s.config.Follow, func(e *colly.HTMLElement) {
+            link := e.Request.AbsoluteURL(e.Attr("href"))
+            if link == "" {
+                return
+            }
+            // Visit errors here are expected: already visited, depth or domain limits
+            _ = e.Request.Visit(link)
+        })
+    }
+
+    c.OnError(func(r *colly.Response, err error) {
+        mu.Lock()
+        defer mu.Unlock()
+        errs = append(errs, fmt.Errorf("failed to fetch %s: %w", r.Request.URL, err))
+    })
+
+    for _, u := range s.config.StartURLs {
+        if err := c.Visit(u); err != nil {
+            return nil, fmt.Errorf("failed to visit %s: %w", u, err)
+        }
+    }
+    c.Wait()
+
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+
+    // A page that failed entirely is fatal, a record that failed to parse is not
+    for _, err := range errs {
+        log.Printf("Scraper %s: %v", s.config.Name, err)
+    }
+    if len(records) == 0 && len(errs) > 0 {
+        return nil, fmt.Errorf("no records extracted: %w", errs[0])
+    }
+
+    return records, nil
+}
+
+// newCollector builds a colly collector honouring the site limits
+func (s *Scraper) newCollector() (*colly.Collector, error) {
+    userAgent := s.config.UserAgent
+    if userAgent == "" {
+        userAgent = defaultUserAgent
+    }
+
+    // Colly treats a depth of 0 as unlimited
+    maxDepth := s.config.MaxDepth
+    if maxDepth == 0 {
+        maxDepth = defaultMaxDepth
+    } else if maxDepth < 0 {
+        maxDepth = 0
+    }
+
+    c := colly.NewCollector(
+        colly.UserAgent(userAgent),
+        colly.MaxDepth(maxDepth),
+        colly.Async(true),
+    )
+    c.AllowedDomains = s.config.AllowedDomains
+    if len(c.AllowedDomains) == 0 {
+        c.AllowedDomains = startHosts(s.config.StartURLs)
+    }
+    c.IgnoreRobotsTxt = s.config.IgnoreRobots
+    // Share retries and logging with the other crawlers, keeping the site
+    // on one proxy when the pool is sticky
+    c.WithTransport(proxy.Sticky(s.client.Transport(), s.config.Name))
+
+    // The shared client bounds each attempt; this bounds a page including retries
+    timeout := s.config.Timeout
+    if timeout == 0 {
+        timeout = 5 * time.Minute
+    }
+    c.SetRequestTimeout(timeout)
+
+    parallelism := s.config.Parallelism
+    if parallelism < 1 {
+        parallelism = 1
+    }
+    if err := c.Limit(&colly.LimitRule{
+        DomainGlob:  "*",
+        Parallelism: parallelism,
+        Delay:       s.config.Delay,
+    }); err != nil {
+        return nil, fmt.Errorf("failed to set limits: %w", err)
+    }
+
+    return c, nil
+}
+
+// startHosts returns the distinct hosts of the start URLs
+func startHosts(urls []string) []string {
+    seen := make(map[string]bool, len(urls))
+    var hosts []string
+    for _, raw := range urls {
+        u, err := url.Parse(raw)
+        if err != nil || seen[u.Hostname()] {
+            continue
+        }
+        seen[u.Hostname()] = true
+        hosts = append(hosts, u.Hostname())
+    }
+    return hosts
+}
+
+// extract converts a record element into a typed record
+func (s *Scraper) extract(e *colly.HTMLElement) (models.Record, error) {
+    record := models.Record{
+        URL:       e.Request.URL.String(),
+        ScrapedAt: time.Now().UTC(),
+        Fields:    make(map[string]interface{}, len(s.config.Record.Fields)),
+    }
+
+    for _, f := range s.config.Record.Fields {
+        var raw string
+        switch {
+        case f.Selector == "" && f.Attr != "":
+            raw = e.Attr(f.Attr)
+        case f.Selector == "":
+            raw = e.Text
+        case f.Attr != "":
+            raw = e.ChildAttr(f.Selector, f.Attr)
+        default:
+            raw = e.ChildText(f.Selector)
+        }
+
+        raw = strings.TrimSpace(raw)
+        if raw == "" {
+            if f.Required {
+                return record, fmt.Errorf("%s: required field %s is empty", record.URL, f.Name)
+            }
+            continue
+        }
+
+        value, err := parseField(f, raw)
+        if err != nil {
+            return record, fmt.Errorf("%s: field %s: %w", record.URL, f.Name, err)
+        }
+        record.Fields[f.Name] = value
+    }
+
+    return record, nil
+} 
\ No newline at end of file
@@ -0,0 +1,197 @@
+package scraper
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "sort"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/httpclient"
+)
+
+// site serves a chain of pages, each holding one record and a link to the
+// next, along with robots.txt
+type site struct {
+    *httptest.Server
+    mu      sync.Mutex
+    visited []string
+}
+
+func newSite(t *testing.T, robots string, extraLink string) *site {
+    s := &site{}
+    s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/robots.txt" {
+            if robots == "" {
+                http.NotFound(w, r)
+                return
+            }
+            fmt.Fprint(w, robots)
+            return
+        }
+        s.mu.Lock()
+        s.visited = append(s.visited, r.URL.Path)
+        s.mu.Unlock()
+
+        var n int
+        fmt.Sscanf(r.URL.Path, "/page/%d", &n)
+        fmt.Fprintf(w, `<html><body><table><tr class="row"><td>page %d</td></tr></table>`+
+            `<a class="next" href="/page/%d">next</a><a class="next" href="/private">private</a>`+
+            `<a class="next" href="%s">elsewhere</a></body></html>`, n, n+1, extraLink)
+    }))
+    t.Cleanup(s.Close)
+    return s
+}
+
+func (s *site) pages() string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    visited := append([]string(nil), s.visited...)
+    sort.Strings(visited)
+    return strings.Join(visited, " ")
+}
+
+func scrape(t *testing.T, config *SiteConfig) error {
+    t.Helper()
+    config.Follow = "a.next"
+    config.Record = RecordConfig{Selector: "tr.row", Fields: []FieldConfig{{Name: "page"}}}
+    s, err := NewScraper(httpclient.New(httpclient.Config{MaxRetries: -1}), config)
+    if err != nil {
+        t.Fatal(err)
+    }
+    _, err = s.Scrape(context.Background())
+    return err
+}
+
+func TestScrapeLimits(t *testing.T) {
+    other := newSite(t, "", "")
+    // Both servers listen on 127.0.0.1, so the other one is reached as localhost
+    otherURL := strings.Replace(other.URL, "127.0.0.1", "localhost", 1) + "/page/100"
+    robots := "User-agent: *\nDisallow: /private\n"
+
+    tests := []struct {
+        name   string
+        config SiteConfig
+        pages  string
+    }{
+        // Defaults: two levels deep, the start host only, robots.txt honoured
+        {"defaults", SiteConfig{}, "/page/1 /page/2"},
+        {"deeper", SiteConfig{MaxDepth: 4}, "/page/1 /page/2 /page/3 /page/4"},
+        {"ignore robots", SiteConfig{IgnoreRobots: true}, "/page/1 /page/2 /private"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            s := newSite(t, robots, otherURL)
+            config := tt.config
+            config.Name = "test"
+            config.StartURLs = []string{s.URL + "/page/1"}
+            if err := scrape(t, &config); err != nil {
+                t.Fatalf("Scrape: %v", err)
+            }
+            if got := s.pages(); got != tt.pages {
+                t.Errorf("visited %q, want %q", got, tt.pages)
+            }
+        })
+    }
+    if got := other.pages(); got != "" {
+        t.Errorf("followed links off the start host to %q", got)
+    }
+
+    // Listing the other host lets the crawl reach it
+    s := newSite(t, robots, otherURL)
+    config := SiteConfig{Name: "test", StartURLs: []string{s.URL + "/page/1"}, AllowedDomains: []string{"127.0.0.1", "localhost"}}
+    if err := scrape(t, &config); err != nil {
+        t.Fatalf("Scrape: %v", err)
+    }
+    if got := other.pages(); got != "/page/100" {
+        t.Errorf("visited %q on the allowed host, want /page/100", got)
+    }
+}
+
+func TestScrapeExtractsFields(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/robots.txt" {
+            http.NotFound(w, r)
+            return
+        }
+        fmt.Fprint(w, `<html><body><table id="holdings">
+<tr><th>Ticker</th><th>Weight</th><th>Shares</th><th>Listed</th><th>Link</th></tr>
+<tr data-active="true"><td> BTC </td><td>45.5%</td><td>1,200</td><td>2009-01-03</td><td><a href="/btc">BTC</a></td></tr>
+<tr data-active="false"><td>ETH</td><td>$30.25</td><td>800</td><td></td><td><a href="/eth">ETH</a></td></tr>
+<tr data-active="true"><td></td><td>1</td><td>1</td><td>2020-01-01</td><td></td></tr>
+<tr data-active="true"><td>BAD</td><td>n/a</td><td>1</td><td>2020-01-01</td><td></td></tr>
+</table></body></html>`)
+    }))
+    defer server.Close()
+
+    s, err := NewScraper(httpclient.New(httpclient.Config{MaxRetries: -1}), &SiteConfig{
+        Name:      "holdings",
+        StartURLs: []string{server.URL},
+        Record: RecordConfig{
+            Selector: "table#holdings tr:has(td)",
+            Fields: []FieldConfig{
+                {Name: "ticker", Selector: "td:nth-child(1)", Required: true},
+                {Name: "weight", Selector: "td:nth-child(2)", Type: TypeFloat},
+                {Name: "shares", Selector: "td:nth-child(3)", Type: TypeInt},
+                {Name: "listed", Selector: "td:nth-child(4)", Type: TypeTime, Layout: "2006-01-02"},
+                {Name: "link", Selector: "a", Attr: "href"},
+                {Name: "active", Attr: "data-active", Type: TypeBool},
+            },
+        },
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    records, err := s.Scrape(context.Background())
+    if err != nil {
+        t.Fatalf("Scrape: %v", err)
+    }
+
+    // The row without a ticker and the one with an invalid weight are skipped
+    if len(records) != 2 {
+        t.Fatalf("extracted %d records, want 2: %+v", len(records), records)
+    }
+    btc := records[0].Fields
+    if btc["ticker"] != "BTC" || btc["weight"] != 45.5 || btc["shares"] != int64(1200) ||
+        btc["listed"] != time.Date(2009, 1, 3, 0, 0, 0, 0, time.UTC) || btc["link"] != "/btc" || btc["active"] != true {
+        t.Errorf("BTC record = %+v", btc)
+    }
+    eth := records[1].Fields
+    if _, ok := eth["listed"]; ok || eth["weight"] != 30.25 || eth["active"] != false {
+        t.Errorf("ETH record = %+v, want no listed date", eth)
+    }
+    if records[0].URL != server.URL {
+        t.Errorf("record URL %q, want %q", records[0].URL, server.URL)
+    }
+}
+
+func TestParseField(t *testing.T) {
+    tests := []struct {
+        field FieldConfig
+        raw   string
+        want  interface{}
+    }{
+        {FieldConfig{}, " text ", "text"},
+        {FieldConfig{Type: TypeInt}, "1,234,567", int64(1234567)},
+        {FieldConfig{Type: TypeFloat}, "€ 1,234.50", 1234.5},
+        {FieldConfig{Type: TypeFloat}, "12.5%", 12.5},
+        {FieldConfig{Type: TypeBool}, "TRUE", true},
+        {FieldConfig{Type: TypeTime, Layout: "Jan 2, 2006"}, "Mar 4, 2024", time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+    }
+    for _, tt := range tests {
+        got, err := parseField(tt.field, tt.raw)
+        if err != nil || got != tt.want {
+            t.Errorf("parseField(%q as %q) = %v, %v, want %v", tt.raw, tt.field.Type, got, err, tt.want)
+        }
+    }
+
+    for _, bad := range []FieldConfig{{Type: TypeInt}, {Type: TypeFloat}, {Type: TypeBool}, {Type: TypeTime, Layout: "2006-01-02"}} {
+        if _, err := parseField(bad, "abc"); err == nil {
+            t.Errorf("parseField(abc as %q) succeeded", bad.Type)
+        }
+    }
+} 
\ No newline at end of file
@@ -1,6 +1,12 @@
 package storage
 
-import "context"
+import (
+    "context"
+    "errors"
+)
+
+// ErrNotFound is returned by Load when no data is stored under the key
+var ErrNotFound = errors.New("storage: key not found")
 
 // Storage defines the interface for data storage operations
 type Storage interface {
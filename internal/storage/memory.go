@@ -0,0 +1,75 @@
+package storage
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "sort"
+    "strings"
+    "sync"
+)
+
+// MemoryStorage keeps data in memory, for offline runs and tests. Values
+// are stored as JSON so loads return copies, as with a real backend.
+type MemoryStorage struct {
+    mu   sync.RWMutex
+    data map[string][]byte
+}
+
+// NewMemoryStorage creates a new, empty MemoryStorage
+func NewMemoryStorage() *MemoryStorage {
+    return &MemoryStorage{
+        data: make(map[string][]byte),
+    }
+}
+
+// Save implements Storage.Save
+func (m *MemoryStorage) Save(ctx context.Context, key string, data interface{}) error {
+    b, err := json.Marshal(data)
+    if err != nil {
+        return fmt.Errorf("failed to marshal data: %w", err)
+    }
+
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.data[key] = b
+    return nil
+}
+
+// Load implements Storage.Load
+func (m *MemoryStorage) Load(ctx context.Context, key string, v interface{}) error {
+    m.mu.RLock()
+    b, ok := m.data[key]
+    m.mu.RUnlock()
+    if !ok {
+        return fmt.Errorf("failed to find document %s: %w", key, ErrNotFound)
+    }
+
+    if err := json.Unmarshal(b, v); err != nil {
+        return fmt.Errorf("failed to unmarshal data: %w", err)
+    }
+    return nil
+}
+
+// Delete implements Storage.Delete
+func (m *MemoryStorage) Delete(ctx context.Context, key string) error {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    delete(m.data, key)
+    return nil
+}
+
+// List implements Storage.List, returning keys in sorted order
+func (m *MemoryStorage) List(ctx context.Context, prefix string) ([]string, error) {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+
+    var keys []string
+    for key := range m.data {
+        if strings.HasPrefix(key, prefix) {
+            keys = append(keys, key)
+        }
+    }
+    sort.Strings(keys)
+    return keys, nil
+} 
\ No newline at end of file
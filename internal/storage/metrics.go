@@ -0,0 +1,25 @@
+package storage
+
+import (
+    "errors"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/metrics"
+)
+
+var (
+    operationDuration = metrics.NewHistogramVec("storage_operation_duration_seconds",
+        "Duration of storage operations by store and operation", nil, "store", "operation")
+    operationErrors = metrics.NewCounterVec("storage_operation_errors_total",
+        "Failed storage operations by store and operation", "store", "operation")
+)
+
+// ObserveOperation records the latency of an operation on store that started
+// at start, and its failure unless err is nil or ErrNotFound. It returns err.
+func ObserveOperation(store, operation string, start time.Time, err error) error {
+    operationDuration.WithLabelValues(store, operation).Observe(time.Since(start).Seconds())
+    if err != nil && !errors.Is(err, ErrNotFound) {
+        operationErrors.WithLabelValues(store, operation).Inc()
+    }
+    return err
+} 
\ No newline at end of file
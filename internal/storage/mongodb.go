@@ -2,6 +2,7 @@ package storage
 
 import (
     "context"
+    "errors"
     "fmt"
     "time"
 
@@ -23,6 +24,10 @@ type MongoDBConfig struct {
 }
 
 func NewMongoDBStorage(cfg MongoDBConfig) (*MongoDBStorage, error) {
+    if cfg.Collection == "" {
+        return nil, fmt.Errorf("mongodb storage: collection is required")
+    }
+
     ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
     defer cancel()
 
@@ -43,7 +48,13 @@ func NewMongoDBStorage(cfg MongoDBConfig) (*MongoDBStorage, error) {
     }, nil
 }
 
+// Save stores data under key
 func (m *MongoDBStorage) Save(ctx context.Context, key string, data interface{}) error {
+    start := time.Now()
+    return ObserveOperation("storage", "save", start, m.save(ctx, key, data))
+}
+
+func (m *MongoDBStorage) save(ctx context.Context, key string, data interface{}) error {
     coll := m.client.Database(m.database).Collection(m.collection)
     
     doc := bson.M{
@@ -66,11 +77,20 @@ func (m *MongoDBStorage) Save(ctx context.Context, key string, data interface{})
     return nil
 }
 
+// Load decodes the data stored under key into v
 func (m *MongoDBStorage) Load(ctx context.Context, key string, v interface{}) error {
+    start := time.Now()
+    return ObserveOperation("storage", "load", start, m.load(ctx, key, v))
+}
+
+func (m *MongoDBStorage) load(ctx context.Context, key string, v interface{}) error {
     coll := m.client.Database(m.database).Collection(m.collection)
     
     result := coll.FindOne(ctx, bson.M{"_id": key})
     if err := result.Err(); err != nil {
+        if errors.Is(err, mongo.ErrNoDocuments) {
+            return fmt.Errorf("failed to find document %s: %w", key, ErrNotFound)
+        }
         return fmt.Errorf("failed to find document: %w", err)
     }
 
@@ -96,7 +116,13 @@ func (m *MongoDBStorage) Load(ctx context.Context, key string, v interface{}) er
     return nil
 }
 
+// Delete removes the data stored under key
 func (m *MongoDBStorage) Delete(ctx context.Context, key string) error {
+    start := time.Now()
+    return ObserveOperation("storage", "delete", start, m.delete(ctx, key))
+}
+
+func (m *MongoDBStorage) delete(ctx context.Context, key string) error {
     coll := m.client.Database(m.database).Collection(m.collection)
     
     _, err := coll.DeleteOne(ctx, bson.M{"_id": key})
@@ -107,7 +133,14 @@ func (m *MongoDBStorage) Delete(ctx context.Context, key string) error {
     return nil
 }
 
+// List returns the keys starting with prefix
 func (m *MongoDBStorage) List(ctx context.Context, prefix string) ([]string, error) {
+    start := time.Now()
+    keys, err := m.list(ctx, prefix)
+    return keys, ObserveOperation("storage", "list", start, err)
+}
+
+func (m *MongoDBStorage) list(ctx context.Context, prefix string) ([]string, error) {
     coll := m.client.Database(m.database).Collection(m.collection)
     
     filter := bson.M{"_id": bson.M{"$regex": fmt.Sprintf("^%s", prefix)}}
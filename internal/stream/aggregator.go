@@ -0,0 +1,119 @@
+package stream
+
+import (
+    "log"
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Aggregator builds fixed interval OHLCV bars from trade and ticker events
+// and tracks trade id gaps. Trades and ticker prices go to separate bars.
+// It is not safe for concurrent use.
+type Aggregator struct {
+    interval  time.Duration
+    grace     time.Duration
+    bars      map[string]*models.Bar
+    lastTrade map[string]int64
+    closed    map[string]time.Time
+}
+
+// NewAggregator creates a new Aggregator. Grace is how long a bar stays open
+// after its interval ends, to accept trades that arrive late.
+func NewAggregator(interval, grace time.Duration) *Aggregator {
+    return &Aggregator{
+        interval:  interval,
+        grace:     grace,
+        bars:      make(map[string]*models.Bar),
+        lastTrade: make(map[string]int64),
+        closed:    make(map[string]time.Time),
+    }
+}
+
+// Add applies an event and returns the bars it completed, along with the
+// number of trades found missing before it
+func (a *Aggregator) Add(e Event) ([]models.Bar, int64) {
+    source := ChannelTrades
+    if e.Type == EventTicker {
+        source = ChannelTicker
+    }
+    key := e.Exchange + ":" + e.Symbol + ":" + source
+    missed := a.checkSequence(key, e)
+
+    start := e.Time.Truncate(a.interval)
+    if closedAt, ok := a.closed[key]; ok && !start.After(closedAt) {
+        log.Printf("Stream %s: dropping late %s at %s", key, e.Type, e.Time.Format(time.RFC3339))
+        return nil, missed
+    }
+
+    var completed []models.Bar
+    bar, ok := a.bars[key]
+    if ok && start.After(bar.Start) {
+        completed = append(completed, *bar)
+        a.closed[key] = bar.Start
+        ok = false
+    }
+    if !ok {
+        bar = &models.Bar{
+            Exchange: e.Exchange,
+            Symbol:   e.Symbol,
+            Start:    start,
+            Interval: a.interval.String(),
+            Source:   source,
+            Open:     e.Price,
+            High:     e.Price,
+            Low:      e.Price,
+        }
+        a.bars[key] = bar
+    }
+
+    if e.Price > bar.High {
+        bar.High = e.Price
+    }
+    if e.Price < bar.Low {
+        bar.Low = e.Price
+    }
+    bar.Close = e.Price
+    bar.MissedTrades += missed
+    if e.Type == EventTrade {
+        bar.Volume += e.Quantity
+        bar.Trades++
+    }
+
+    return completed, missed
+}
+
+// Flush returns the bars whose interval and grace period ended before now
+func (a *Aggregator) Flush(now time.Time) []models.Bar {
+    var completed []models.Bar
+    for key, bar := range a.bars {
+        if bar.Start.Add(a.interval + a.grace).Before(now) {
+            completed = append(completed, *bar)
+            a.closed[key] = bar.Start
+            delete(a.bars, key)
+        }
+    }
+    sort.Slice(completed, func(i, j int) bool {
+        return completed[i].Start.Before(completed[j].Start)
+    })
+    return completed
+}
+
+// checkSequence returns how many trades were skipped before this trade
+func (a *Aggregator) checkSequence(key string, e Event) int64 {
+    if e.Type != EventTrade || e.TradeID == 0 {
+        return 0
+    }
+
+    last, seen := a.lastTrade[key]
+    var missed int64
+    if seen && e.TradeID > last+1 {
+        missed = e.TradeID - last - 1
+        log.Printf("Stream %s: sequence gap, missed %d trades after trade %d", key, missed, last)
+    }
+    if !seen || e.TradeID > last {
+        a.lastTrade[key] = e.TradeID
+    }
+    return missed
+} 
\ No newline at end of file
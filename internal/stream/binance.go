@@ -0,0 +1,115 @@
+package stream
+
+import (
+    "encoding/json"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+const binanceStreamURL = "wss://stream.binance.com:9443/ws"
+
+// binanceFeed implements Feed for the Binance raw stream API
+type binanceFeed struct {
+    url      string
+    symbols  []string
+    channels []string
+}
+
+func newBinanceFeed(url string, symbols, channels []string) *binanceFeed {
+    if url == "" {
+        url = binanceStreamURL
+    }
+    return &binanceFeed{url: url, symbols: symbols, channels: channels}
+}
+
+// Exchange implements Feed.Exchange
+func (f *binanceFeed) Exchange() string {
+    return "binance"
+}
+
+// URL implements Feed.URL
+func (f *binanceFeed) URL() string {
+    return f.url
+}
+
+// Subscriptions implements Feed.Subscriptions
+func (f *binanceFeed) Subscriptions() []interface{} {
+    var params []string
+    for _, s := range f.symbols {
+        for _, ch := range f.channels {
+            switch ch {
+            case ChannelTrades:
+                params = append(params, strings.ToLower(s)+"@trade")
+            case ChannelTicker:
+                params = append(params, strings.ToLower(s)+"@ticker")
+            }
+        }
+    }
+    return []interface{}{
+        map[string]interface{}{
+            "method": "SUBSCRIBE",
+            "params": params,
+            "id":     1,
+        },
+    }
+}
+
+// binanceMessage covers the fields of trade and 24hrTicker stream messages
+type binanceMessage struct {
+    EventType string `json:"e"`
+    EventTime int64  `json:"E"`
+    Symbol    string `json:"s"`
+    TradeID   int64  `json:"t"`
+    Price     string `json:"p"`
+    Quantity  string `json:"q"`
+    TradeTime int64  `json:"T"`
+    LastPrice string `json:"c"`
+}
+
+// Parse implements Feed.Parse
+func (f *binanceFeed) Parse(msg []byte) ([]Event, error) {
+    var m binanceMessage
+    if err := json.Unmarshal(msg, &m); err != nil {
+        return nil, fmt.Errorf("failed to decode message: %w", err)
+    }
+
+    switch m.EventType {
+    case "trade":
+        price, err := strconv.ParseFloat(m.Price, 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid price %q: %w", m.Price, err)
+        }
+        qty, err := strconv.ParseFloat(m.Quantity, 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid quantity %q: %w", m.Quantity, err)
+        }
+        return []Event{{
+            Type:     EventTrade,
+            Exchange: f.Exchange(),
+            Symbol:   m.Symbol,
+            Price:    price,
+            Quantity: qty,
+            Time:     time.UnixMilli(m.TradeTime).UTC(),
+            TradeID:  m.TradeID,
+        }}, nil
+
+    case "24hrTicker":
+        price, err := strconv.ParseFloat(m.LastPrice, 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid price %q: %w", m.LastPrice, err)
+        }
+        return []Event{{
+            Type:     EventTicker,
+            Exchange: f.Exchange(),
+            Symbol:   m.Symbol,
+            Price:    price,
+            Time:     time.UnixMilli(m.EventTime).UTC(),
+        }}, nil
+
+    default:
+        // Subscription acknowledgements and other messages
+        return nil, nil
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,177 @@
+package stream
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/gorilla/websocket"
+)
+
+const (
+    defaultHeartbeat    = 30 * time.Second
+    defaultMaxReconnect = time.Minute
+    defaultMinResync    = time.Minute
+)
+
+// errResync ends a session that is resubscribed after a sequence gap
+var errResync = errors.New("resubscribing after a sequence gap")
+
+// Client keeps a feed connected, resubscribing after every reconnect
+type Client struct {
+    feed         Feed
+    dialer       *websocket.Dialer
+    heartbeat    time.Duration
+    maxReconnect time.Duration
+    minResync    time.Duration
+    resync       chan struct{}
+
+    mu         sync.Mutex
+    lastResync time.Time
+}
+
+// NewClient creates a new Client. Heartbeat is how often pings are sent; a
+// connection that stays silent for two heartbeats is considered dead.
+func NewClient(feed Feed, heartbeat time.Duration) *Client {
+    if heartbeat <= 0 {
+        heartbeat = defaultHeartbeat
+    }
+    return &Client{
+        feed: feed,
+        dialer: &websocket.Dialer{
+            HandshakeTimeout: 10 * time.Second,
+        },
+        heartbeat:    heartbeat,
+        maxReconnect: defaultMaxReconnect,
+        minResync:    defaultMinResync,
+        resync:       make(chan struct{}, 1),
+    }
+}
+
+// Resync reconnects and resubscribes the feed, e.g. after a sequence gap
+// showed that the exchange dropped messages. A resync within a minute of the
+// last subscription is ignored, since the trades missed while (re)connecting
+// show up as another gap.
+func (c *Client) Resync() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if !c.lastResync.IsZero() && time.Since(c.lastResync) < c.minResync {
+        return
+    }
+    c.lastResync = time.Now()
+    select {
+    case c.resync <- struct{}{}:
+    default:
+    }
+}
+
+// Run streams events to handler until ctx is done, reconnecting with
+// exponential backoff whenever the connection drops
+func (c *Client) Run(ctx context.Context, handler func(Event)) error {
+    backoff := time.Second
+    for {
+        start := time.Now()
+        err := c.session(ctx, handler)
+        if ctx.Err() != nil {
+            return nil
+        }
+        if errors.Is(err, errResync) {
+            log.Printf("Stream %s: %v", c.feed.Exchange(), err)
+            continue
+        }
+
+        // A session that lived for a while resets the backoff
+        if time.Since(start) > c.maxReconnect {
+            backoff = time.Second
+        }
+        log.Printf("Stream %s disconnected: %v, reconnecting in %s", c.feed.Exchange(), err, backoff)
+
+        select {
+        case <-ctx.Done():
+            return nil
+        case <-time.After(backoff):
+        }
+        backoff *= 2
+        if backoff > c.maxReconnect {
+            backoff = c.maxReconnect
+        }
+    }
+}
+
+// session runs a single connection until it fails or ctx is done
+func (c *Client) session(ctx context.Context, handler func(Event)) error {
+    conn, _, err := c.dialer.DialContext(ctx, c.feed.URL(), nil)
+    if err != nil {
+        return fmt.Errorf("failed to connect: %w", err)
+    }
+    defer conn.Close()
+
+    for _, sub := range c.feed.Subscriptions() {
+        if err := conn.WriteJSON(sub); err != nil {
+            return fmt.Errorf("failed to subscribe: %w", err)
+        }
+    }
+    log.Printf("Stream %s connected and subscribed", c.feed.Exchange())
+    c.mu.Lock()
+    c.lastResync = time.Now()
+    c.mu.Unlock()
+
+    // Any frame, including pongs, proves the connection is alive
+    deadline := 2 * c.heartbeat
+    conn.SetReadDeadline(time.Now().Add(deadline))
+    conn.SetPongHandler(func(string) error {
+        return conn.SetReadDeadline(time.Now().Add(deadline))
+    })
+
+    var resyncing atomic.Bool
+    done := make(chan struct{})
+    defer close(done)
+    go func() {
+        ticker := time.NewTicker(c.heartbeat)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ctx.Done():
+                // Unblock the read loop
+                conn.WriteControl(websocket.CloseMessage,
+                    websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+                conn.Close()
+                return
+            case <-done:
+                return
+            case <-c.resync:
+                resyncing.Store(true)
+                conn.Close()
+                return
+            case <-ticker.C:
+                if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.heartbeat)); err != nil {
+                    return
+                }
+            }
+        }
+    }()
+
+    for {
+        _, msg, err := conn.ReadMessage()
+        if err != nil {
+            if resyncing.Load() {
+                return errResync
+            }
+            return fmt.Errorf("failed to read message: %w", err)
+        }
+        conn.SetReadDeadline(time.Now().Add(deadline))
+
+        events, err := c.feed.Parse(msg)
+        if err != nil {
+            log.Printf("Stream %s: %v", c.feed.Exchange(), err)
+            continue
+        }
+        for _, e := range events {
+            handler(e)
+        }
+    }
+} 
\ No newline at end of file
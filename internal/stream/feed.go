@@ -0,0 +1,79 @@
+package stream
+
+import (
+    "fmt"
+    "time"
+)
+
+// Event types produced by feeds
+const (
+    EventTrade  = "trade"
+    EventTicker = "ticker"
+)
+
+// Channels supported by FeedConfig.Channels
+const (
+    ChannelTrades = "trades"
+    ChannelTicker = "ticker"
+)
+
+// Event is a normalized message from an exchange feed
+type Event struct {
+    Type     string
+    Exchange string
+    Symbol   string
+    Price    float64
+    Quantity float64
+    Time     time.Time
+    // TradeID is the exchange's sequential trade id of a trade, zero when
+    // unknown
+    TradeID int64
+}
+
+// Feed adapts an exchange WebSocket API to normalized events
+type Feed interface {
+    // Exchange returns the exchange name
+    Exchange() string
+
+    // URL returns the WebSocket endpoint
+    URL() string
+
+    // Subscriptions returns the messages sent after every (re)connect
+    Subscriptions() []interface{}
+
+    // Parse converts a raw message into events; unknown messages yield none
+    Parse(msg []byte) ([]Event, error)
+}
+
+// FeedConfig declares an exchange feed
+type FeedConfig struct {
+    Exchange string   `yaml:"exchange"`
+    URL      string   `yaml:"url"`
+    Symbols  []string `yaml:"symbols"`
+    Channels []string `yaml:"channels"`
+}
+
+// NewFeed creates the feed for the configured exchange
+func NewFeed(cfg FeedConfig) (Feed, error) {
+    if len(cfg.Symbols) == 0 {
+        return nil, fmt.Errorf("%s feed: at least one symbol is required", cfg.Exchange)
+    }
+    channels := cfg.Channels
+    if len(channels) == 0 {
+        channels = []string{ChannelTrades}
+    }
+    for _, ch := range channels {
+        if ch != ChannelTrades && ch != ChannelTicker {
+            return nil, fmt.Errorf("%s feed: unknown channel %q", cfg.Exchange, ch)
+        }
+    }
+
+    switch cfg.Exchange {
+    case "binance":
+        return newBinanceFeed(cfg.URL, cfg.Symbols, channels), nil
+    case "coinbase":
+        return newCoinbaseFeed(cfg.URL, cfg.Symbols, channels), nil
+    default:
+        return nil, fmt.Errorf("unsupported exchange %q", cfg.Exchange)
+    }
+} 
\ No newline at end of file
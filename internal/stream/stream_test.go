@@ -0,0 +1,203 @@
+package stream
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/gorilla/websocket"
+)
+
+// exchange is a local stand-in for an exchange WebSocket feed. It records the
+// subscriptions of every connection and answers each with the messages of
+// the matching entry of sessions.
+type exchange struct {
+    *httptest.Server
+    sessions [][]string
+
+    mu            sync.Mutex
+    subscriptions []map[string]interface{}
+}
+
+func newExchange(t *testing.T, sessions ...[]string) *exchange {
+    x := &exchange{sessions: sessions}
+    upgrader := websocket.Upgrader{}
+    x.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        conn, err := upgrader.Upgrade(w, r, nil)
+        if err != nil {
+            t.Errorf("upgrade: %v", err)
+            return
+        }
+        defer conn.Close()
+
+        var sub map[string]interface{}
+        if err := conn.ReadJSON(&sub); err != nil {
+            return
+        }
+        x.mu.Lock()
+        n := len(x.subscriptions)
+        x.subscriptions = append(x.subscriptions, sub)
+        x.mu.Unlock()
+
+        if n < len(x.sessions) {
+            for _, msg := range x.sessions[n] {
+                if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+                    return
+                }
+            }
+        }
+        // Stay connected until the client goes away
+        for {
+            if _, _, err := conn.ReadMessage(); err != nil {
+                return
+            }
+        }
+    }))
+    t.Cleanup(x.Close)
+    return x
+}
+
+func (x *exchange) url() string {
+    return "ws" + strings.TrimPrefix(x.URL, "http")
+}
+
+func (x *exchange) subscribed() []map[string]interface{} {
+    x.mu.Lock()
+    defer x.mu.Unlock()
+    return append([]map[string]interface{}(nil), x.subscriptions...)
+}
+
+func coinbaseMatch(id int64, price, size string, at string) string {
+    b, _ := json.Marshal(map[string]interface{}{
+        "type":       "match",
+        "product_id": "BTC-USD",
+        "trade_id":   id,
+        "price":      price,
+        "size":       size,
+        "time":       at,
+    })
+    return string(b)
+}
+
+// collect runs client until n events arrived, passing each to handle
+func collect(t *testing.T, client *Client, n int, handle func(Event)) []Event {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    var events []Event
+    client.Run(ctx, func(e Event) {
+        events = append(events, e)
+        if handle != nil {
+            handle(e)
+        }
+        if len(events) == n {
+            cancel()
+        }
+    })
+    if len(events) < n {
+        t.Fatalf("received %d events, want %d", len(events), n)
+    }
+    return events
+}
+
+func TestCoinbaseFeedSubscribesWithoutHeartbeat(t *testing.T) {
+    x := newExchange(t, []string{
+        `{"type":"subscriptions"}`,
+        coinbaseMatch(1, "100000.00", "0.5", "2024-12-05T09:00:01Z"),
+        `{"type":"ticker","product_id":"BTC-USD","price":"100010.00","time":"2024-12-05T09:00:02Z"}`,
+    })
+    feed, err := NewFeed(FeedConfig{Exchange: "coinbase", URL: x.url(), Symbols: []string{"BTC-USD"}, Channels: []string{ChannelTrades, ChannelTicker}})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    events := collect(t, NewClient(feed, time.Second), 2, nil)
+    if events[0].Type != EventTrade || events[0].TradeID != 1 || events[1].Type != EventTicker {
+        t.Errorf("events = %+v", events)
+    }
+
+    subs := x.subscribed()
+    if len(subs) != 1 {
+        t.Fatalf("%d subscriptions, want 1", len(subs))
+    }
+    channels, _ := json.Marshal(subs[0]["channels"])
+    if string(channels) != `["matches","ticker"]` {
+        t.Errorf("subscribed to %s", channels)
+    }
+}
+
+func TestClientResubscribesAfterGap(t *testing.T) {
+    x := newExchange(t,
+        []string{
+            coinbaseMatch(10, "100000.00", "0.1", "2024-12-05T09:00:01Z"),
+            coinbaseMatch(11, "100001.00", "0.1", "2024-12-05T09:00:02Z"),
+            coinbaseMatch(15, "100002.00", "0.1", "2024-12-05T09:00:03Z"),
+        },
+        []string{
+            coinbaseMatch(16, "100003.00", "0.1", "2024-12-05T09:00:04Z"),
+        },
+    )
+    feed, err := NewFeed(FeedConfig{Exchange: "coinbase", URL: x.url(), Symbols: []string{"BTC-USD"}})
+    if err != nil {
+        t.Fatal(err)
+    }
+    client := NewClient(feed, time.Second)
+    client.minResync = 0
+
+    agg := NewAggregator(time.Minute, time.Second)
+    var missed int64
+    collect(t, client, 4, func(e Event) {
+        _, m := agg.Add(e)
+        if m > 0 {
+            missed += m
+            client.Resync()
+        }
+    })
+
+    if missed != 3 {
+        t.Errorf("missed %d trades, want 3", missed)
+    }
+    if n := len(x.subscribed()); n != 2 {
+        t.Errorf("%d subscriptions, want a resubscription after the gap", n)
+    }
+}
+
+func TestAggregatorKeepsTickersOutOfTradeBars(t *testing.T) {
+    agg := NewAggregator(time.Minute, 0)
+    at := time.Date(2024, 12, 5, 9, 0, 0, 0, time.UTC)
+    events := []Event{
+        {Type: EventTrade, Exchange: "binance", Symbol: "BTCUSDT", Price: 100, Quantity: 1, Time: at.Add(time.Second), TradeID: 1},
+        {Type: EventTicker, Exchange: "binance", Symbol: "BTCUSDT", Price: 150, Time: at.Add(2 * time.Second)},
+        {Type: EventTrade, Exchange: "binance", Symbol: "BTCUSDT", Price: 101, Quantity: 2, Time: at.Add(3 * time.Second), TradeID: 2},
+        {Type: EventTicker, Exchange: "binance", Symbol: "BTCUSDT", Price: 90, Time: at.Add(4 * time.Second)},
+    }
+    for _, e := range events {
+        if bars, missed := agg.Add(e); len(bars) != 0 || missed != 0 {
+            t.Fatalf("Add(%+v) = %v, %d", e, bars, missed)
+        }
+    }
+
+    bars := agg.Flush(at.Add(2 * time.Minute))
+    if len(bars) != 2 {
+        t.Fatalf("flushed %d bars, want a trade and a ticker bar", len(bars))
+    }
+    for _, bar := range bars {
+        switch bar.Source {
+        case ChannelTrades:
+            if bar.Open != 100 || bar.High != 101 || bar.Low != 100 || bar.Close != 101 || bar.Volume != 3 || bar.Trades != 2 {
+                t.Errorf("trade bar = %+v", bar)
+            }
+        case ChannelTicker:
+            if bar.Open != 150 || bar.High != 150 || bar.Low != 90 || bar.Close != 90 || bar.Volume != 0 {
+                t.Errorf("ticker bar = %+v", bar)
+            }
+        default:
+            t.Errorf("bar with source %q", bar.Source)
+        }
+    }
+} 
\ No newline at end of file
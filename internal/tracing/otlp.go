@@ -0,0 +1,38 @@
+package tracing
+
+import (
+    "context"
+    "fmt"
+    "net/url"
+    "strings"
+    "time"
+
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+// NewExporter creates an OTLP/HTTP exporter posting the spans to
+// <endpoint>/v1/traces. It uses its own HTTP client: the module's client
+// traces its requests, which would export spans about exporting spans.
+func NewExporter(ctx context.Context, config Config) (*otlptrace.Exporter, error) {
+    u, err := url.Parse(config.Endpoint)
+    if err != nil || u.Scheme == "" || u.Host == "" {
+        return nil, fmt.Errorf("tracing: invalid endpoint %q", config.Endpoint)
+    }
+    if config.Timeout <= 0 {
+        config.Timeout = 10 * time.Second
+    }
+
+    opts := []otlptracehttp.Option{
+        otlptracehttp.WithEndpointURL(strings.TrimSuffix(config.Endpoint, "/") + "/v1/traces"),
+        otlptracehttp.WithTimeout(config.Timeout),
+    }
+    if len(config.Headers) > 0 {
+        opts = append(opts, otlptracehttp.WithHeaders(config.Headers))
+    }
+    exporter, err := otlptracehttp.New(ctx, opts...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create exporter: %w", err)
+    }
+    return exporter, nil
+} 
\ No newline at end of file
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/propagation"
+    "go.opentelemetry.io/otel/sdk/resource"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer of every span the packages start
+const instrumentationName = "github.com/yourusername/investutil-gocrawler"
+
+// Config holds configuration for tracing
+type Config struct {
+    // Endpoint enables tracing when set. It is the base URL of an OTLP/HTTP
+    // receiver, e.g. http://localhost:4318; spans are posted to
+    // <endpoint>/v1/traces.
+    Endpoint string `yaml:"endpoint"`
+    // Headers are added to export requests, e.g. an API key
+    Headers map[string]string `yaml:"headers"`
+    // ServiceName defaults to the name the binary passes to Setup
+    ServiceName string `yaml:"service_name"`
+    // SampleRatio is the fraction of new traces recorded, defaults to 1.
+    // Traces continued from a message or a request follow the sender's
+    // decision.
+    SampleRatio *float64 `yaml:"sample_ratio"`
+    // Timeout bounds each export request, defaults to 10s
+    Timeout time.Duration `yaml:"timeout"`
+}
+
+// Tracer returns the tracer used for the spans of this module. Until Setup
+// or Use installs a provider, its spans are not recorded.
+func Tracer() trace.Tracer {
+    return otel.Tracer(instrumentationName)
+}
+
+// NewTracerProvider creates a provider batching the spans of service to
+// exporter, sampling sampleRatio of the new traces. Tests pass an in-memory
+// exporter such as tracetest.NewInMemoryExporter.
+func NewTracerProvider(service string, exporter sdktrace.SpanExporter, sampleRatio float64) *sdktrace.TracerProvider {
+    res := resource.NewSchemaless(attribute.String("service.name", service))
+    return sdktrace.NewTracerProvider(
+        sdktrace.WithBatcher(exporter),
+        sdktrace.WithResource(res),
+        sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+    )
+}
+
+// Use installs provider as the global tracer provider, along with the W3C
+// trace context propagator that carries traces across the queue
+func Use(provider trace.TracerProvider) {
+    otel.SetTracerProvider(provider)
+    otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// Setup installs a provider exporting to the configured OTLP endpoint and
+// returns a function flushing and stopping it. Without an endpoint it does
+// nothing and the spans are dropped.
+func Setup(config Config, service string) (func(context.Context) error, error) {
+    if config.Endpoint == "" {
+        return func(context.Context) error { return nil }, nil
+    }
+    if config.ServiceName != "" {
+        service = config.ServiceName
+    }
+    ratio := 1.0
+    if config.SampleRatio != nil {
+        ratio = *config.SampleRatio
+    }
+    if ratio < 0 || ratio > 1 {
+        return nil, fmt.Errorf("tracing: sample_ratio must be between 0 and 1")
+    }
+
+    exporter, err := NewExporter(context.Background(), config)
+    if err != nil {
+        return nil, err
+    }
+    provider := NewTracerProvider(service, exporter, ratio)
+    Use(provider)
+    return provider.Shutdown, nil
+}
+
+// End records err, if any, as the span's error status and ends the span
+func End(span trace.Span, err error) {
+    if err != nil {
+        span.RecordError(err)
+        span.SetStatus(codes.Error, err.Error())
+    }
+    span.End()
+} 
\ No newline at end of file
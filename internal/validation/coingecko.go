@@ -0,0 +1,47 @@
+package validation
+
+import (
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+var zero = 0.0
+
+// CoinGecko validates a market_chart response: the three arrays must be
+// non-empty and of equal length, timestamps increasing and values positive.
+// Points are paired by index, so market_caps and total_volumes must carry
+// the timestamps of prices.
+func CoinGecko(source string, resp *models.CoinGeckoResponse) error {
+    v := New(source)
+    v.NonEmpty("prices", len(resp.Prices))
+    v.EqualLength(map[string]int{
+        "prices":        len(resp.Prices),
+        "market_caps":   len(resp.MarketCaps),
+        "total_volumes": len(resp.TotalVolumes),
+    })
+
+    timestamps := make([]time.Time, 0, len(resp.Prices))
+    for _, p := range resp.Prices {
+        timestamps = append(timestamps, time.UnixMilli(int64(p[0])))
+        if p[1] <= 0 {
+            v.Add("prices", RuleRange, "price %v is not positive", p[1])
+        }
+    }
+    v.Increasing("prices", timestamps)
+
+    marketCaps := make([]time.Time, 0, len(resp.MarketCaps))
+    for _, m := range resp.MarketCaps {
+        marketCaps = append(marketCaps, time.UnixMilli(int64(m[0])))
+        v.Range("market_caps", m[1], &zero, nil)
+    }
+    v.Aligned("market_caps", "prices", marketCaps, timestamps)
+
+    volumes := make([]time.Time, 0, len(resp.TotalVolumes))
+    for _, t := range resp.TotalVolumes {
+        volumes = append(volumes, time.UnixMilli(int64(t[0])))
+        v.Range("total_volumes", t[1], &zero, nil)
+    }
+    v.Aligned("total_volumes", "prices", volumes, timestamps)
+    return v.Err()
+} 
\ No newline at end of file
@@ -0,0 +1,39 @@
+package validation
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+func TestCoinGeckoValid(t *testing.T) {
+    resp := &models.CoinGeckoResponse{
+        Prices:       [][2]float64{{1000, 10}, {2000, 11}},
+        MarketCaps:   [][2]float64{{1000, 100}, {2000, 110}},
+        TotalVolumes: [][2]float64{{1000, 5}, {2000, 6}},
+    }
+    if err := CoinGecko("coingecko", resp); err != nil {
+        t.Fatalf("CoinGecko: %v", err)
+    }
+}
+
+func TestCoinGeckoMisaligned(t *testing.T) {
+    resp := &models.CoinGeckoResponse{
+        Prices:       [][2]float64{{1000, 10}, {2000, 11}, {3000, 12}},
+        MarketCaps:   [][2]float64{{1000, 100}, {2500, 110}, {3500, 120}},
+        TotalVolumes: [][2]float64{{1000, 5}, {2000, 6}, {3000, 7}},
+    }
+    err := CoinGecko("coingecko", resp)
+    var verr *Error
+    if !errors.As(err, &verr) {
+        t.Fatalf("expected *Error, got %v", err)
+    }
+    if len(verr.Violations) != 1 {
+        t.Fatalf("expected one violation, got %+v", verr.Violations)
+    }
+    got := verr.Violations[0]
+    if got.Field != "market_caps" || got.Rule != RuleAligned || got.Count != 2 {
+        t.Errorf("unexpected violation %+v", got)
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,44 @@
+package validation
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+    "github.com/yourusername/investutil-gocrawler/internal/storage"
+)
+
+// DriftPrefix is the storage prefix of drift events
+const DriftPrefix = "drift"
+
+// NewDriftEvent returns the drift event for err, or nil when err is not a
+// validation failure
+func NewDriftEvent(err error) *models.DriftEvent {
+    var verr *Error
+    if !errors.As(err, &verr) {
+        return nil
+    }
+    return &models.DriftEvent{
+        Source:     verr.Source,
+        DetectedAt: time.Now().UTC(),
+        Violations: verr.Violations,
+    }
+}
+
+// RecordDrift saves a drift event under drift/<source>/<time> when err is a
+// validation failure, and does nothing otherwise
+func RecordDrift(ctx context.Context, s storage.Storage, err error) {
+    event := NewDriftEvent(err)
+    if event == nil {
+        return
+    }
+
+    log.Printf("Upstream drift detected for %s: %v", event.Source, err)
+    key := fmt.Sprintf("%s/%s/%s.json", DriftPrefix, event.Source, event.DetectedAt.Format("20060102T150405.000Z"))
+    if saveErr := s.Save(ctx, key, event); saveErr != nil {
+        log.Printf("Failed to save drift event for %s: %v", event.Source, saveErr)
+    }
+} 
\ No newline at end of file
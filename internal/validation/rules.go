@@ -0,0 +1,53 @@
+package validation
+
+import (
+    "sort"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Bounds limits the values of a field; unset bounds are open
+type Bounds struct {
+    Min *float64 `yaml:"min"`
+    Max *float64 `yaml:"max"`
+}
+
+// Rules declares validation for a configured source
+type Rules struct {
+    // NonEmpty fails responses without any point
+    NonEmpty bool `yaml:"non_empty"`
+    // Increasing requires the points of each field in strictly increasing time order
+    Increasing bool `yaml:"increasing"`
+    // Fields maps a field name to the bounds of its values
+    Fields map[string]Bounds `yaml:"fields"`
+}
+
+// Check applies the rules to the points extracted for each field
+func (r Rules) Check(v *Validator, points map[string][]models.MetricPoint) {
+    fields := make([]string, 0, len(points))
+    total := 0
+    for field, pts := range points {
+        fields = append(fields, field)
+        total += len(pts)
+    }
+    sort.Strings(fields)
+
+    if r.NonEmpty {
+        v.NonEmpty("items", total)
+    }
+    for _, field := range fields {
+        if r.Increasing {
+            timestamps := make([]time.Time, 0, len(points[field]))
+            for _, p := range points[field] {
+                timestamps = append(timestamps, p.Timestamp)
+            }
+            v.Increasing(field, timestamps)
+        }
+        if bounds, ok := r.Fields[field]; ok {
+            for _, p := range points[field] {
+                v.Range(field, p.Value, bounds.Min, bounds.Max)
+            }
+        }
+    }
+} 
\ No newline at end of file
@@ -0,0 +1,151 @@
+package validation
+
+import (
+    "fmt"
+    "math"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/yourusername/investutil-gocrawler/internal/models"
+)
+
+// Rule names used in violations
+const (
+    RuleRequired    = "required"
+    RuleNonEmpty    = "non_empty"
+    RuleEqualLength = "equal_length"
+    RuleIncreasing  = "increasing"
+    RuleAligned     = "aligned"
+    RuleUnique      = "unique"
+    RuleRange       = "range"
+)
+
+// Error is returned when an upstream response fails validation
+type Error struct {
+    Source     string
+    Violations []models.Violation
+}
+
+func (e *Error) Error() string {
+    messages := make([]string, 0, len(e.Violations))
+    for _, v := range e.Violations {
+        msg := v.Message
+        if v.Count > 1 {
+            msg = fmt.Sprintf("%s (and %d more)", msg, v.Count-1)
+        }
+        messages = append(messages, msg)
+    }
+    return fmt.Sprintf("%s: response failed validation: %s", e.Source, strings.Join(messages, "; "))
+}
+
+// Validator collects violations for a single response. Repeated violations
+// of the same rule on the same field are counted, keeping the first message.
+type Validator struct {
+    source     string
+    violations []models.Violation
+    index      map[string]int
+}
+
+// New creates a new Validator for a source
+func New(source string) *Validator {
+    return &Validator{
+        source: source,
+        index:  make(map[string]int),
+    }
+}
+
+// Add records a violation
+func (v *Validator) Add(field, rule, format string, args ...interface{}) {
+    key := field + "\x00" + rule
+    if i, ok := v.index[key]; ok {
+        v.violations[i].Count++
+        return
+    }
+    v.index[key] = len(v.violations)
+    v.violations = append(v.violations, models.Violation{
+        Field:   field,
+        Rule:    rule,
+        Message: fmt.Sprintf("%s: %s", field, fmt.Sprintf(format, args...)),
+        Count:   1,
+    })
+}
+
+// Required checks that a field is present
+func (v *Validator) Required(field string, present bool) {
+    if !present {
+        v.Add(field, RuleRequired, "missing")
+    }
+}
+
+// NonEmpty checks that an array field has at least one element
+func (v *Validator) NonEmpty(field string, n int) {
+    if n == 0 {
+        v.Add(field, RuleNonEmpty, "no items")
+    }
+}
+
+// EqualLength checks that parallel arrays have the same length
+func (v *Validator) EqualLength(lengths map[string]int) {
+    fields := make([]string, 0, len(lengths))
+    for field := range lengths {
+        fields = append(fields, field)
+    }
+    sort.Strings(fields)
+
+    for _, field := range fields[1:] {
+        if lengths[field] != lengths[fields[0]] {
+            v.Add(strings.Join(fields, ","), RuleEqualLength, "lengths differ: %s", formatLengths(fields, lengths))
+            return
+        }
+    }
+}
+
+// Increasing checks that timestamps are strictly increasing
+func (v *Validator) Increasing(field string, timestamps []time.Time) {
+    for i := 1; i < len(timestamps); i++ {
+        if !timestamps[i].After(timestamps[i-1]) {
+            v.Add(field, RuleIncreasing, "timestamp %s at index %d does not follow %s",
+                timestamps[i].Format(time.RFC3339), i, timestamps[i-1].Format(time.RFC3339))
+        }
+    }
+}
+
+// Aligned checks that the timestamps of a parallel array match those of the
+// reference array at every index both have
+func (v *Validator) Aligned(field, reference string, timestamps, want []time.Time) {
+    for i := 0; i < len(timestamps) && i < len(want); i++ {
+        if !timestamps[i].Equal(want[i]) {
+            v.Add(field, RuleAligned, "timestamp %s at index %d does not match %s of %s",
+                timestamps[i].Format(time.RFC3339), i, want[i].Format(time.RFC3339), reference)
+        }
+    }
+}
+
+// Range checks that a value is finite and within the optional bounds
+func (v *Validator) Range(field string, value float64, min, max *float64) {
+    switch {
+    case math.IsNaN(value) || math.IsInf(value, 0):
+        v.Add(field, RuleRange, "value %v is not a number", value)
+    case min != nil && value < *min:
+        v.Add(field, RuleRange, "value %v is below %v", value, *min)
+    case max != nil && value > *max:
+        v.Add(field, RuleRange, "value %v is above %v", value, *max)
+    }
+}
+
+// Err returns an *Error holding the violations, or nil when there are none
+func (v *Validator) Err() error {
+    if len(v.violations) == 0 {
+        return nil
+    }
+    return &Error{Source: v.source, Violations: v.violations}
+}
+
+func formatLengths(fields []string, lengths map[string]int) string {
+    parts := make([]string, 0, len(fields))
+    for _, field := range fields {
+        parts = append(parts, fmt.Sprintf("%s=%d", field, lengths[field]))
+    }
+    return strings.Join(parts, ", ")
+} 
\ No newline at end of file